@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"strings"
 
+	"github.com/onkernel/cli/pkg/create"
 	"github.com/onkernel/cli/pkg/util"
 	"github.com/onkernel/kernel-go-sdk"
 	"github.com/pterm/pterm"
@@ -11,6 +14,29 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// currentAppRef stands for the app scaffolded in the working directory.
+const currentAppRef = "@current"
+
+// resolveAppName expands @current to the app name recorded in the working
+// directory's .kernel/app.json; other names are returned unchanged.
+func resolveAppName(name string) (string, error) {
+	if name != currentAppRef {
+		return name, nil
+	}
+	meta, err := create.ReadAppMetadata(".")
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%s needs %s in the current directory; run from an app created with `kernel create`", currentAppRef, create.AppMetadataPath)
+	}
+	if err != nil {
+		return "", err
+	}
+	appName := meta.DeployedAppName()
+	if appName == "" {
+		return "", fmt.Errorf("%s does not record an app name", create.AppMetadataPath)
+	}
+	return appName, nil
+}
+
 var appCmd = &cobra.Command{
 	Use:     "app",
 	Aliases: []string{"apps"},
@@ -28,7 +54,7 @@ var appListCmd = &cobra.Command{
 // --- app history subcommand (scaffold)
 var appHistoryCmd = &cobra.Command{
 	Use:   "history <app_name>",
-	Short: "Show deployment history for an application",
+	Short: "Show deployment history for an application (use @current for the app in this directory)",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runAppHistory,
 }
@@ -191,7 +217,10 @@ func runAppList(cmd *cobra.Command, args []string) error {
 
 func runAppHistory(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
-	appName := args[0]
+	appName, err := resolveAppName(args[0])
+	if err != nil {
+		return err
+	}
 	lim, _ := cmd.Flags().GetInt("limit")
 
 	pterm.Debug.Printf("Fetching deployment history for app '%s'...\n", appName)
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/onkernel/cli/pkg/util"
@@ -31,11 +35,64 @@ type BrowserPoolsCmd struct {
 
 type BrowserPoolsListInput struct {
 	Output string
+	Count  bool
+	// Sort is one of poolSortKeys; empty keeps the API order.
+	Sort string
+	// MinUtilization and MaxUtilization, when set, keep only pools whose
+	// utilization percentage is within the bound.
+	MinUtilization *float64
+	MaxUtilization *float64
+}
+
+// poolUtilization is the share of the pool's browsers currently acquired, as
+// a percentage.
+func poolUtilization(p kernel.BrowserPool) float64 {
+	if p.BrowserPoolConfig.Size <= 0 {
+		return 0
+	}
+	return float64(p.AcquiredCount) / float64(p.BrowserPoolConfig.Size) * 100
+}
+
+// poolSortKeys are the orderings accepted by `browser-pools list --sort`.
+var poolSortKeys = map[string]func(a, b kernel.BrowserPool) int{
+	"utilization":  func(a, b kernel.BrowserPool) int { return cmp.Compare(poolUtilization(a), poolUtilization(b)) },
+	"-utilization": func(a, b kernel.BrowserPool) int { return cmp.Compare(poolUtilization(b), poolUtilization(a)) },
+	"available":    func(a, b kernel.BrowserPool) int { return cmp.Compare(a.AvailableCount, b.AvailableCount) },
+	"-available":   func(a, b kernel.BrowserPool) int { return cmp.Compare(b.AvailableCount, a.AvailableCount) },
+	"name":         func(a, b kernel.BrowserPool) int { return strings.Compare(a.Name, b.Name) },
+}
+
+func poolSortKeyNames() []string {
+	names := make([]string, 0, len(poolSortKeys))
+	for name := range poolSortKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// filterPoolsByUtilization keeps the pools within the optional bounds.
+func filterPoolsByUtilization(items []kernel.BrowserPool, lo, hi *float64) []kernel.BrowserPool {
+	if lo == nil && hi == nil {
+		return items
+	}
+	var out []kernel.BrowserPool
+	for _, p := range items {
+		u := poolUtilization(p)
+		if (lo == nil || u >= *lo) && (hi == nil || u <= *hi) {
+			out = append(out, p)
+		}
+	}
+	return out
 }
 
 func (c BrowserPoolsCmd) List(ctx context.Context, in BrowserPoolsListInput) error {
-	if in.Output != "" && in.Output != "json" {
-		pterm.Error.Println("unsupported --output value: use 'json'")
+	if !checkOutput(in.Output, outputJSON, outputYAML, outputCSV) {
+		return nil
+	}
+	less := poolSortKeys[in.Sort]
+	if in.Sort != "" && less == nil {
+		pterm.Error.Printf("unknown --sort key %q: use one of %s\n", in.Sort, strings.Join(poolSortKeyNames(), ", "))
 		return nil
 	}
 
@@ -43,30 +100,22 @@ func (c BrowserPoolsCmd) List(ctx context.Context, in BrowserPoolsListInput) err
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-
-	if in.Output == "json" {
-		if pools == nil {
-			fmt.Println("[]")
-			return nil
-		}
-		bs, err := json.MarshalIndent(*pools, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(bs))
-		return nil
+	items := []kernel.BrowserPool{}
+	if pools != nil {
+		items = *pools
 	}
-
-	if pools == nil || len(*pools) == 0 {
-		pterm.Info.Println("No browser pools found")
-		return nil
+	items = filterPoolsByUtilization(items, in.MinUtilization, in.MaxUtilization)
+	if in.Count {
+		return printCount(len(items), in.Output)
+	}
+	if less != nil {
+		slices.SortStableFunc(items, less)
 	}
 
 	tableData := pterm.TableData{
-		{"ID", "Name", "Available", "Acquired", "Created At", "Size"},
+		{"ID", "Name", "Available", "Acquired", "Created At", "Size", "Utilization"},
 	}
-
-	for _, p := range *pools {
+	for _, p := range items {
 		tableData = append(tableData, []string{
 			p.ID,
 			util.OrDash(p.Name),
@@ -74,9 +123,25 @@ func (c BrowserPoolsCmd) List(ctx context.Context, in BrowserPoolsListInput) err
 			fmt.Sprintf("%d", p.AcquiredCount),
 			util.FormatLocal(p.CreatedAt),
 			fmt.Sprintf("%d", p.BrowserPoolConfig.Size),
+			fmt.Sprintf("%.0f%%", poolUtilization(p)),
 		})
 	}
 
+	switch in.Output {
+	case outputJSON, outputYAML:
+		if items == nil {
+			items = []kernel.BrowserPool{}
+		}
+		return printStructured(items, in.Output)
+	case outputCSV:
+		return printCSV(tableData)
+	}
+
+	if len(items) == 0 {
+		pterm.Info.Println("No browser pools found")
+		return nil
+	}
+
 	PrintTableNoPad(tableData, true)
 	return nil
 }
@@ -98,52 +163,11 @@ type BrowserPoolsCreateInput struct {
 }
 
 func (c BrowserPoolsCmd) Create(ctx context.Context, in BrowserPoolsCreateInput) error {
-	params := kernel.BrowserPoolNewParams{
-		Size: in.Size,
-	}
-
-	if in.Name != "" {
-		params.Name = kernel.String(in.Name)
-	}
-	if in.FillRate > 0 {
-		params.FillRatePerMinute = kernel.Int(in.FillRate)
-	}
-	if in.TimeoutSeconds > 0 {
-		params.TimeoutSeconds = kernel.Int(in.TimeoutSeconds)
-	}
-	if in.Stealth.Set {
-		params.Stealth = kernel.Bool(in.Stealth.Value)
-	}
-	if in.Headless.Set {
-		params.Headless = kernel.Bool(in.Headless.Value)
-	}
-	if in.Kiosk.Set {
-		params.KioskMode = kernel.Bool(in.Kiosk.Value)
-	}
-
-	profile, err := buildProfileParam(in.ProfileID, in.ProfileName, in.ProfileSaveChanges)
-	if err != nil {
-		pterm.Error.Println(err.Error())
-		return nil
-	}
-	if profile != nil {
-		params.Profile = *profile
-	}
-
-	if in.ProxyID != "" {
-		params.ProxyID = kernel.String(in.ProxyID)
-	}
-
-	params.Extensions = buildExtensionsParam(in.Extensions)
-
-	viewport, err := buildViewportParam(in.Viewport)
+	params, err := buildPoolNewParams(in)
 	if err != nil {
 		pterm.Error.Println(err.Error())
 		return nil
 	}
-	if viewport != nil {
-		params.Viewport = *viewport
-	}
 
 	pool, err := c.client.New(ctx, params)
 	if err != nil {
@@ -164,8 +188,7 @@ type BrowserPoolsGetInput struct {
 }
 
 func (c BrowserPoolsCmd) Get(ctx context.Context, in BrowserPoolsGetInput) error {
-	if in.Output != "" && in.Output != "json" {
-		pterm.Error.Println("unsupported --output value: use 'json'")
+	if !checkOutput(in.Output, outputJSON, outputYAML) {
 		return nil
 	}
 
@@ -174,13 +197,8 @@ func (c BrowserPoolsCmd) Get(ctx context.Context, in BrowserPoolsGetInput) error
 		return util.CleanedUpSdkError{Err: err}
 	}
 
-	if in.Output == "json" {
-		bs, err := json.MarshalIndent(pool, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(bs))
-		return nil
+	if in.Output != "" {
+		return printStructured(pool, in.Output)
 	}
 
 	cfg := pool.BrowserPoolConfig
@@ -224,59 +242,30 @@ type BrowserPoolsUpdateInput struct {
 	Extensions         []string
 	Viewport           string
 	DiscardAllIdle     BoolFlag
+	DryRun             bool
 }
 
 func (c BrowserPoolsCmd) Update(ctx context.Context, in BrowserPoolsUpdateInput) error {
-	params := kernel.BrowserPoolUpdateParams{}
-
-	if in.Name != "" {
-		params.Name = kernel.String(in.Name)
-	}
-	if in.Size > 0 {
-		params.Size = in.Size
-	}
-	if in.FillRate > 0 {
-		params.FillRatePerMinute = kernel.Int(in.FillRate)
-	}
-	if in.TimeoutSeconds > 0 {
-		params.TimeoutSeconds = kernel.Int(in.TimeoutSeconds)
-	}
-	if in.Stealth.Set {
-		params.Stealth = kernel.Bool(in.Stealth.Value)
-	}
-	if in.Headless.Set {
-		params.Headless = kernel.Bool(in.Headless.Value)
-	}
-	if in.Kiosk.Set {
-		params.KioskMode = kernel.Bool(in.Kiosk.Value)
-	}
-	if in.DiscardAllIdle.Set {
-		params.DiscardAllIdle = kernel.Bool(in.DiscardAllIdle.Value)
+	current, err := c.client.Get(ctx, in.IDOrName)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
 	}
-
-	profile, err := buildProfileParam(in.ProfileID, in.ProfileName, in.ProfileSaveChanges)
+	changes, err := diffPoolUpdate(current, in)
 	if err != nil {
 		pterm.Error.Println(err.Error())
 		return nil
 	}
-	if profile != nil {
-		params.Profile = *profile
-	}
-
-	if in.ProxyID != "" {
-		params.ProxyID = kernel.String(in.ProxyID)
+	printPoolChanges(current, changes)
+	if in.DryRun {
+		pterm.Info.Println("Dry run: no changes applied")
+		return nil
 	}
 
-	params.Extensions = buildExtensionsParam(in.Extensions)
-
-	viewport, err := buildViewportParam(in.Viewport)
+	params, err := buildPoolUpdateParams(in)
 	if err != nil {
 		pterm.Error.Println(err.Error())
 		return nil
 	}
-	if viewport != nil {
-		params.Viewport = *viewport
-	}
 
 	pool, err := c.client.Update(ctx, in.IDOrName, params)
 	if err != nil {
@@ -311,6 +300,9 @@ func (c BrowserPoolsCmd) Delete(ctx context.Context, in BrowserPoolsDeleteInput)
 type BrowserPoolsAcquireInput struct {
 	IDOrName       string
 	TimeoutSeconds int64
+	SaveSession    string
+	// RememberSession records the acquired session as @last.
+	RememberSession bool
 }
 
 func (c BrowserPoolsCmd) Acquire(ctx context.Context, in BrowserPoolsAcquireInput) error {
@@ -334,7 +326,7 @@ func (c BrowserPoolsCmd) Acquire(ctx context.Context, in BrowserPoolsAcquireInpu
 		{"Live View URL", resp.BrowserLiveViewURL},
 	}
 	PrintTableNoPad(tableData, true)
-	return nil
+	return recordSession(resp.SessionID, in.SaveSession, in.RememberSession)
 }
 
 type BrowserPoolsReleaseInput struct {
@@ -371,6 +363,166 @@ func (c BrowserPoolsCmd) Flush(ctx context.Context, in BrowserPoolsFlushInput) e
 	return nil
 }
 
+// poolConfigFile is the pool definition read by `browser-pools ensure`.
+// Keys mirror the browser_pool_config object returned by the API. Keys left
+// out of the file are not sent, so an existing pool keeps its current value.
+type poolConfigFile struct {
+	Size              int64 `json:"size"`
+	FillRatePerMinute int64 `json:"fill_rate_per_minute"`
+	TimeoutSeconds    int64 `json:"timeout_seconds"`
+	Stealth           *bool `json:"stealth"`
+	Headless          *bool `json:"headless"`
+	KioskMode         *bool `json:"kiosk_mode"`
+	Profile           *struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		SaveChanges bool   `json:"save_changes"`
+	} `json:"profile"`
+	ProxyID    string `json:"proxy_id"`
+	Extensions []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"extensions"`
+	Viewport *struct {
+		Width       int64 `json:"width"`
+		Height      int64 `json:"height"`
+		RefreshRate int64 `json:"refresh_rate"`
+	} `json:"viewport"`
+}
+
+func loadPoolConfigFile(path string) (poolConfigFile, error) {
+	var cfg poolConfigFile
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func optBoolFlag(v *bool) BoolFlag {
+	if v == nil {
+		return BoolFlag{}
+	}
+	return BoolFlag{Set: true, Value: *v}
+}
+
+// toCreateInput converts the config into the same input used by `browser-pools create`.
+func (f poolConfigFile) toCreateInput(name string) BrowserPoolsCreateInput {
+	in := BrowserPoolsCreateInput{
+		Name:           name,
+		Size:           f.Size,
+		FillRate:       f.FillRatePerMinute,
+		TimeoutSeconds: f.TimeoutSeconds,
+		Stealth:        optBoolFlag(f.Stealth),
+		Headless:       optBoolFlag(f.Headless),
+		Kiosk:          optBoolFlag(f.KioskMode),
+		ProxyID:        f.ProxyID,
+	}
+	if f.Profile != nil {
+		in.ProfileID = f.Profile.ID
+		in.ProfileName = f.Profile.Name
+		in.ProfileSaveChanges = BoolFlag{Set: true, Value: f.Profile.SaveChanges}
+	}
+	for _, ext := range f.Extensions {
+		if v := util.FirstOrDash(ext.ID, ext.Name); v != "-" {
+			in.Extensions = append(in.Extensions, v)
+		}
+	}
+	if f.Viewport != nil && f.Viewport.Width > 0 && f.Viewport.Height > 0 {
+		in.Viewport = formatViewport(kernel.BrowserViewport{Width: f.Viewport.Width, Height: f.Viewport.Height, RefreshRate: f.Viewport.RefreshRate})
+	}
+	return in
+}
+
+// toUpdateInput converts the config into the same input used by `browser-pools update`.
+func (f poolConfigFile) toUpdateInput(idOrName string) BrowserPoolsUpdateInput {
+	c := f.toCreateInput("")
+	return BrowserPoolsUpdateInput{
+		IDOrName:           idOrName,
+		Size:               c.Size,
+		FillRate:           c.FillRate,
+		TimeoutSeconds:     c.TimeoutSeconds,
+		Stealth:            c.Stealth,
+		Headless:           c.Headless,
+		Kiosk:              c.Kiosk,
+		ProfileID:          c.ProfileID,
+		ProfileName:        c.ProfileName,
+		ProfileSaveChanges: c.ProfileSaveChanges,
+		ProxyID:            c.ProxyID,
+		Extensions:         c.Extensions,
+		Viewport:           c.Viewport,
+	}
+}
+
+type BrowserPoolsEnsureInput struct {
+	Name   string
+	Config poolConfigFile
+	DryRun bool
+}
+
+// Ensure creates the named pool if it does not exist, or otherwise merges the
+// config into it: only settings present in the config are updated, and the
+// rest keep their server-side values. Pools that already match are left
+// untouched.
+func (c BrowserPoolsCmd) Ensure(ctx context.Context, in BrowserPoolsEnsureInput) error {
+	current, err := c.client.Get(ctx, in.Name)
+	if err != nil && !util.IsNotFound(err) {
+		return util.CleanedUpSdkError{Err: err}
+	}
+
+	if util.IsNotFound(err) {
+		createIn := in.Config.toCreateInput(in.Name)
+		if createIn.Size <= 0 {
+			pterm.Error.Println("config must set size to create a pool")
+			return nil
+		}
+		params, err := buildPoolNewParams(createIn)
+		if err != nil {
+			pterm.Error.Println(err.Error())
+			return nil
+		}
+		if in.DryRun {
+			pterm.Info.Printf("Dry run: browser pool %s would be created\n", in.Name)
+			return nil
+		}
+		pool, err := c.client.New(ctx, params)
+		if err != nil {
+			return util.CleanedUpSdkError{Err: err}
+		}
+		pterm.Success.Printf("Created browser pool %s (%s)\n", in.Name, pool.ID)
+		return nil
+	}
+
+	updateIn := in.Config.toUpdateInput(in.Name)
+	changes, err := diffPoolUpdate(current, updateIn)
+	if err != nil {
+		pterm.Error.Println(err.Error())
+		return nil
+	}
+	if len(changes) == 0 {
+		pterm.Success.Printf("Browser pool %s is unchanged\n", in.Name)
+		return nil
+	}
+	printPoolChanges(current, changes)
+	if in.DryRun {
+		pterm.Info.Printf("Dry run: browser pool %s would be updated\n", in.Name)
+		return nil
+	}
+	params, err := buildPoolUpdateParams(updateIn)
+	if err != nil {
+		pterm.Error.Println(err.Error())
+		return nil
+	}
+	if _, err := c.client.Update(ctx, in.Name, params); err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	pterm.Success.Printf("Updated browser pool %s (%s)\n", in.Name, current.ID)
+	return nil
+}
+
 var browserPoolsCmd = &cobra.Command{
 	Use:     "browser-pools",
 	Aliases: []string{"browser-pool", "pool", "pools"},
@@ -432,46 +584,66 @@ var browserPoolsFlushCmd = &cobra.Command{
 	RunE:  runBrowserPoolsFlush,
 }
 
+var browserPoolsEnsureCmd = &cobra.Command{
+	Use:   "ensure",
+	Short: "Create a browser pool or merge a config file into it",
+	Long:  "Creates the named pool if it does not exist. If it does, only the settings present in the config are updated; settings left out of the config keep their current values and are not reset to defaults. Reports whether the pool was created, updated, or unchanged.",
+	Args:  cobra.NoArgs,
+	RunE:  runBrowserPoolsEnsure,
+}
+
 func init() {
-	browserPoolsListCmd.Flags().StringP("output", "o", "", "Output format: json for raw API response")
+	browserPoolsListCmd.Flags().StringP("output", "o", "", "Output format: json or yaml for raw API response, csv for the table columns")
+	browserPoolsListCmd.Flags().Bool("count", false, "Print only the number of pools")
+	browserPoolsListCmd.Flags().String("sort", "", "Sort by utilization, available or name (prefix with - to reverse, e.g. -utilization)")
+	browserPoolsListCmd.Flags().Float64("min-utilization", 0, "Only show pools at or above this utilization percentage")
+	browserPoolsListCmd.Flags().Float64("max-utilization", 0, "Only show pools at or below this utilization percentage")
 
 	browserPoolsCreateCmd.Flags().String("name", "", "Optional unique name for the pool")
 	browserPoolsCreateCmd.Flags().Int64("size", 0, "Number of browsers in the pool")
 	_ = browserPoolsCreateCmd.MarkFlagRequired("size")
 	browserPoolsCreateCmd.Flags().Int64("fill-rate", 0, "Fill rate per minute")
-	browserPoolsCreateCmd.Flags().Int64("timeout", 0, "Idle timeout in seconds")
+	addSecondsFlag(browserPoolsCreateCmd, "timeout", "", 0, maxBrowserTimeout, "Idle timeout, in seconds or as a duration (e.g. 90m, 2h)")
 	browserPoolsCreateCmd.Flags().Bool("stealth", false, "Enable stealth mode")
 	browserPoolsCreateCmd.Flags().Bool("headless", false, "Enable headless mode")
 	browserPoolsCreateCmd.Flags().Bool("kiosk", false, "Enable kiosk mode")
 	browserPoolsCreateCmd.Flags().String("profile-id", "", "Profile ID")
 	browserPoolsCreateCmd.Flags().String("profile-name", "", "Profile name")
-	browserPoolsCreateCmd.Flags().Bool("save-changes", false, "Save changes to profile")
+	addSaveChangesFlags(browserPoolsCreateCmd, "Save changes to profile")
 	browserPoolsCreateCmd.Flags().String("proxy-id", "", "Proxy ID")
 	browserPoolsCreateCmd.Flags().StringSlice("extension", []string{}, "Extension IDs or names")
 	browserPoolsCreateCmd.Flags().String("viewport", "", "Viewport size (e.g. 1280x800)")
 
-	browserPoolsGetCmd.Flags().StringP("output", "o", "", "Output format: json for raw API response")
+	browserPoolsGetCmd.Flags().StringP("output", "o", "", "Output format: json or yaml for raw API response")
 
 	browserPoolsUpdateCmd.Flags().String("name", "", "Update the pool name")
 	browserPoolsUpdateCmd.Flags().Int64("size", 0, "Number of browsers in the pool")
 	browserPoolsUpdateCmd.Flags().Int64("fill-rate", 0, "Fill rate per minute")
-	browserPoolsUpdateCmd.Flags().Int64("timeout", 0, "Idle timeout in seconds")
+	addSecondsFlag(browserPoolsUpdateCmd, "timeout", "", 0, maxBrowserTimeout, "Idle timeout, in seconds or as a duration (e.g. 90m, 2h)")
 	browserPoolsUpdateCmd.Flags().Bool("stealth", false, "Enable stealth mode")
 	browserPoolsUpdateCmd.Flags().Bool("headless", false, "Enable headless mode")
 	browserPoolsUpdateCmd.Flags().Bool("kiosk", false, "Enable kiosk mode")
 	browserPoolsUpdateCmd.Flags().String("profile-id", "", "Profile ID")
 	browserPoolsUpdateCmd.Flags().String("profile-name", "", "Profile name")
-	browserPoolsUpdateCmd.Flags().Bool("save-changes", false, "Save changes to profile")
+	addSaveChangesFlags(browserPoolsUpdateCmd, "Save changes to profile")
 	browserPoolsUpdateCmd.Flags().String("proxy-id", "", "Proxy ID")
 	browserPoolsUpdateCmd.Flags().StringSlice("extension", []string{}, "Extension IDs or names")
 	browserPoolsUpdateCmd.Flags().String("viewport", "", "Viewport size (e.g. 1280x800)")
 	browserPoolsUpdateCmd.Flags().Bool("discard-all-idle", false, "Discard all idle browsers")
+	browserPoolsUpdateCmd.Flags().Bool("dry-run", false, "Show the changes that would be applied without updating the pool")
+
+	browserPoolsEnsureCmd.Flags().String("name", "", "Name of the pool to create or update")
+	_ = browserPoolsEnsureCmd.MarkFlagRequired("name")
+	browserPoolsEnsureCmd.Flags().String("config", "", "Path to a JSON pool config (same keys as browser_pool_config); omitted keys are left unchanged")
+	_ = browserPoolsEnsureCmd.MarkFlagRequired("config")
+	browserPoolsEnsureCmd.Flags().Bool("dry-run", false, "Show what would change without creating or updating the pool")
 
 	browserPoolsDeleteCmd.Flags().Bool("force", false, "Force delete even if browsers are leased")
 
 	browserPoolsAcquireCmd.Flags().Int64("timeout", 0, "Acquire timeout in seconds")
+	browserPoolsAcquireCmd.Flags().String("save-session", "", "Write the acquired session ID to this file")
 
-	browserPoolsReleaseCmd.Flags().String("session-id", "", "Browser session ID to release")
+	browserPoolsReleaseCmd.Flags().String("session-id", "", "Browser session ID to release (or @last)")
 	_ = browserPoolsReleaseCmd.MarkFlagRequired("session-id")
 	browserPoolsReleaseCmd.Flags().Bool("reuse", true, "Reuse the browser instance")
 
@@ -483,13 +655,25 @@ func init() {
 	browserPoolsCmd.AddCommand(browserPoolsAcquireCmd)
 	browserPoolsCmd.AddCommand(browserPoolsReleaseCmd)
 	browserPoolsCmd.AddCommand(browserPoolsFlushCmd)
+	browserPoolsCmd.AddCommand(browserPoolsEnsureCmd)
 }
 
 func runBrowserPoolsList(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	out, _ := cmd.Flags().GetString("output")
+	count, _ := cmd.Flags().GetBool("count")
+	sortKey, _ := cmd.Flags().GetString("sort")
+	in := BrowserPoolsListInput{Output: out, Count: count, Sort: sortKey}
+	if cmd.Flags().Changed("min-utilization") {
+		v, _ := cmd.Flags().GetFloat64("min-utilization")
+		in.MinUtilization = &v
+	}
+	if cmd.Flags().Changed("max-utilization") {
+		v, _ := cmd.Flags().GetFloat64("max-utilization")
+		in.MaxUtilization = &v
+	}
 	c := BrowserPoolsCmd{client: &client.BrowserPools}
-	return c.List(cmd.Context(), BrowserPoolsListInput{Output: out})
+	return c.List(cmd.Context(), in)
 }
 
 func runBrowserPoolsCreate(cmd *cobra.Command, args []string) error {
@@ -498,13 +682,12 @@ func runBrowserPoolsCreate(cmd *cobra.Command, args []string) error {
 	name, _ := cmd.Flags().GetString("name")
 	size, _ := cmd.Flags().GetInt64("size")
 	fillRate, _ := cmd.Flags().GetInt64("fill-rate")
-	timeout, _ := cmd.Flags().GetInt64("timeout")
+	timeout := getSeconds(cmd, "timeout")
 	stealth, _ := cmd.Flags().GetBool("stealth")
 	headless, _ := cmd.Flags().GetBool("headless")
 	kiosk, _ := cmd.Flags().GetBool("kiosk")
 	profileID, _ := cmd.Flags().GetString("profile-id")
 	profileName, _ := cmd.Flags().GetString("profile-name")
-	saveChanges, _ := cmd.Flags().GetBool("save-changes")
 	proxyID, _ := cmd.Flags().GetString("proxy-id")
 	extensions, _ := cmd.Flags().GetStringSlice("extension")
 	viewport, _ := cmd.Flags().GetString("viewport")
@@ -519,7 +702,7 @@ func runBrowserPoolsCreate(cmd *cobra.Command, args []string) error {
 		Kiosk:              BoolFlag{Set: cmd.Flags().Changed("kiosk"), Value: kiosk},
 		ProfileID:          profileID,
 		ProfileName:        profileName,
-		ProfileSaveChanges: BoolFlag{Set: cmd.Flags().Changed("save-changes"), Value: saveChanges},
+		ProfileSaveChanges: getSaveChangesFlag(cmd),
 		ProxyID:            proxyID,
 		Extensions:         extensions,
 		Viewport:           viewport,
@@ -542,17 +725,17 @@ func runBrowserPoolsUpdate(cmd *cobra.Command, args []string) error {
 	name, _ := cmd.Flags().GetString("name")
 	size, _ := cmd.Flags().GetInt64("size")
 	fillRate, _ := cmd.Flags().GetInt64("fill-rate")
-	timeout, _ := cmd.Flags().GetInt64("timeout")
+	timeout := getSeconds(cmd, "timeout")
 	stealth, _ := cmd.Flags().GetBool("stealth")
 	headless, _ := cmd.Flags().GetBool("headless")
 	kiosk, _ := cmd.Flags().GetBool("kiosk")
 	profileID, _ := cmd.Flags().GetString("profile-id")
 	profileName, _ := cmd.Flags().GetString("profile-name")
-	saveChanges, _ := cmd.Flags().GetBool("save-changes")
 	proxyID, _ := cmd.Flags().GetString("proxy-id")
 	extensions, _ := cmd.Flags().GetStringSlice("extension")
 	viewport, _ := cmd.Flags().GetString("viewport")
 	discardIdle, _ := cmd.Flags().GetBool("discard-all-idle")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 	in := BrowserPoolsUpdateInput{
 		IDOrName:           args[0],
@@ -565,11 +748,12 @@ func runBrowserPoolsUpdate(cmd *cobra.Command, args []string) error {
 		Kiosk:              BoolFlag{Set: cmd.Flags().Changed("kiosk"), Value: kiosk},
 		ProfileID:          profileID,
 		ProfileName:        profileName,
-		ProfileSaveChanges: BoolFlag{Set: cmd.Flags().Changed("save-changes"), Value: saveChanges},
+		ProfileSaveChanges: getSaveChangesFlag(cmd),
 		ProxyID:            proxyID,
 		Extensions:         extensions,
 		Viewport:           viewport,
 		DiscardAllIdle:     BoolFlag{Set: cmd.Flags().Changed("discard-all-idle"), Value: discardIdle},
+		DryRun:             dryRun,
 	}
 
 	c := BrowserPoolsCmd{client: &client.BrowserPools}
@@ -586,13 +770,18 @@ func runBrowserPoolsDelete(cmd *cobra.Command, args []string) error {
 func runBrowserPoolsAcquire(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	timeout, _ := cmd.Flags().GetInt64("timeout")
+	saveSession, _ := cmd.Flags().GetString("save-session")
 	c := BrowserPoolsCmd{client: &client.BrowserPools}
-	return c.Acquire(cmd.Context(), BrowserPoolsAcquireInput{IDOrName: args[0], TimeoutSeconds: timeout})
+	return c.Acquire(cmd.Context(), BrowserPoolsAcquireInput{IDOrName: args[0], TimeoutSeconds: timeout, SaveSession: saveSession, RememberSession: true})
 }
 
 func runBrowserPoolsRelease(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	sessionID, _ := cmd.Flags().GetString("session-id")
+	sessionID, err := resolveSessionRef(sessionID)
+	if err != nil {
+		return err
+	}
 	reuse, _ := cmd.Flags().GetBool("reuse")
 	c := BrowserPoolsCmd{client: &client.BrowserPools}
 	return c.Release(cmd.Context(), BrowserPoolsReleaseInput{
@@ -608,6 +797,120 @@ func runBrowserPoolsFlush(cmd *cobra.Command, args []string) error {
 	return c.Flush(cmd.Context(), BrowserPoolsFlushInput{IDOrName: args[0]})
 }
 
+func runBrowserPoolsEnsure(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	name, _ := cmd.Flags().GetString("name")
+	configPath, _ := cmd.Flags().GetString("config")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	cfg, err := loadPoolConfigFile(configPath)
+	if err != nil {
+		pterm.Error.Println(err.Error())
+		return nil
+	}
+	c := BrowserPoolsCmd{client: &client.BrowserPools}
+	return c.Ensure(cmd.Context(), BrowserPoolsEnsureInput{Name: name, Config: cfg, DryRun: dryRun})
+}
+
+func buildPoolNewParams(in BrowserPoolsCreateInput) (kernel.BrowserPoolNewParams, error) {
+	params := kernel.BrowserPoolNewParams{
+		Size: in.Size,
+	}
+
+	if in.Name != "" {
+		params.Name = kernel.String(in.Name)
+	}
+	if in.FillRate > 0 {
+		params.FillRatePerMinute = kernel.Int(in.FillRate)
+	}
+	if in.TimeoutSeconds > 0 {
+		params.TimeoutSeconds = kernel.Int(in.TimeoutSeconds)
+	}
+	if in.Stealth.Set {
+		params.Stealth = kernel.Bool(in.Stealth.Value)
+	}
+	if in.Headless.Set {
+		params.Headless = kernel.Bool(in.Headless.Value)
+	}
+	if in.Kiosk.Set {
+		params.KioskMode = kernel.Bool(in.Kiosk.Value)
+	}
+
+	profile, err := buildProfileParam(in.ProfileID, in.ProfileName, in.ProfileSaveChanges)
+	if err != nil {
+		return params, err
+	}
+	if profile != nil {
+		params.Profile = *profile
+	}
+
+	if in.ProxyID != "" {
+		params.ProxyID = kernel.String(in.ProxyID)
+	}
+
+	params.Extensions = buildExtensionsParam(in.Extensions)
+
+	viewport, err := buildViewportParam(in.Viewport)
+	if err != nil {
+		return params, err
+	}
+	if viewport != nil {
+		params.Viewport = *viewport
+	}
+	return params, nil
+}
+
+func buildPoolUpdateParams(in BrowserPoolsUpdateInput) (kernel.BrowserPoolUpdateParams, error) {
+	params := kernel.BrowserPoolUpdateParams{}
+
+	if in.Name != "" {
+		params.Name = kernel.String(in.Name)
+	}
+	if in.Size > 0 {
+		params.Size = in.Size
+	}
+	if in.FillRate > 0 {
+		params.FillRatePerMinute = kernel.Int(in.FillRate)
+	}
+	if in.TimeoutSeconds > 0 {
+		params.TimeoutSeconds = kernel.Int(in.TimeoutSeconds)
+	}
+	if in.Stealth.Set {
+		params.Stealth = kernel.Bool(in.Stealth.Value)
+	}
+	if in.Headless.Set {
+		params.Headless = kernel.Bool(in.Headless.Value)
+	}
+	if in.Kiosk.Set {
+		params.KioskMode = kernel.Bool(in.Kiosk.Value)
+	}
+	if in.DiscardAllIdle.Set {
+		params.DiscardAllIdle = kernel.Bool(in.DiscardAllIdle.Value)
+	}
+
+	profile, err := buildProfileParam(in.ProfileID, in.ProfileName, in.ProfileSaveChanges)
+	if err != nil {
+		return params, err
+	}
+	if profile != nil {
+		params.Profile = *profile
+	}
+
+	if in.ProxyID != "" {
+		params.ProxyID = kernel.String(in.ProxyID)
+	}
+
+	params.Extensions = buildExtensionsParam(in.Extensions)
+
+	viewport, err := buildViewportParam(in.Viewport)
+	if err != nil {
+		return params, err
+	}
+	if viewport != nil {
+		params.Viewport = *viewport
+	}
+	return params, nil
+}
+
 func buildProfileParam(profileID, profileName string, saveChanges BoolFlag) (*kernel.BrowserProfileParam, error) {
 	if profileID != "" && profileName != "" {
 		return nil, fmt.Errorf("must specify at most one of --profile-id or --profile-name")
@@ -707,3 +1010,107 @@ func formatViewport(viewport kernel.BrowserViewport) string {
 	}
 	return s
 }
+
+// poolChange describes a single pool field whose value would change on update.
+type poolChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// diffPoolUpdate compares the current pool against the fields set in an
+// update and returns only the fields whose values differ.
+func diffPoolUpdate(pool *kernel.BrowserPool, in BrowserPoolsUpdateInput) ([]poolChange, error) {
+	cfg := pool.BrowserPoolConfig
+	var changes []poolChange
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, poolChange{Field: field, From: from, To: to})
+		}
+	}
+
+	if in.Name != "" {
+		add("Name", util.OrDash(pool.Name), in.Name)
+	}
+	if in.Size > 0 {
+		add("Size", fmt.Sprintf("%d", cfg.Size), fmt.Sprintf("%d", in.Size))
+	}
+	if in.FillRate > 0 {
+		add("Fill Rate", formatFillRate(cfg.FillRatePerMinute), formatFillRate(in.FillRate))
+	}
+	if in.TimeoutSeconds > 0 {
+		add("Timeout", fmt.Sprintf("%d seconds", cfg.TimeoutSeconds), fmt.Sprintf("%d seconds", in.TimeoutSeconds))
+	}
+	if in.Stealth.Set {
+		add("Stealth", fmt.Sprintf("%t", cfg.Stealth), fmt.Sprintf("%t", in.Stealth.Value))
+	}
+	if in.Headless.Set {
+		add("Headless", fmt.Sprintf("%t", cfg.Headless), fmt.Sprintf("%t", in.Headless.Value))
+	}
+	if in.Kiosk.Set {
+		add("Kiosk Mode", fmt.Sprintf("%t", cfg.KioskMode), fmt.Sprintf("%t", in.Kiosk.Value))
+	}
+	if in.ProfileID != "" || in.ProfileName != "" {
+		cur := cfg.Profile
+		same := cur.SaveChanges == in.ProfileSaveChanges.Value &&
+			((in.ProfileID != "" && in.ProfileID == cur.ID) || (in.ProfileName != "" && in.ProfileName == cur.Name))
+		if !same {
+			want := kernel.BrowserProfile{ID: in.ProfileID, Name: in.ProfileName, SaveChanges: in.ProfileSaveChanges.Value}
+			add("Profile", formatProfile(cur), formatProfile(want))
+		}
+	}
+	if in.ProxyID != "" {
+		add("Proxy ID", util.OrDash(cfg.ProxyID), in.ProxyID)
+	}
+	if want := buildExtensionsParam(in.Extensions); len(want) > 0 && !extensionsMatch(cfg.Extensions, want) {
+		var names []string
+		for _, ext := range want {
+			names = append(names, ext.ID.Or(ext.Name.Value))
+		}
+		add("Extensions", formatExtensions(cfg.Extensions), strings.Join(names, ", "))
+	}
+	if in.Viewport != "" {
+		width, height, refreshRate, err := parseViewport(in.Viewport)
+		if err != nil {
+			return nil, fmt.Errorf("invalid viewport format: %v", err)
+		}
+		cur := cfg.Viewport
+		if cur.Width != width || cur.Height != height || (refreshRate > 0 && cur.RefreshRate != refreshRate) {
+			add("Viewport", formatViewport(cur), formatViewport(kernel.BrowserViewport{Width: width, Height: height, RefreshRate: refreshRate}))
+		}
+	}
+	return changes, nil
+}
+
+// extensionsMatch reports whether the requested extensions are exactly the
+// ones currently configured, matching each by ID or name.
+func extensionsMatch(current []kernel.BrowserExtension, want []kernel.BrowserExtensionParam) bool {
+	if len(current) != len(want) {
+		return false
+	}
+	for _, w := range want {
+		found := false
+		for _, c := range current {
+			if (w.ID.Valid() && w.ID.Value == c.ID) || (w.Name.Valid() && w.Name.Value == c.Name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func printPoolChanges(pool *kernel.BrowserPool, changes []poolChange) {
+	label := util.FirstOrDash(pool.Name, pool.ID)
+	if len(changes) == 0 {
+		pterm.Info.Printf("No configuration changes for browser pool %s\n", label)
+		return
+	}
+	pterm.Info.Printf("Changes to browser pool %s:\n", label)
+	for _, ch := range changes {
+		pterm.Printf("  %s: %s -> %s\n", ch.Field, pterm.Red(ch.From), pterm.Green(ch.To))
+	}
+}
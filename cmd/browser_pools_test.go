@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/onkernel/kernel-go-sdk"
+	"github.com/onkernel/kernel-go-sdk/option"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeBrowserPoolsService is a configurable fake implementing BrowserPoolsService.
+type FakeBrowserPoolsService struct {
+	ListFunc    func(ctx context.Context, opts ...option.RequestOption) (*[]kernel.BrowserPool, error)
+	NewFunc     func(ctx context.Context, body kernel.BrowserPoolNewParams, opts ...option.RequestOption) (*kernel.BrowserPool, error)
+	GetFunc     func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserPool, error)
+	UpdateFunc  func(ctx context.Context, id string, body kernel.BrowserPoolUpdateParams, opts ...option.RequestOption) (*kernel.BrowserPool, error)
+	DeleteFunc  func(ctx context.Context, id string, body kernel.BrowserPoolDeleteParams, opts ...option.RequestOption) error
+	AcquireFunc func(ctx context.Context, id string, body kernel.BrowserPoolAcquireParams, opts ...option.RequestOption) (*kernel.BrowserPoolAcquireResponse, error)
+	ReleaseFunc func(ctx context.Context, id string, body kernel.BrowserPoolReleaseParams, opts ...option.RequestOption) error
+	FlushFunc   func(ctx context.Context, id string, opts ...option.RequestOption) error
+}
+
+func (f *FakeBrowserPoolsService) List(ctx context.Context, opts ...option.RequestOption) (*[]kernel.BrowserPool, error) {
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, opts...)
+	}
+	empty := []kernel.BrowserPool{}
+	return &empty, nil
+}
+func (f *FakeBrowserPoolsService) New(ctx context.Context, body kernel.BrowserPoolNewParams, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+	if f.NewFunc != nil {
+		return f.NewFunc(ctx, body, opts...)
+	}
+	return &kernel.BrowserPool{ID: "pool-new"}, nil
+}
+func (f *FakeBrowserPoolsService) Get(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, id, opts...)
+	}
+	return nil, &kernel.Error{StatusCode: http.StatusNotFound}
+}
+func (f *FakeBrowserPoolsService) Update(ctx context.Context, id string, body kernel.BrowserPoolUpdateParams, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, id, body, opts...)
+	}
+	return &kernel.BrowserPool{ID: id}, nil
+}
+func (f *FakeBrowserPoolsService) Delete(ctx context.Context, id string, body kernel.BrowserPoolDeleteParams, opts ...option.RequestOption) error {
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, id, body, opts...)
+	}
+	return nil
+}
+func (f *FakeBrowserPoolsService) Acquire(ctx context.Context, id string, body kernel.BrowserPoolAcquireParams, opts ...option.RequestOption) (*kernel.BrowserPoolAcquireResponse, error) {
+	if f.AcquireFunc != nil {
+		return f.AcquireFunc(ctx, id, body, opts...)
+	}
+	return &kernel.BrowserPoolAcquireResponse{}, nil
+}
+func (f *FakeBrowserPoolsService) Release(ctx context.Context, id string, body kernel.BrowserPoolReleaseParams, opts ...option.RequestOption) error {
+	if f.ReleaseFunc != nil {
+		return f.ReleaseFunc(ctx, id, body, opts...)
+	}
+	return nil
+}
+func (f *FakeBrowserPoolsService) Flush(ctx context.Context, id string, opts ...option.RequestOption) error {
+	if f.FlushFunc != nil {
+		return f.FlushFunc(ctx, id, opts...)
+	}
+	return nil
+}
+
+func newFakePool(name string, size int64) *kernel.BrowserPool {
+	return &kernel.BrowserPool{
+		ID:   "pool_1",
+		Name: name,
+		BrowserPoolConfig: kernel.BrowserPoolBrowserPoolConfig{
+			Size:           size,
+			TimeoutSeconds: 600,
+			Headless:       true,
+		},
+	}
+}
+
+func samplePools() []kernel.BrowserPool {
+	pool := func(id, name string, size, acquired int64) kernel.BrowserPool {
+		p := kernel.BrowserPool{ID: id, Name: name, AcquiredCount: acquired, AvailableCount: size - acquired}
+		p.BrowserPoolConfig.Size = size
+		return p
+	}
+	return []kernel.BrowserPool{
+		pool("p1", "alpha", 10, 5),
+		pool("p2", "beta", 4, 4),
+		pool("p3", "gamma", 0, 0),
+		pool("p4", "delta", 5, 1),
+	}
+}
+
+func TestPoolUtilization(t *testing.T) {
+	pools := samplePools()
+	assert.Equal(t, 50.0, poolUtilization(pools[0]))
+	assert.Equal(t, 100.0, poolUtilization(pools[1]))
+	assert.Equal(t, 0.0, poolUtilization(pools[2]))
+	assert.Equal(t, 20.0, poolUtilization(pools[3]))
+}
+
+func TestBrowserPoolsList_SortAndFilterByUtilization(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := &FakeBrowserPoolsService{ListFunc: func(ctx context.Context, opts ...option.RequestOption) (*[]kernel.BrowserPool, error) {
+		pools := samplePools()
+		return &pools, nil
+	}}
+	c := BrowserPoolsCmd{client: fake}
+	minU := 20.0
+	out := captureStdout(t, func() {
+		assert.NoError(t, c.List(context.Background(), BrowserPoolsListInput{Output: "csv", Sort: "-utilization", MinUtilization: &minU}))
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Equal(t, "ID,Name,Available,Acquired,Created At,Size,Utilization", lines[0])
+	var ids []string
+	for _, line := range lines[1:] {
+		ids = append(ids, strings.SplitN(line, ",", 2)[0])
+	}
+	assert.Equal(t, []string{"p2", "p1", "p4"}, ids)
+	assert.True(t, strings.HasSuffix(lines[1], ",100%"))
+
+	maxU := 0.0
+	out = captureStdout(t, func() {
+		assert.NoError(t, c.List(context.Background(), BrowserPoolsListInput{Count: true, MaxUtilization: &maxU}))
+	})
+	assert.Equal(t, "1", strings.TrimSpace(out))
+}
+
+func TestBrowserPoolsUpdate_PrintsDiffOfChangedFieldsOnly(t *testing.T) {
+	setupStdoutCapture(t)
+	updated := false
+	fake := &FakeBrowserPoolsService{
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+			return newFakePool("prod", 5), nil
+		},
+		UpdateFunc: func(ctx context.Context, id string, body kernel.BrowserPoolUpdateParams, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+			updated = true
+			assert.Equal(t, int64(10), body.Size)
+			return &kernel.BrowserPool{ID: "pool_1", Name: "prod"}, nil
+		},
+	}
+	c := BrowserPoolsCmd{client: fake}
+	err := c.Update(context.Background(), BrowserPoolsUpdateInput{
+		IDOrName: "prod",
+		Size:     10,
+		Headless: BoolFlag{Set: true, Value: true},
+	})
+	assert.NoError(t, err)
+	assert.True(t, updated)
+
+	out := pterm.RemoveColorFromString(outBuf.String())
+	assert.Contains(t, out, "Size: 5 -> 10")
+	assert.NotContains(t, out, "Headless:")
+	assert.NotContains(t, out, "Timeout:")
+	assert.Contains(t, out, "Updated browser pool prod")
+}
+
+func TestBrowserPoolsUpdate_DryRunDoesNotApply(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := &FakeBrowserPoolsService{
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+			return newFakePool("prod", 5), nil
+		},
+		UpdateFunc: func(ctx context.Context, id string, body kernel.BrowserPoolUpdateParams, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+			t.Fatal("Update should not be called on dry run")
+			return nil, nil
+		},
+	}
+	c := BrowserPoolsCmd{client: fake}
+	err := c.Update(context.Background(), BrowserPoolsUpdateInput{IDOrName: "prod", Size: 10, DryRun: true})
+	assert.NoError(t, err)
+
+	out := pterm.RemoveColorFromString(outBuf.String())
+	assert.Contains(t, out, "Size: 5 -> 10")
+	assert.Contains(t, out, "Dry run")
+}
+
+func TestBrowserPoolsEnsure_CreatesWhenNotFound(t *testing.T) {
+	setupStdoutCapture(t)
+	var created kernel.BrowserPoolNewParams
+	fake := &FakeBrowserPoolsService{
+		NewFunc: func(ctx context.Context, body kernel.BrowserPoolNewParams, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+			created = body
+			return &kernel.BrowserPool{ID: "pool_new", Name: "prod"}, nil
+		},
+		UpdateFunc: func(ctx context.Context, id string, body kernel.BrowserPoolUpdateParams, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+			t.Fatal("Update should not be called when the pool does not exist")
+			return nil, nil
+		},
+	}
+	headless := true
+	c := BrowserPoolsCmd{client: fake}
+	err := c.Ensure(context.Background(), BrowserPoolsEnsureInput{Name: "prod", Config: poolConfigFile{Size: 3, Headless: &headless}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), created.Size)
+	assert.Equal(t, "prod", created.Name.Value)
+	assert.True(t, created.Headless.Value)
+	assert.Contains(t, outBuf.String(), "Created browser pool prod")
+}
+
+func TestBrowserPoolsEnsure_UpdatesWhenDifferent(t *testing.T) {
+	setupStdoutCapture(t)
+	var updatedID string
+	var updated kernel.BrowserPoolUpdateParams
+	fake := &FakeBrowserPoolsService{
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+			return newFakePool("prod", 5), nil
+		},
+		NewFunc: func(ctx context.Context, body kernel.BrowserPoolNewParams, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+			t.Fatal("New should not be called when the pool exists")
+			return nil, nil
+		},
+		UpdateFunc: func(ctx context.Context, id string, body kernel.BrowserPoolUpdateParams, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+			updatedID = id
+			updated = body
+			return &kernel.BrowserPool{ID: "pool_1", Name: "prod"}, nil
+		},
+	}
+	c := BrowserPoolsCmd{client: fake}
+	err := c.Ensure(context.Background(), BrowserPoolsEnsureInput{Name: "prod", Config: poolConfigFile{Size: 8, TimeoutSeconds: 600}})
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", updatedID)
+	assert.Equal(t, int64(8), updated.Size)
+	// Keys missing from the config are merged, not reset.
+	assert.False(t, updated.Headless.Valid())
+	assert.False(t, updated.Stealth.Valid())
+
+	out := pterm.RemoveColorFromString(outBuf.String())
+	assert.Contains(t, out, "Size: 5 -> 8")
+	assert.NotContains(t, out, "Timeout:")
+	assert.Contains(t, out, "Updated browser pool prod")
+}
+
+func TestBrowserPoolsEnsure_UnchangedSkipsUpdate(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := &FakeBrowserPoolsService{
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+			return newFakePool("prod", 5), nil
+		},
+		UpdateFunc: func(ctx context.Context, id string, body kernel.BrowserPoolUpdateParams, opts ...option.RequestOption) (*kernel.BrowserPool, error) {
+			t.Fatal("Update should not be called when nothing changed")
+			return nil, nil
+		},
+	}
+	c := BrowserPoolsCmd{client: fake}
+	err := c.Ensure(context.Background(), BrowserPoolsEnsureInput{Name: "prod", Config: poolConfigFile{Size: 5}})
+	assert.NoError(t, err)
+	assert.Contains(t, outBuf.String(), "Browser pool prod is unchanged")
+}
+
+// failOnConfirm makes any confirmation prompt fail the test.
+func failOnConfirm(t *testing.T) {
+	t.Helper()
+	orig := confirmPrompt
+	confirmPrompt = func(msg string) bool {
+		t.Fatalf("unexpected confirmation prompt: %s", msg)
+		return false
+	}
+	t.Cleanup(func() { confirmPrompt = orig })
+}
+
+func TestBrowserPoolsDelete_DoesNotPrompt(t *testing.T) {
+	setupStdoutCapture(t)
+	failOnConfirm(t)
+	var force bool
+	fake := &FakeBrowserPoolsService{
+		DeleteFunc: func(ctx context.Context, id string, body kernel.BrowserPoolDeleteParams, opts ...option.RequestOption) error {
+			force = body.Force.Value
+			return nil
+		},
+	}
+	c := BrowserPoolsCmd{client: fake}
+	err := c.Delete(context.Background(), BrowserPoolsDeleteInput{IDOrName: "prod", Force: true})
+	assert.NoError(t, err)
+	assert.True(t, force)
+	assert.Contains(t, outBuf.String(), "Deleted browser pool prod")
+}
+
+func TestBrowserPoolsFlush_DoesNotPrompt(t *testing.T) {
+	setupStdoutCapture(t)
+	failOnConfirm(t)
+	flushed := false
+	fake := &FakeBrowserPoolsService{
+		FlushFunc: func(ctx context.Context, id string, opts ...option.RequestOption) error {
+			flushed = true
+			return nil
+		},
+	}
+	c := BrowserPoolsCmd{client: fake}
+	err := c.Flush(context.Background(), BrowserPoolsFlushInput{IDOrName: "prod"})
+	assert.NoError(t, err)
+	assert.True(t, flushed)
+}
+
+func TestConfirmingCommandsHaveYesFlag(t *testing.T) {
+	for _, c := range []*cobra.Command{browsersDeleteCmd, extensionsDeleteCmd, profilesDeleteCmd} {
+		f := c.Flags().Lookup("yes")
+		if assert.NotNil(t, f, "%s is missing --yes", c.CommandPath()) {
+			assert.Equal(t, "y", f.Shorthand)
+		}
+	}
+}
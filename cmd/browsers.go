@@ -1,26 +1,43 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
 	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash"
+	"image/jpeg"
+	"image/png"
 	"io"
 	"math/big"
 	"net/http"
 	"os"
+	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/onkernel/cli/pkg/table"
 	"github.com/onkernel/cli/pkg/util"
 	"github.com/onkernel/kernel-go-sdk"
 	"github.com/onkernel/kernel-go-sdk/option"
 	"github.com/onkernel/kernel-go-sdk/packages/pagination"
 	"github.com/onkernel/kernel-go-sdk/packages/ssestream"
 	"github.com/onkernel/kernel-go-sdk/shared"
+	"github.com/pkg/browser"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -99,6 +116,24 @@ type BoolFlag struct {
 	Value bool
 }
 
+// addSaveChangesFlags registers --save-changes and its inverse, --read-only.
+// Read them back with getSaveChangesFlag.
+func addSaveChangesFlags(cmd *cobra.Command, usage string) {
+	cmd.Flags().Bool("save-changes", false, usage)
+	cmd.Flags().Bool("read-only", false, "Do not save changes back to the profile (inverse of --save-changes)")
+	cmd.MarkFlagsMutuallyExclusive("save-changes", "read-only")
+}
+
+// getSaveChangesFlag reports the profile save-changes setting; --read-only
+// counts as an explicit --save-changes=false.
+func getSaveChangesFlag(cmd *cobra.Command) BoolFlag {
+	if readOnly, _ := cmd.Flags().GetBool("read-only"); readOnly {
+		return BoolFlag{Set: true, Value: false}
+	}
+	saveChanges, _ := cmd.Flags().GetBool("save-changes")
+	return BoolFlag{Set: cmd.Flags().Changed("save-changes"), Value: saveChanges}
+}
+
 // Regular expression to validate CUID2 identifiers (24 lowercase alphanumeric characters).
 var cuidRegex = regexp.MustCompile(`^[a-z0-9]{24}$`)
 
@@ -150,6 +185,18 @@ func parseViewport(viewport string) (width, height, refreshRate int64, err error
 	return w, h, refreshRate, nil
 }
 
+// isSupportedViewport reports whether the size matches one of
+// getAvailableViewports. A zero refresh rate matches any rate for that size.
+func isSupportedViewport(width, height, refreshRate int64) bool {
+	for _, v := range getAvailableViewports() {
+		w, h, r, err := parseViewport(v)
+		if err == nil && w == width && h == height && (refreshRate == 0 || r == refreshRate) {
+			return true
+		}
+	}
+	return false
+}
+
 // Inputs for each command
 type BrowsersCreateInput struct {
 	PersistenceID      string
@@ -160,10 +207,43 @@ type BrowsersCreateInput struct {
 	ProfileID          string
 	ProfileName        string
 	ProfileSaveChanges BoolFlag
+	CreateIfMissing    bool
 	ProxyID            string
 	Extensions         []string
-	Viewport           string
-}
+	// ExtensionDirs are local unpacked extensions uploaded before the session
+	// is created and loaded into it.
+	ExtensionDirs []string
+	Viewport      string
+	// ForceViewport skips the check against the supported viewport list.
+	ForceViewport bool
+	SaveSession   string
+	// RememberSession records the new session as @last.
+	RememberSession bool
+	// ScreenshotOnCreate, when set, is where a screenshot of the new session
+	// is saved once the browser is reachable.
+	ScreenshotOnCreate string
+	// RetryOnCapacity keeps retrying for up to CapacityTimeout while the org
+	// is at its browser limit.
+	RetryOnCapacity bool
+	CapacityTimeout time.Duration
+	// Wait blocks for up to WaitTimeout until the new session is ready before
+	// printing it.
+	Wait        bool
+	WaitTimeout time.Duration
+	// Open launches the live view in the default browser once the session
+	// is ready. It implies Wait.
+	Open bool
+	// Output is "json" to print the API response instead of a table.
+	Output string
+}
+
+// capacityRetryInterval is how long create waits between attempts with
+// --retry-on-capacity.
+var capacityRetryInterval = 5 * time.Second
+
+// screenshotReadyTimeout bounds how long create waits for the browser before
+// taking the --screenshot-on-create capture.
+const screenshotReadyTimeout = 30 * time.Second
 
 type BrowsersDeleteInput struct {
 	Identifier  string
@@ -172,13 +252,64 @@ type BrowsersDeleteInput struct {
 
 type BrowsersViewInput struct {
 	Identifier string
+	// Open launches the live view in the default browser.
+	Open   bool
+	Output string
+}
+
+// browserViewResult is printed by `browsers view --output json`. LiveViewURL
+// is null when the browser has none (e.g. it is headless).
+type browserViewResult struct {
+	LiveViewURL *string `json:"live_view_url"`
+	Headless    bool    `json:"headless"`
 }
 
+// openInBrowser opens url in the user's default browser. It is a variable so
+// tests don't launch one.
+var openInBrowser = browser.OpenURL
+
 type BrowsersGetInput struct {
 	Identifier string
 	Output     string
+	Retry      RetryOptions
+	// WatchUntil names a browserConditions entry to poll for before printing.
+	WatchUntil string
+	Timeout    time.Duration
+}
+
+// watchPollInterval is how often Get re-checks a --watch-until condition.
+var watchPollInterval = time.Second
+
+// browserConditions are the states `browsers get --watch-until` can wait for.
+var browserConditions = map[string]func(ctx context.Context, b BrowsersCmd, br *kernel.BrowserGetResponse) bool{
+	"reachable": func(ctx context.Context, b BrowsersCmd, br *kernel.BrowserGetResponse) bool {
+		return b.chromiumReachable(ctx, br.SessionID)
+	},
+	"headless": func(ctx context.Context, b BrowsersCmd, br *kernel.BrowserGetResponse) bool {
+		return br.Headless
+	},
+	"has-live-view": func(ctx context.Context, b BrowsersCmd, br *kernel.BrowserGetResponse) bool {
+		return br.BrowserLiveViewURL != ""
+	},
+}
+
+func browserConditionNames() []string {
+	names := make([]string, 0, len(browserConditions))
+	for name := range browserConditions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
+type BrowsersRebootInput struct {
+	Identifier string
+	Timeout    time.Duration
+}
+
+// rebootPollInterval is how often Reboot checks whether Chromium is reachable again.
+var rebootPollInterval = time.Second
+
 // BrowsersCmd is a cobra-independent command handler for browsers operations.
 type BrowsersCmd struct {
 	browsers   BrowsersService
@@ -188,53 +319,181 @@ type BrowsersCmd struct {
 	logs       BrowserLogService
 	computer   BrowserComputerService
 	playwright BrowserPlaywrightService
+	profiles   ProfilesService
+	extensions ExtensionsService
+	tracker    ProcessTracker
 }
 
 type BrowsersListInput struct {
 	Output         string
 	IncludeDeleted bool
-	Limit          int
-	Offset         int
+	Count          bool
+	// All pages through every result, using Limit as the page size.
+	All    bool
+	Limit  int
+	Offset int
+	Retry  RetryOptions
+	// Sort is one of browserSortKeys; empty keeps the API order.
+	Sort string
+	// Profile keeps only sessions whose profile name or ID matches.
+	Profile string
+}
+
+// browserSortKeys are the orderings accepted by `browsers list --sort`.
+var browserSortKeys = map[string]func(a, b kernel.BrowserListResponse) int{
+	"created":    func(a, b kernel.BrowserListResponse) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"-created":   func(a, b kernel.BrowserListResponse) int { return b.CreatedAt.Compare(a.CreatedAt) },
+	"session-id": func(a, b kernel.BrowserListResponse) int { return strings.Compare(a.SessionID, b.SessionID) },
+	"profile": func(a, b kernel.BrowserListResponse) int {
+		return strings.Compare(browserProfileLabel(a.Profile), browserProfileLabel(b.Profile))
+	},
+}
+
+func browserSortKeyNames() []string {
+	names := make([]string, 0, len(browserSortKeys))
+	for name := range browserSortKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// browserProfileLabel is the profile name, falling back to its ID.
+func browserProfileLabel(p kernel.Profile) string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.ID
+}
+
+// filterBrowsersByProfile keeps the sessions whose profile name or ID is
+// profile. An empty profile keeps everything.
+func filterBrowsersByProfile(items []kernel.BrowserListResponse, profile string) []kernel.BrowserListResponse {
+	if profile == "" {
+		return items
+	}
+	var kept []kernel.BrowserListResponse
+	for _, item := range items {
+		if item.Profile.Name == profile || item.Profile.ID == profile {
+			kept = append(kept, item)
+		}
+	}
+	return kept
 }
 
-func (b BrowsersCmd) List(ctx context.Context, in BrowsersListInput) error {
-	if in.Output != "" && in.Output != "json" {
-		pterm.Error.Println("unsupported --output value: use 'json'")
-		return nil
-	}
+// listPageSize is the page size used by `browsers list --all` when --limit
+// is not given.
+const listPageSize = 100
 
+// listPages fetches the page described by in, or with in.All every page from
+// in.Offset onwards, calling visit with the items of each page as it arrives.
+func (b BrowsersCmd) listPages(ctx context.Context, in BrowsersListInput, visit func([]kernel.BrowserListResponse) error) error {
 	params := kernel.BrowserListParams{}
 	if in.IncludeDeleted {
 		params.IncludeDeleted = kernel.Opt(true)
 	}
-	if in.Limit > 0 {
-		params.Limit = kernel.Opt(int64(in.Limit))
+	pageSize := in.Limit
+	if in.All && pageSize <= 0 {
+		pageSize = listPageSize
 	}
-	if in.Offset > 0 {
-		params.Offset = kernel.Opt(int64(in.Offset))
+	if pageSize > 0 {
+		params.Limit = kernel.Opt(int64(pageSize))
 	}
-
-	page, err := b.browsers.List(ctx, params)
-	if err != nil {
-		return util.CleanedUpSdkError{Err: err}
+	offset := in.Offset
+	for {
+		if offset > 0 {
+			params.Offset = kernel.Opt(int64(offset))
+		}
+		var page *pagination.OffsetPagination[kernel.BrowserListResponse]
+		var resp *http.Response
+		err := withRetry(ctx, in.Retry, func() (err error) {
+			page, err = b.browsers.List(ctx, params, option.WithResponseInto(&resp))
+			return err
+		})
+		if err != nil {
+			return util.CleanedUpSdkError{Err: err}
+		}
+		var items []kernel.BrowserListResponse
+		if page != nil {
+			items = page.Items
+		}
+		if err := visit(items); err != nil {
+			return err
+		}
+		// A short page doesn't mean the end: the server may cap the page size
+		// below --limit. Stop on an empty page, or when X-Next-Offset says
+		// there is nothing more.
+		if !in.All || len(items) == 0 {
+			return nil
+		}
+		next := offset + len(items)
+		if resp != nil {
+			if v := resp.Header.Get("X-Next-Offset"); v != "" {
+				n, err := strconv.Atoi(v)
+				if err != nil || n <= offset {
+					return nil
+				}
+				next = n
+			}
+		}
+		offset = next
 	}
+}
 
-	var browsers []kernel.BrowserListResponse
-	if page != nil {
-		browsers = page.Items
+func (b BrowsersCmd) List(ctx context.Context, in BrowsersListInput) error {
+	if !checkOutput(in.Output, outputJSON, outputNDJSON, outputYAML, outputCSV) {
+		return nil
+	}
+	less := browserSortKeys[in.Sort]
+	if in.Sort != "" && less == nil {
+		pterm.Error.Printf("unknown --sort key %q: use one of %s\n", in.Sort, strings.Join(browserSortKeyNames(), ", "))
+		return nil
 	}
 
-	if in.Output == "json" {
-		if len(browsers) == 0 {
-			fmt.Println("[]")
+	// ndjson is written as pages arrive so large --all exports are not
+	// buffered, unless sorting needs every row first.
+	if in.Output == outputNDJSON && !in.Count && less == nil {
+		return b.listPages(ctx, in, func(items []kernel.BrowserListResponse) error {
+			for _, item := range filterBrowsersByProfile(items, in.Profile) {
+				bs, err := json.Marshal(item)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(bs))
+			}
 			return nil
+		})
+	}
+
+	browsers := []kernel.BrowserListResponse{}
+	err := b.listPages(ctx, in, func(items []kernel.BrowserListResponse) error {
+		browsers = append(browsers, filterBrowsersByProfile(items, in.Profile)...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if in.Count {
+		return printCount(len(browsers), in.Output)
+	}
+	if less != nil {
+		slices.SortStableFunc(browsers, less)
+	}
+
+	switch in.Output {
+	case outputNDJSON:
+		for _, item := range browsers {
+			bs, err := json.Marshal(item)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bs))
 		}
-		bs, err := json.MarshalIndent(browsers, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(bs))
 		return nil
+	case outputJSON, outputYAML:
+		return printStructured(browsers, in.Output)
+	case outputCSV:
+		return printCSV(browserListRows(browsers, in.IncludeDeleted, false))
 	}
 
 	if len(browsers) == 0 {
@@ -242,12 +501,18 @@ func (b BrowsersCmd) List(ctx context.Context, in BrowsersListInput) error {
 		return nil
 	}
 
-	// Prepare table data
+	PrintTableNoPad(browserListRows(browsers, in.IncludeDeleted, true), true)
+	return nil
+}
+
+// browserListRows builds the header and rows shown by `browsers list`.
+// URLs are shortened for the terminal table when truncate is set.
+func browserListRows(browsers []kernel.BrowserListResponse, includeDeleted, truncate bool) [][]string {
 	headers := []string{"Browser ID", "Created At", "Persistent ID", "Profile", "CDP WS URL", "Live View URL"}
-	if in.IncludeDeleted {
+	if includeDeleted {
 		headers = append(headers, "Deleted At")
 	}
-	tableData := pterm.TableData{headers}
+	rows := [][]string{headers}
 
 	for _, browser := range browsers {
 		persistentID := "-"
@@ -255,39 +520,37 @@ func (b BrowsersCmd) List(ctx context.Context, in BrowsersListInput) error {
 			persistentID = browser.Persistence.ID
 		}
 
-		profile := "-"
-		if browser.Profile.Name != "" {
-			profile = browser.Profile.Name
-		} else if browser.Profile.ID != "" {
-			profile = browser.Profile.ID
-		}
+		profile := util.OrDash(browserProfileLabel(browser.Profile))
 
+		cdpURL, liveViewURL := browser.CdpWsURL, browser.BrowserLiveViewURL
+		if truncate {
+			cdpURL, liveViewURL = truncateURL(cdpURL, 50), truncateURL(liveViewURL, 50)
+		}
 		row := []string{
 			browser.SessionID,
 			util.FormatLocal(browser.CreatedAt),
 			persistentID,
 			profile,
-			truncateURL(browser.CdpWsURL, 50),
-			truncateURL(browser.BrowserLiveViewURL, 50),
+			cdpURL,
+			liveViewURL,
 		}
 
-		if in.IncludeDeleted {
-			deletedAt := "-"
-			if !browser.DeletedAt.IsZero() {
-				deletedAt = util.FormatLocal(browser.DeletedAt)
-			}
-			row = append(row, deletedAt)
+		if includeDeleted {
+			row = append(row, util.FormatLocal(browser.DeletedAt))
 		}
 
-		tableData = append(tableData, row)
+		rows = append(rows, row)
 	}
-
-	PrintTableNoPad(tableData, true)
-	return nil
+	return rows
 }
 
 func (b BrowsersCmd) Create(ctx context.Context, in BrowsersCreateInput) error {
-	pterm.Info.Println("Creating browser session...")
+	if !checkOutput(in.Output, outputJSON) {
+		return nil
+	}
+	if in.Output == "" {
+		pterm.Info.Println("Creating browser session...")
+	}
 	params := kernel.BrowserNewParams{}
 	if in.PersistenceID != "" {
 		params.Persistence = kernel.BrowserPersistenceParam{ID: in.PersistenceID}
@@ -319,6 +582,15 @@ func (b BrowsersCmd) Create(ctx context.Context, in BrowsersCreateInput) error {
 			params.Profile.Name = kernel.Opt(in.ProfileName)
 		}
 	}
+	if in.CreateIfMissing {
+		if in.ProfileName == "" {
+			pterm.Error.Println("--create-if-missing requires --profile-name")
+			return nil
+		}
+		if err := b.ensureProfile(ctx, in.ProfileName, in.Output); err != nil {
+			return err
+		}
+	}
 
 	// Add proxy if specified
 	if in.ProxyID != "" {
@@ -349,6 +621,10 @@ func (b BrowsersCmd) Create(ctx context.Context, in BrowsersCreateInput) error {
 			pterm.Error.Printf("Invalid viewport format: %v\n", err)
 			return nil
 		}
+		if !in.ForceViewport && !isSupportedViewport(width, height, refreshRate) {
+			pterm.Error.Printf("Unsupported viewport %s. Supported: %s (use --force-viewport to send it anyway)\n", in.Viewport, strings.Join(getAvailableViewports(), ", "))
+			return nil
+		}
 		params.Viewport = kernel.BrowserViewportParam{
 			Width:  width,
 			Height: height,
@@ -358,12 +634,148 @@ func (b BrowsersCmd) Create(ctx context.Context, in BrowsersCreateInput) error {
 		}
 	}
 
-	browser, err := b.browsers.New(ctx, params)
+	var uploaded []*kernel.ExtensionUploadResponse
+	if len(in.ExtensionDirs) > 0 {
+		if b.extensions == nil {
+			pterm.Error.Println("extensions service not available")
+			return nil
+		}
+		var err error
+		if uploaded, err = b.uploadExtensionDirs(ctx, in.ExtensionDirs, in.Output); err != nil {
+			return err
+		}
+		for _, ext := range uploaded {
+			if ext.Name != "" {
+				params.Extensions = append(params.Extensions, kernel.BrowserExtensionParam{Name: kernel.Opt(ext.Name)})
+			} else {
+				params.Extensions = append(params.Extensions, kernel.BrowserExtensionParam{ID: kernel.Opt(ext.ID)})
+			}
+		}
+	}
+
+	browser, err := b.newBrowser(ctx, params, in)
 	if err != nil {
+		b.deleteUploadedExtensions(ctx, uploaded, in.Output)
 		return util.CleanedUpSdkError{Err: err}
 	}
 
-	printBrowserSessionResult(browser.SessionID, browser.CdpWsURL, browser.BrowserLiveViewURL, browser.Persistence, browser.Profile)
+	if in.Wait || in.Open {
+		if in.Output == "" {
+			pterm.Info.Println("Waiting for browser to become ready...")
+		}
+		br, ready := b.waitSessionReady(ctx, browser.SessionID, in.WaitTimeout)
+		if br != nil {
+			browser.CdpWsURL, browser.BrowserLiveViewURL = br.CdpWsURL, br.BrowserLiveViewURL
+		}
+		if !ready {
+			statusPrinter(pterm.Warning, in.Output).Printf("Browser %s not ready after %s\n", browser.SessionID, in.WaitTimeout)
+		}
+	}
+
+	if in.Output == outputJSON {
+		if err := printStructured(browser, outputJSON); err != nil {
+			return err
+		}
+	} else {
+		printBrowserSessionResult(browser.SessionID, browser.CdpWsURL, browser.BrowserLiveViewURL, browser.Persistence, browser.Profile)
+		if profile := util.FirstOrDash(in.ProfileName, in.ProfileID); profile != "-" {
+			if in.ProfileSaveChanges.Value {
+				pterm.Info.Printf("Changes will be saved to profile %s when the session ends\n", profile)
+			} else {
+				pterm.Info.Printf("Profile %s is read-only for this session: changes will not be saved\n", profile)
+			}
+		}
+	}
+	if err := recordSession(browser.SessionID, in.SaveSession, in.RememberSession); err != nil {
+		return err
+	}
+	warn := statusPrinter(pterm.Warning, in.Output)
+	if in.Open {
+		switch {
+		case browser.Headless:
+			warn.Println("Headless browsers have no live view; not opening one")
+		case browser.BrowserLiveViewURL == "":
+			warn.Println("No live view URL available for this browser")
+		default:
+			if err := openInBrowser(browser.BrowserLiveViewURL); err != nil {
+				warn.Printf("Could not open a browser (%v); open the live view URL above manually\n", err)
+			} else {
+				statusPrinter(pterm.Success, in.Output).Println("Opened live view in your browser")
+			}
+		}
+	}
+	if in.ScreenshotOnCreate != "" {
+		if b.playwright != nil && !b.waitReachable(ctx, browser.SessionID, screenshotReadyTimeout) {
+			warn.Printf("Browser not reachable after %s; capturing screenshot anyway\n", screenshotReadyTimeout)
+		}
+		return b.ComputerScreenshot(ctx, BrowsersComputerScreenshotInput{Identifier: browser.SessionID, To: in.ScreenshotOnCreate, StatusToStderr: in.Output != ""})
+	}
+	return nil
+}
+
+// uploadExtensionDirs uploads each local extension directory for Create. If
+// one fails, the ones already uploaded are deleted again.
+func (b BrowsersCmd) uploadExtensionDirs(ctx context.Context, dirs []string, output string) ([]*kernel.ExtensionUploadResponse, error) {
+	var uploaded []*kernel.ExtensionUploadResponse
+	for _, dir := range dirs {
+		item, err := uploadExtensionDir(ctx, b.extensions, dir, "", output)
+		if err != nil {
+			b.deleteUploadedExtensions(ctx, uploaded, output)
+			return nil, fmt.Errorf("failed to upload extension %s: %w", dir, err)
+		}
+		uploaded = append(uploaded, item)
+	}
+	return uploaded, nil
+}
+
+// deleteUploadedExtensions removes extensions uploaded by a failed Create.
+// Failures only warn, since the original error is what gets reported.
+func (b BrowsersCmd) deleteUploadedExtensions(ctx context.Context, items []*kernel.ExtensionUploadResponse, output string) {
+	for _, item := range items {
+		if err := b.extensions.Delete(ctx, item.ID); err != nil && !util.IsNotFound(err) {
+			statusPrinter(pterm.Warning, output).Printf("Failed to delete uploaded extension %s: %v\n", item.ID, util.CleanedUpSdkError{Err: err})
+		}
+	}
+}
+
+// newBrowser creates the session, waiting for free capacity when
+// in.RetryOnCapacity is set and the API reports the org is at its limit.
+func (b BrowsersCmd) newBrowser(ctx context.Context, params kernel.BrowserNewParams, in BrowsersCreateInput) (*kernel.BrowserNewResponse, error) {
+	deadline := time.Now().Add(in.CapacityTimeout)
+	for {
+		browser, err := b.browsers.New(ctx, params)
+		if err == nil || !in.RetryOnCapacity || !util.IsCapacityError(err) {
+			return browser, err
+		}
+		if !time.Now().Add(capacityRetryInterval).Before(deadline) {
+			statusPrinter(pterm.Warning, in.Output).Printf("No browser capacity freed up within %s\n", in.CapacityTimeout)
+			return nil, err
+		}
+		statusPrinter(pterm.Info, in.Output).Printf("At browser capacity; retrying in %s...\n", capacityRetryInterval)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(capacityRetryInterval):
+		}
+	}
+}
+
+// ensureProfile looks up a profile by name and creates it when it does not
+// exist. The notice goes to stderr when output is set.
+func (b BrowsersCmd) ensureProfile(ctx context.Context, name, output string) error {
+	if b.profiles == nil {
+		return fmt.Errorf("cannot create profile '%s': profiles service not available", name)
+	}
+	if _, err := b.profiles.Get(ctx, name); err == nil {
+		return nil
+	} else if !util.IsNotFound(err) {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	profile, err := b.profiles.New(ctx, kernel.ProfileNewParams{Name: kernel.Opt(name)})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	statusPrinter(pterm.Info, output).Printf("Created profile '%s' (%s)\n", profile.Name, profile.ID)
 	return nil
 }
 
@@ -403,9 +815,7 @@ func (b BrowsersCmd) Delete(ctx context.Context, in BrowsersDeleteInput) error {
 		}
 
 		confirmMsg := fmt.Sprintf("Are you sure you want to delete browser \"%s\"?", in.Identifier)
-		pterm.DefaultInteractiveConfirm.DefaultText = confirmMsg
-		result, _ := pterm.DefaultInteractiveConfirm.Show()
-		if !result {
+		if !confirmPrompt(confirmMsg) {
 			pterm.Info.Println("Deletion cancelled")
 			return nil
 		}
@@ -428,68 +838,299 @@ func (b BrowsersCmd) Delete(ctx context.Context, in BrowsersDeleteInput) error {
 		return nil
 	}
 
-	// Skip confirmation: try both deletion modes without listing first
-	// Treat not found as a success (idempotent delete)
+	if _, err := b.deleteAnyKind(ctx, in.Identifier); err != nil {
+		return err
+	}
+	pterm.Success.Printf("Successfully deleted (or already absent) browser: %s\n", in.Identifier)
+	return nil
+}
+
+// deleteAnyKind deletes identifier as both a session ID and a persistent ID
+// without looking it up first. Not found is treated as success; the returned
+// bool reports whether either delete actually removed something.
+func (b BrowsersCmd) deleteAnyKind(ctx context.Context, identifier string) (bool, error) {
 	var nonNotFoundErrors []error
+	deleted := false
 
 	// Attempt by session ID
-	if err := b.browsers.DeleteByID(ctx, in.Identifier); err != nil {
+	if err := b.browsers.DeleteByID(ctx, identifier); err != nil {
 		if !util.IsNotFound(err) {
 			nonNotFoundErrors = append(nonNotFoundErrors, err)
 		}
+	} else {
+		deleted = true
 	}
 
 	// Attempt by persistent ID (backward compatibility)
-	if err := b.browsers.Delete(ctx, kernel.BrowserDeleteParams{PersistentID: in.Identifier}); err != nil {
+	if err := b.browsers.Delete(ctx, kernel.BrowserDeleteParams{PersistentID: identifier}); err != nil {
 		if !util.IsNotFound(err) {
 			nonNotFoundErrors = append(nonNotFoundErrors, err)
 		}
+	} else {
+		deleted = true
 	}
 
 	if len(nonNotFoundErrors) >= 2 {
 		// Both failed with meaningful errors; report one
-		return util.CleanedUpSdkError{Err: nonNotFoundErrors[0]}
+		return false, util.CleanedUpSdkError{Err: nonNotFoundErrors[0]}
 	}
+	return deleted, nil
+}
 
-	pterm.Success.Printf("Successfully deleted (or already absent) browser: %s\n", in.Identifier)
+type BrowsersDeleteManyInput struct {
+	Identifiers []string
+	// All deletes every running browser instead of Identifiers.
+	All         bool
+	SkipConfirm bool
+	Output      string
+	// Concurrency bounds how many identifiers are deleted at once.
+	Concurrency int
+	// FailFast stops starting new deletions after the first failure.
+	FailFast bool
+}
+
+// defaultDeleteConcurrency is the --concurrency default for browsers delete.
+// Deletes are cheap, so it is higher than the usual batch default.
+const defaultDeleteConcurrency = 8
+
+// browserDeleteResult is one entry of `browsers delete --output json`.
+type browserDeleteResult struct {
+	Identifier string `json:"identifier"`
+	Deleted    bool   `json:"deleted,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// Skipped is set for identifiers not attempted after a --fail-fast stop.
+	Skipped bool `json:"skipped,omitempty"`
+	// notFound marks identifiers that were already gone; they still count
+	// as deleted.
+	notFound bool
+}
+
+// DeleteMany deletes every identifier, several at a time. A single
+// identifier without --yes goes through Delete and its prompt; otherwise one
+// prompt covers them all and each result is reported in input order.
+func (b BrowsersCmd) DeleteMany(ctx context.Context, in BrowsersDeleteManyInput) error {
+	if in.Output != "" && in.Output != "json" {
+		pterm.Error.Println("unsupported --output value: use 'json'")
+		return nil
+	}
+	if in.All {
+		return b.deleteAll(ctx, in)
+	}
+	if in.Output == "json" && !in.SkipConfirm {
+		pterm.Error.Println("--output json requires --yes")
+		return nil
+	}
+	if in.Output != "json" && len(in.Identifiers) == 1 {
+		return b.Delete(ctx, BrowsersDeleteInput{Identifier: in.Identifiers[0], SkipConfirm: in.SkipConfirm})
+	}
+	if !in.SkipConfirm && !confirmPrompt(fmt.Sprintf("Delete %d browsers?", len(in.Identifiers))) {
+		pterm.Info.Println("Deletion cancelled")
+		return nil
+	}
+
+	results := make([]browserDeleteResult, len(in.Identifiers))
+	for i, id := range in.Identifiers {
+		results[i] = browserDeleteResult{Identifier: id, Skipped: true}
+	}
+	forEachConcurrentUntil(len(in.Identifiers), in.Concurrency, in.FailFast, func(i int) error {
+		id := in.Identifiers[i]
+		deleted, err := b.deleteAnyKind(ctx, id)
+		results[i] = browserDeleteResult{Identifier: id, Deleted: err == nil, notFound: err == nil && !deleted}
+		if err != nil {
+			results[i].Error = err.Error()
+		}
+		return err
+	})
+	failed, skipped := 0, 0
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			failed++
+		case r.Skipped:
+			skipped++
+		}
+	}
+
+	if in.Output == "json" {
+		bs, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bs))
+	} else {
+		rows := pterm.TableData{{"Browser ID", "Status"}}
+		for _, r := range results {
+			status := "deleted"
+			switch {
+			case r.Error != "":
+				status = "error: " + r.Error
+			case r.Skipped:
+				status = "skipped"
+			case r.notFound:
+				status = "not found"
+			}
+			rows = append(rows, []string{r.Identifier, status})
+		}
+		PrintTableNoPad(rows, true)
+	}
+	if skipped > 0 {
+		return fmt.Errorf("failed to delete %d of %d browsers (stopped early, %d not attempted)", failed, len(in.Identifiers), skipped)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d browsers", failed, len(in.Identifiers))
+	}
+	return nil
+}
+
+// deleteAll deletes every running browser by session ID after a single
+// confirmation. Sessions that are already gone count as skipped.
+func (b BrowsersCmd) deleteAll(ctx context.Context, in BrowsersDeleteManyInput) error {
+	if in.Output == "json" && !in.SkipConfirm {
+		pterm.Error.Println("--output json requires --yes")
+		return nil
+	}
+	var ids []string
+	err := b.listPages(ctx, BrowsersListInput{All: true}, func(items []kernel.BrowserListResponse) error {
+		for _, item := range items {
+			ids = append(ids, item.SessionID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		if in.Output == "json" {
+			fmt.Println("[]")
+			return nil
+		}
+		pterm.Info.Println("No running browsers found")
+		return nil
+	}
+	if !in.SkipConfirm && !confirmPrompt(fmt.Sprintf("Delete %d running browsers?", len(ids))) {
+		pterm.Info.Println("Deletion cancelled")
+		return nil
+	}
+
+	results := make([]browserDeleteResult, 0, len(ids))
+	deleted, skipped, failed := 0, 0, 0
+	for _, id := range ids {
+		res := browserDeleteResult{Identifier: id, Deleted: true}
+		if err := b.browsers.DeleteByID(ctx, id); err != nil {
+			if util.IsNotFound(err) {
+				skipped++
+			} else {
+				res.Deleted = false
+				res.Error = util.CleanedUpSdkError{Err: err}.Error()
+				failed++
+				if in.Output != "json" {
+					pterm.Error.Printf("Failed to delete browser %s: %s\n", id, res.Error)
+				}
+			}
+		} else {
+			deleted++
+		}
+		results = append(results, res)
+		if failed > 0 && in.FailFast {
+			break
+		}
+	}
+
+	if in.Output == "json" {
+		bs, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bs))
+	} else {
+		pterm.Success.Printf("Deleted %d browsers, skipped %d already gone\n", deleted, skipped)
+	}
+	if len(results) < len(ids) {
+		return fmt.Errorf("failed to delete %d of %d browsers (stopped early, %d not attempted)", failed, len(ids), len(ids)-len(results))
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d browsers", failed, len(ids))
+	}
 	return nil
 }
 
 func (b BrowsersCmd) View(ctx context.Context, in BrowsersViewInput) error {
+	if !checkOutput(in.Output, outputJSON) {
+		return nil
+	}
 	browser, err := b.browsers.Get(ctx, in.Identifier)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
+	if in.Output == outputJSON {
+		res := browserViewResult{Headless: browser.Headless}
+		if browser.BrowserLiveViewURL != "" {
+			res.LiveViewURL = &browser.BrowserLiveViewURL
+		}
+		return printStructured(res, outputJSON)
+	}
 	if browser.BrowserLiveViewURL == "" {
 		if browser.Headless {
 			pterm.Warning.Println("This browser is running in headless mode and does not have a live view URL")
 		} else {
 			pterm.Warning.Println("No live view URL available for this browser")
 		}
+		if in.Open {
+			pterm.Info.Println("Nothing to open")
+		}
 		return nil
 	}
 
+	if in.Open {
+		err := openInBrowser(browser.BrowserLiveViewURL)
+		if err == nil {
+			pterm.Success.Println("Opened live view in your browser")
+			return nil
+		}
+		pterm.Warning.Printf("Could not open a browser (%v); open the URL manually:\n", err)
+	}
 	fmt.Println(browser.BrowserLiveViewURL)
 	return nil
 }
 
 func (b BrowsersCmd) Get(ctx context.Context, in BrowsersGetInput) error {
-	if in.Output != "" && in.Output != "json" {
-		pterm.Error.Println("unsupported --output value: use 'json'")
+	if !checkOutput(in.Output, outputJSON, outputYAML) {
 		return nil
 	}
 
-	browser, err := b.browsers.Get(ctx, in.Identifier)
-	if err != nil {
-		return util.CleanedUpSdkError{Err: err}
+	var cond func(context.Context, BrowsersCmd, *kernel.BrowserGetResponse) bool
+	if in.WatchUntil != "" {
+		cond = browserConditions[in.WatchUntil]
+		if cond == nil {
+			pterm.Error.Printf("unknown --watch-until condition %q: use one of %s\n", in.WatchUntil, strings.Join(browserConditionNames(), ", "))
+			return nil
+		}
 	}
-	if in.Output == "json" {
-		bs, err := json.MarshalIndent(browser, "", "  ")
-		if err != nil {
+
+	var browser *kernel.BrowserGetResponse
+	deadline := time.Now().Add(in.Timeout)
+	for {
+		err := withRetry(ctx, in.Retry, func() (err error) {
+			browser, err = b.browsers.Get(ctx, in.Identifier)
 			return err
+		})
+		if err != nil {
+			return util.CleanedUpSdkError{Err: err}
 		}
-		fmt.Println(string(bs))
-		return nil
+		if cond == nil || cond(ctx, b, browser) {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("browser %s did not become %s within %s", in.Identifier, in.WatchUntil, in.Timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(watchPollInterval):
+		}
+	}
+	if in.Output != "" {
+		return printStructured(browser, in.Output)
 	}
 
 	// Build table starting with common browser fields
@@ -527,45 +1168,256 @@ func (b BrowsersCmd) Get(ctx context.Context, in BrowsersGetInput) error {
 
 // Logs
 type BrowsersLogsStreamInput struct {
-	Identifier        string
+	Identifier string
+	// Source is one source, a comma-separated list, or "all"; several
+	// sources are streamed concurrently and interleaved.
 	Source            string
 	Follow            BoolFlag
 	Path              string
 	SupervisorProcess string
+	// OrderBy is logOrderArrival (default) or logOrderTimestamp.
+	OrderBy string
+	// OutputFile, when set, also receives every line as plain
+	// "[timestamp] message" text.
+	OutputFile string
+	// RotateSize, when positive, rotates OutputFile once it reaches this many
+	// bytes, keeping RotateKeep old files.
+	RotateSize int64
+	RotateKeep int
+	// JSON prints one logLine object per event instead of the human format.
+	JSON bool
+	// Level, when set, drops events below this severity (see logEventLevel).
+	Level string
+}
+
+// logLine is one event as printed by `browsers logs stream --json`.
+type logLine struct {
+	Timestamp string `json:"timestamp"`
+	Message   string `json:"message"`
+	Source    string `json:"source"`
+}
+
+// logSourceNames expands --source: a single source, a comma-separated list,
+// or "all" for every source the API offers. Duplicates are dropped.
+func logSourceNames(source string) []string {
+	if strings.TrimSpace(source) == "all" {
+		return []string{string(kernel.BrowserLogStreamParamsSourcePath), string(kernel.BrowserLogStreamParamsSourceSupervisor)}
+	}
+	var names []string
+	for _, name := range strings.Split(source, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		names = []string{""}
+	}
+	return names
 }
 
-func (b BrowsersCmd) LogsStream(ctx context.Context, in BrowsersLogsStreamInput) error {
-	if b.logs == nil {
-		pterm.Error.Println("logs service not available")
+// chromiumReachable reports whether Playwright can talk to the session's browser.
+func (b BrowsersCmd) chromiumReachable(ctx context.Context, sessionID string) bool {
+	if b.playwright == nil {
+		return false
+	}
+	probe, err := b.playwright.Execute(ctx, sessionID, kernel.BrowserPlaywrightExecuteParams{Code: "return browser.version();"})
+	return err == nil && probe.Success
+}
+
+// waitReachable polls chromiumReachable until it succeeds or timeout elapses.
+func (b BrowsersCmd) waitReachable(ctx context.Context, sessionID string, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if b.chromiumReachable(ctx, sessionID) {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(watchPollInterval):
+		}
+	}
+}
+
+// waitSessionReady polls the session until it has a live view URL (headless
+// sessions have none) and, when Playwright is available, Chromium answers. It
+// returns the last successful Get response and whether the session is ready.
+func (b BrowsersCmd) waitSessionReady(ctx context.Context, sessionID string, timeout time.Duration) (*kernel.BrowserGetResponse, bool) {
+	deadline := time.Now().Add(timeout)
+	var last *kernel.BrowserGetResponse
+	for {
+		if br, err := b.browsers.Get(ctx, sessionID); err == nil {
+			last = br
+			if (br.BrowserLiveViewURL != "" || br.Headless) && (b.playwright == nil || b.chromiumReachable(ctx, sessionID)) {
+				return last, true
+			}
+		}
+		if time.Now().After(deadline) {
+			return last, false
+		}
+		select {
+		case <-ctx.Done():
+			return last, false
+		case <-time.After(watchPollInterval):
+		}
+	}
+}
+
+// Reboot restarts Chromium inside the session through supervisor and waits
+// until Playwright can reach the browser again.
+func (b BrowsersCmd) Reboot(ctx context.Context, in BrowsersRebootInput) error {
+	if b.process == nil || b.playwright == nil {
+		pterm.Error.Println("process or playwright service not available")
 		return nil
 	}
 	br, err := b.browsers.Get(ctx, in.Identifier)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-	params := kernel.BrowserLogStreamParams{Source: kernel.BrowserLogStreamParamsSource(in.Source)}
-	if in.Follow.Set {
-		params.Follow = kernel.Opt(in.Follow.Value)
+
+	start := time.Now()
+	res, err := b.process.Exec(ctx, br.SessionID, kernel.BrowserProcessExecParams{
+		Command: "supervisorctl",
+		Args:    []string{"restart", "chromium"},
+		AsRoot:  kernel.Opt(true),
+	})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
 	}
-	if in.Path != "" {
-		params.Path = kernel.Opt(in.Path)
+	if res.ExitCode != 0 {
+		stderr, _ := base64.StdEncoding.DecodeString(res.StderrB64)
+		pterm.Error.Printf("Failed to restart Chromium (exit code %d): %s\n", res.ExitCode, strings.TrimSpace(string(stderr)))
+		return nil
 	}
-	if in.SupervisorProcess != "" {
-		params.SupervisorProcess = kernel.Opt(in.SupervisorProcess)
+
+	spinner, _ := pterm.DefaultSpinner.Start("Waiting for Chromium to become reachable...")
+	deadline := start.Add(in.Timeout)
+	for {
+		if b.chromiumReachable(ctx, br.SessionID) {
+			_ = spinner.Stop()
+			pterm.Success.Printf("Chromium restarted (downtime: %s)\n", time.Since(start).Round(100*time.Millisecond))
+			return nil
+		}
+		if time.Now().After(deadline) {
+			spinner.Fail("Chromium did not become reachable in time")
+			return fmt.Errorf("browser %s not reachable %s after restart", in.Identifier, in.Timeout)
+		}
+		select {
+		case <-ctx.Done():
+			spinner.Fail("Cancelled")
+			return ctx.Err()
+		case <-time.After(rebootPollInterval):
+		}
 	}
-	stream := b.logs.StreamStreaming(ctx, br.SessionID, params)
-	if stream == nil {
-		pterm.Error.Println("failed to open log stream")
+}
+
+func (b BrowsersCmd) LogsStream(ctx context.Context, in BrowsersLogsStreamInput) error {
+	if b.logs == nil {
+		pterm.Error.Println("logs service not available")
 		return nil
 	}
-	defer stream.Close()
-	for stream.Next() {
-		ev := stream.Current()
-		pterm.Println(fmt.Sprintf("[%s] %s", util.FormatLocal(ev.Timestamp), ev.Message))
+	if in.OrderBy != "" && in.OrderBy != logOrderArrival && in.OrderBy != logOrderTimestamp {
+		pterm.Error.Printf("unsupported --order-by value %q: use %s or %s\n", in.OrderBy, logOrderArrival, logOrderTimestamp)
+		return nil
 	}
-	if err := stream.Err(); err != nil {
+	minLevel := logLevelDebug
+	if in.Level != "" {
+		lvl, ok := parseLogLevel(in.Level)
+		if !ok {
+			pterm.Error.Printf("unsupported --level value %q: use debug, info, warn or error\n", in.Level)
+			return nil
+		}
+		minLevel = lvl
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
+	names := logSourceNames(in.Source)
+	if len(names) > 1 {
+		for _, name := range names {
+			if name == string(kernel.BrowserLogStreamParamsSourcePath) && in.Path == "" {
+				pterm.Error.Println("--source path requires --path")
+				return nil
+			}
+			if name == string(kernel.BrowserLogStreamParamsSourceSupervisor) && in.SupervisorProcess == "" {
+				pterm.Error.Println("--source supervisor requires --supervisor-process")
+				return nil
+			}
+		}
+	}
+	var file io.WriteCloser
+	if in.OutputFile != "" {
+		if in.RotateSize > 0 {
+			file, err = openRotatingLogOutput(in.OutputFile, in.RotateSize, in.RotateKeep)
+		} else {
+			file, err = openLogOutput(in.OutputFile, false)
+		}
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+	}
+	sources := make([]logSource, 0, len(names))
+	for _, name := range names {
+		params := kernel.BrowserLogStreamParams{Source: kernel.BrowserLogStreamParamsSource(name)}
+		if in.Follow.Set {
+			params.Follow = kernel.Opt(in.Follow.Value)
+		}
+		// With several sources, each only gets the option that applies to it.
+		if in.Path != "" && (len(names) == 1 || name == string(kernel.BrowserLogStreamParamsSourcePath)) {
+			params.Path = kernel.Opt(in.Path)
+		}
+		if in.SupervisorProcess != "" && (len(names) == 1 || name == string(kernel.BrowserLogStreamParamsSourceSupervisor)) {
+			params.SupervisorProcess = kernel.Opt(in.SupervisorProcess)
+		}
+		stream := b.logs.StreamStreaming(ctx, br.SessionID, params)
+		if stream == nil {
+			for _, src := range sources {
+				_ = src.Stream.Close()
+			}
+			pterm.Error.Println("failed to open log stream")
+			return nil
+		}
+		sources = append(sources, logSource{Name: name, Stream: stream})
+	}
+	prefixes := map[string]string{}
+	if len(sources) > 1 {
+		for i, src := range sources {
+			prefixes[src.Name] = logSourceColor(i).Sprint(src.Name) + " "
+		}
+	}
+	err = mergeLogStreams(ctx, sources, mergeOptions{OrderBy: in.OrderBy}, func(source string, ev shared.LogEvent) {
+		if minLevel > logLevelDebug && logEventLevel(ev) < minLevel {
+			return
+		}
+		if in.JSON {
+			bs, _ := json.Marshal(logLine{Timestamp: ev.Timestamp.UTC().Format(time.RFC3339Nano), Message: ev.Message, Source: source})
+			fmt.Println(string(bs))
+		} else {
+			pterm.Println(fmt.Sprintf("%s[%s] %s", prefixes[source], util.FormatLocal(ev.Timestamp), ev.Message))
+		}
+		if file != nil {
+			var name string
+			if len(sources) > 1 {
+				name = source + " "
+			}
+			fmt.Fprintf(file, "%s[%s] %s\n", name, ev.Timestamp.UTC().Format(time.RFC3339Nano), ev.Message)
+		}
+	})
+	if err != nil {
+		err = util.CleanedUpSdkError{Err: err}
+		if in.JSON {
+			// Keep stdout a clean JSON stream.
+			pterm.Error.WithWriter(os.Stderr).Println(err.Error())
+			return reportedError{err}
+		}
+		return err
+	}
 	return nil
 }
 
@@ -578,6 +1430,9 @@ type BrowsersComputerClickMouseInput struct {
 	Button     string
 	ClickType  string
 	HoldKeys   []string
+	// Selector, when set, clicks the matching element through Playwright
+	// instead of clicking at X/Y.
+	Selector string
 }
 
 type BrowsersComputerMoveMouseInput struct {
@@ -595,8 +1450,71 @@ type BrowsersComputerScreenshotInput struct {
 	Height     int64
 	To         string
 	HasRegion  bool
+	Retry      RetryOptions
+	// FullPage captures the whole scrollable page through Playwright instead
+	// of the visible screen.
+	FullPage bool
+	// Format is png (the API's native format) or jpeg, which is converted
+	// client-side using Quality.
+	Format  string
+	Quality int
+	// Output is "json" to print screenshotResult instead of a success line.
+	Output string
+	// StatusToStderr prints the success line to stderr, for callers whose
+	// own stdout is machine-readable.
+	StatusToStderr bool
+}
+
+// screenshotResult is printed by `computer screenshot --output json`.
+// Width and Height are omitted if the saved image can't be decoded.
+type screenshotResult struct {
+	Path        string `json:"path"`
+	Bytes       int64  `json:"bytes"`
+	ContentType string `json:"content_type"`
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+}
+
+const defaultJPEGQuality = 90
+
+// parseScreenshotFormat normalizes a --format value to "png" or "jpeg".
+func parseScreenshotFormat(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "png":
+		return "png", nil
+	case "jpeg", "jpg":
+		return "jpeg", nil
+	}
+	return "", fmt.Errorf("unsupported --format %q: use png or jpeg", s)
+}
+
+// screenshotFilePath appends the format's extension when path has none.
+func screenshotFilePath(path, format string) string {
+	if filepath.Ext(path) != "" {
+		return path
+	}
+	if format == "jpeg" {
+		return path + ".jpg"
+	}
+	return path + ".png"
+}
+
+// pngToJPEG re-encodes a PNG screenshot as JPEG at the given quality.
+func pngToJPEG(r io.Reader, quality int) ([]byte, error) {
+	img, err := png.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("failed to encode JPEG: %w", err)
+	}
+	return buf.Bytes(), nil
 }
 
+// fullPageScreenshotCode returns a base64 PNG of the full page from Playwright.
+const fullPageScreenshotCode = `const buf = await page.screenshot({ fullPage: true }); return buf.toString("base64");`
+
 type BrowsersComputerTypeTextInput struct {
 	Identifier string
 	Text       string
@@ -610,6 +1528,29 @@ type BrowsersComputerPressKeyInput struct {
 	HoldKeys   []string
 }
 
+// comboModifiers are the key names treated as held modifiers in a --combo.
+var comboModifiers = map[string]bool{
+	"ctrl": true, "control": true, "shift": true, "alt": true,
+	"super": true, "meta": true, "cmd": true,
+}
+
+// parseKeyCombo splits a shortcut like "ctrl+shift+t" into the key to press
+// ("t") and the modifiers to hold while pressing it.
+func parseKeyCombo(combo string) (key string, holdKeys []string, err error) {
+	parts := strings.Split(combo, "+")
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+		if parts[i] == "" {
+			return "", nil, fmt.Errorf("invalid --combo %q: empty key", combo)
+		}
+	}
+	key = parts[len(parts)-1]
+	if comboModifiers[strings.ToLower(key)] {
+		return "", nil, fmt.Errorf("invalid --combo %q: must end with a non-modifier key", combo)
+	}
+	return key, parts[:len(parts)-1], nil
+}
+
 type BrowsersComputerScrollInput struct {
 	Identifier string
 	X          int64
@@ -619,6 +1560,8 @@ type BrowsersComputerScrollInput struct {
 	DeltaY     int64
 	DeltaYSet  bool
 	HoldKeys   []string
+	ToBottom   bool
+	ToTop      bool
 }
 
 type BrowsersComputerDragMouseInput struct {
@@ -631,12 +1574,37 @@ type BrowsersComputerDragMouseInput struct {
 	HoldKeys        []string
 }
 
+// loadDragPath reads a drag path written as a JSON array of [x, y] integer
+// pairs, e.g. [[0,0],[50,50],[100,100]].
+func loadDragPath(file string) ([][]int64, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read path file: %w", err)
+	}
+	var path [][]int64
+	if err := json.Unmarshal(data, &path); err != nil {
+		return nil, fmt.Errorf("invalid path file %s: expected a JSON array of [x, y] integer pairs: %w", file, err)
+	}
+	for i, p := range path {
+		if len(p) != 2 {
+			return nil, fmt.Errorf("invalid path file %s: point %d has %d values, expected [x, y]", file, i, len(p))
+		}
+	}
+	if len(path) < 2 {
+		return nil, fmt.Errorf("invalid path file %s: path must include at least two points", file)
+	}
+	return path, nil
+}
+
 type BrowsersComputerSetCursorInput struct {
 	Identifier string
 	Hidden     bool
 }
 
 func (b BrowsersCmd) ComputerClickMouse(ctx context.Context, in BrowsersComputerClickMouseInput) error {
+	if in.Selector != "" {
+		return b.computerClickSelector(ctx, in)
+	}
 	if b.computer == nil {
 		pterm.Error.Println("computer service not available")
 		return nil
@@ -665,6 +1633,100 @@ func (b BrowsersCmd) ComputerClickMouse(ctx context.Context, in BrowsersComputer
 	return nil
 }
 
+// clickSelectorCode returns a Playwright snippet that clicks the first element
+// matching selector and reports whether one was found.
+func clickSelectorCode(selector, button string, clickCount int64) string {
+	sel, _ := json.Marshal(selector)
+	if button == "" {
+		button = "left"
+	}
+	if clickCount <= 0 {
+		clickCount = 1
+	}
+	return fmt.Sprintf("const el = await page.$(%s); if (!el) return { found: false }; await el.click({ button: %q, clickCount: %d }); return { found: true };", sel, button, clickCount)
+}
+
+func (b BrowsersCmd) computerClickSelector(ctx context.Context, in BrowsersComputerClickMouseInput) error {
+	if b.playwright == nil {
+		pterm.Error.Println("playwright service not available")
+		return nil
+	}
+	switch in.Button {
+	case "", "left", "right", "middle":
+	default:
+		pterm.Error.Printf("--button %s is not supported with --selector: use left, right or middle\n", in.Button)
+		return nil
+	}
+	if in.ClickType != "" && in.ClickType != "click" {
+		pterm.Error.Println("--click-type is not supported with --selector")
+		return nil
+	}
+	if len(in.HoldKeys) > 0 {
+		pterm.Error.Println("--hold-key is not supported with --selector")
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	res, err := b.playwright.Execute(ctx, br.SessionID, kernel.BrowserPlaywrightExecuteParams{Code: clickSelectorCode(in.Selector, in.Button, in.NumClicks)})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	if !res.Success {
+		return fmt.Errorf("click on %q failed: %s", in.Selector, res.Error)
+	}
+	if !selectorFound(res.Result) {
+		return fmt.Errorf("no element matches selector %q", in.Selector)
+	}
+	pterm.Success.Printf("Clicked element %s\n", in.Selector)
+	return nil
+}
+
+// selectorFound reads the { found } object returned by the selector snippets.
+func selectorFound(result any) bool {
+	var out struct {
+		Found bool `json:"found"`
+	}
+	bs, err := json.Marshal(result)
+	return err == nil && json.Unmarshal(bs, &out) == nil && out.Found
+}
+
+type BrowsersComputerScrollIntoViewInput struct {
+	Identifier string
+	Selector   string
+}
+
+// scrollIntoViewCode returns a Playwright snippet that scrolls the first
+// element matching selector into view.
+func scrollIntoViewCode(selector string) string {
+	sel, _ := json.Marshal(selector)
+	return fmt.Sprintf("const el = await page.$(%s); if (!el) return { found: false }; await el.evaluate((e) => e.scrollIntoView()); return { found: true };", sel)
+}
+
+func (b BrowsersCmd) ComputerScrollIntoView(ctx context.Context, in BrowsersComputerScrollIntoViewInput) error {
+	if b.playwright == nil {
+		pterm.Error.Println("playwright service not available")
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	res, err := b.playwright.Execute(ctx, br.SessionID, kernel.BrowserPlaywrightExecuteParams{Code: scrollIntoViewCode(in.Selector)})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	if !res.Success {
+		return fmt.Errorf("scroll to %q failed: %s", in.Selector, res.Error)
+	}
+	if !selectorFound(res.Result) {
+		return fmt.Errorf("no element matches selector %q", in.Selector)
+	}
+	pterm.Success.Printf("Scrolled element %s into view\n", in.Selector)
+	return nil
+}
+
 func (b BrowsersCmd) ComputerMoveMouse(ctx context.Context, in BrowsersComputerMoveMouseInput) error {
 	if b.computer == nil {
 		pterm.Error.Println("computer service not available")
@@ -686,39 +1748,129 @@ func (b BrowsersCmd) ComputerMoveMouse(ctx context.Context, in BrowsersComputerM
 }
 
 func (b BrowsersCmd) ComputerScreenshot(ctx context.Context, in BrowsersComputerScreenshotInput) error {
-	if b.computer == nil {
-		pterm.Error.Println("computer service not available")
+	if !checkOutput(in.Output, outputJSON) {
 		return nil
 	}
-	br, err := b.browsers.Get(ctx, in.Identifier)
+	format, err := parseScreenshotFormat(in.Format)
 	if err != nil {
-		return util.CleanedUpSdkError{Err: err}
+		pterm.Error.Println(err.Error())
+		return nil
+	}
+	quality := in.Quality
+	if quality == 0 {
+		quality = defaultJPEGQuality
+	}
+	if quality < 1 || quality > 100 {
+		pterm.Error.Println("--quality must be between 1 and 100")
+		return nil
 	}
-	var body kernel.BrowserComputerCaptureScreenshotParams
-	if in.HasRegion {
-		body.Region = kernel.BrowserComputerCaptureScreenshotParamsRegion{X: in.X, Y: in.Y, Width: in.Width, Height: in.Height}
+	if in.FullPage {
+		if b.playwright == nil {
+			pterm.Error.Println("playwright service not available")
+			return nil
+		}
+		if in.HasRegion {
+			pterm.Error.Println("--full-page cannot be combined with a region")
+			return nil
+		}
+	} else if b.computer == nil {
+		pterm.Error.Println("computer service not available")
+		return nil
 	}
-	res, err := b.computer.CaptureScreenshot(ctx, br.SessionID, body)
+	var br *kernel.BrowserGetResponse
+	err = withRetry(ctx, in.Retry, func() (err error) {
+		br, err = b.browsers.Get(ctx, in.Identifier)
+		return err
+	})
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-	defer res.Body.Close()
+	var image io.Reader
+	if in.FullPage {
+		data, err := b.fullPageScreenshot(ctx, br.SessionID)
+		if err != nil {
+			return err
+		}
+		image = bytes.NewReader(data)
+	} else {
+		var body kernel.BrowserComputerCaptureScreenshotParams
+		if in.HasRegion {
+			body.Region = kernel.BrowserComputerCaptureScreenshotParamsRegion{X: in.X, Y: in.Y, Width: in.Width, Height: in.Height}
+		}
+		var res *http.Response
+		err = withRetry(ctx, in.Retry, func() (err error) {
+			res, err = b.computer.CaptureScreenshot(ctx, br.SessionID, body)
+			return err
+		})
+		if err != nil {
+			return util.CleanedUpSdkError{Err: err}
+		}
+		defer res.Body.Close()
+		image = res.Body
+	}
 	if in.To == "" {
 		pterm.Error.Println("--to is required to save the screenshot")
 		return nil
 	}
-	f, err := os.Create(in.To)
+	if format == "jpeg" {
+		data, err := pngToJPEG(image, quality)
+		if err != nil {
+			return err
+		}
+		image = bytes.NewReader(data)
+	}
+	to := screenshotFilePath(in.To, format)
+	f, err := os.Create(to)
 	if err != nil {
 		pterm.Error.Printf("Failed to create file: %v\n", err)
 		return nil
 	}
 	defer f.Close()
-	if _, err := io.Copy(f, res.Body); err != nil {
+	n, err := io.Copy(f, image)
+	if err != nil {
 		pterm.Error.Printf("Failed to write file: %v\n", err)
 		return nil
 	}
-	pterm.Success.Printf("Saved screenshot to %s\n", in.To)
-	return nil
+	if in.Output != outputJSON {
+		success := &pterm.Success
+		if in.StatusToStderr {
+			success = pterm.Success.WithWriter(os.Stderr)
+		}
+		success.Printf("Saved screenshot to %s\n", to)
+		return nil
+	}
+	res := screenshotResult{Path: to, Bytes: n, ContentType: "image/" + format}
+	if _, err := f.Seek(0, io.SeekStart); err == nil {
+		decodeConfig := png.DecodeConfig
+		if format == "jpeg" {
+			decodeConfig = jpeg.DecodeConfig
+		}
+		if cfg, err := decodeConfig(f); err == nil {
+			res.Width, res.Height = cfg.Width, cfg.Height
+		}
+	}
+	return printStructured(res, outputJSON)
+}
+
+// fullPageScreenshot captures the whole scrollable page with Playwright and
+// returns the decoded PNG.
+func (b BrowsersCmd) fullPageScreenshot(ctx context.Context, sessionID string) ([]byte, error) {
+	res, err := b.playwright.Execute(ctx, sessionID, kernel.BrowserPlaywrightExecuteParams{Code: fullPageScreenshotCode})
+	if err != nil {
+		return nil, util.CleanedUpSdkError{Err: err}
+	}
+	if !res.Success {
+		return nil, fmt.Errorf("full-page screenshot failed: %s", res.Error)
+	}
+	encoded, ok := res.Result.(string)
+	if !ok {
+		return nil, fmt.Errorf("full-page screenshot returned unexpected result type %T", res.Result)
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode full-page screenshot: %w", err)
+	}
+	return data, nil
 }
 
 func (b BrowsersCmd) ComputerTypeText(ctx context.Context, in BrowsersComputerTypeTextInput) error {
@@ -769,6 +1921,17 @@ func (b BrowsersCmd) ComputerPressKey(ctx context.Context, in BrowsersComputerPr
 }
 
 func (b BrowsersCmd) ComputerScroll(ctx context.Context, in BrowsersComputerScrollInput) error {
+	if in.ToBottom && in.ToTop {
+		pterm.Error.Println("--to-bottom and --to-top are mutually exclusive")
+		return nil
+	}
+	if in.ToBottom || in.ToTop {
+		if in.DeltaXSet || in.DeltaYSet || len(in.HoldKeys) > 0 {
+			pterm.Error.Println("--delta-x, --delta-y and --hold-key cannot be used with --to-bottom or --to-top")
+			return nil
+		}
+		return b.computerScrollToEdge(ctx, in)
+	}
 	if b.computer == nil {
 		pterm.Error.Println("computer service not available")
 		return nil
@@ -794,6 +1957,49 @@ func (b BrowsersCmd) ComputerScroll(ctx context.Context, in BrowsersComputerScro
 	return nil
 }
 
+// scrollToEdgeCode returns a Playwright snippet that scrolls the page to the
+// top or bottom and returns the resulting scroll offsets.
+func scrollToEdgeCode(toBottom bool) string {
+	target := "0"
+	if toBottom {
+		target = "document.scrollingElement ? document.scrollingElement.scrollHeight : document.body.scrollHeight"
+	}
+	return fmt.Sprintf("return await page.evaluate(() => { window.scrollTo(0, %s); return { x: Math.round(window.scrollX), y: Math.round(window.scrollY) }; });", target)
+}
+
+func (b BrowsersCmd) computerScrollToEdge(ctx context.Context, in BrowsersComputerScrollInput) error {
+	if b.playwright == nil {
+		pterm.Error.Println("playwright service not available")
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	res, err := b.playwright.Execute(ctx, br.SessionID, kernel.BrowserPlaywrightExecuteParams{Code: scrollToEdgeCode(in.ToBottom)})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	if !res.Success {
+		pterm.Error.Printf("scroll failed: %s\n", res.Error)
+		return nil
+	}
+	edge := "top"
+	if in.ToBottom {
+		edge = "bottom"
+	}
+	var pos struct {
+		X int64 `json:"x"`
+		Y int64 `json:"y"`
+	}
+	if bs, err := json.Marshal(res.Result); err == nil && json.Unmarshal(bs, &pos) == nil {
+		pterm.Success.Printf("Scrolled to %s (scroll position: %d,%d)\n", edge, pos.X, pos.Y)
+		return nil
+	}
+	pterm.Success.Printf("Scrolled to %s\n", edge)
+	return nil
+}
+
 func (b BrowsersCmd) ComputerDragMouse(ctx context.Context, in BrowsersComputerDragMouseInput) error {
 	if b.computer == nil {
 		pterm.Error.Println("computer service not available")
@@ -855,6 +2061,10 @@ func (b BrowsersCmd) ComputerSetCursor(ctx context.Context, in BrowsersComputerS
 // Replays
 type BrowsersReplaysListInput struct {
 	Identifier string
+	// Active keeps replays that are still recording; Finished keeps the rest.
+	Active   bool
+	Finished bool
+	Output   string
 }
 
 type BrowsersReplaysStartInput struct {
@@ -866,6 +2076,22 @@ type BrowsersReplaysStartInput struct {
 type BrowsersReplaysStopInput struct {
 	Identifier string
 	ReplayID   string
+	// All stops every replay that is still recording instead of ReplayID.
+	All bool
+}
+
+// filterReplays keeps still-recording replays when active is set and
+// finished ones when finished is set; with neither, all replays are kept.
+func filterReplays(items []kernel.BrowserReplayListResponse, active, finished bool) []kernel.BrowserReplayListResponse {
+	out := []kernel.BrowserReplayListResponse{}
+	for _, r := range items {
+		done := !r.FinishedAt.IsZero()
+		if (active && done) || (finished && !done) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
 }
 
 type BrowsersReplaysDownloadInput struct {
@@ -875,20 +2101,40 @@ type BrowsersReplaysDownloadInput struct {
 }
 
 func (b BrowsersCmd) ReplaysList(ctx context.Context, in BrowsersReplaysListInput) error {
+	if in.Output != "" && in.Output != "json" {
+		pterm.Error.Println("unsupported --output value: use 'json'")
+		return nil
+	}
+	if in.Active && in.Finished {
+		pterm.Error.Println("--active and --finished cannot be used together")
+		return nil
+	}
 	br, err := b.browsers.Get(ctx, in.Identifier)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-	items, err := b.replays.List(ctx, br.SessionID)
+	res, err := b.replays.List(ctx, br.SessionID)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-	if items == nil || len(*items) == 0 {
+	items := []kernel.BrowserReplayListResponse{}
+	if res != nil {
+		items = filterReplays(*res, in.Active, in.Finished)
+	}
+	if in.Output == "json" {
+		bs, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bs))
+		return nil
+	}
+	if len(items) == 0 {
 		pterm.Info.Println("No replays found")
 		return nil
 	}
 	rows := pterm.TableData{{"Replay ID", "Started At", "Finished At", "View URL"}}
-	for _, r := range *items {
+	for _, r := range items {
 		rows = append(rows, []string{r.ReplayID, util.FormatLocal(r.StartedAt), util.FormatLocal(r.FinishedAt), truncateURL(r.ReplayViewURL, 60)})
 	}
 	PrintTableNoPad(rows, true)
@@ -917,10 +2163,17 @@ func (b BrowsersCmd) ReplaysStart(ctx context.Context, in BrowsersReplaysStartIn
 }
 
 func (b BrowsersCmd) ReplaysStop(ctx context.Context, in BrowsersReplaysStopInput) error {
+	if in.All == (in.ReplayID != "") {
+		pterm.Error.Println("specify either a replay ID or --all")
+		return nil
+	}
 	br, err := b.browsers.Get(ctx, in.Identifier)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
+	if in.All {
+		return b.stopActiveReplays(ctx, br.SessionID)
+	}
 	err = b.replays.Stop(ctx, in.ReplayID, kernel.BrowserReplayStopParams{ID: br.SessionID})
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
@@ -929,22 +2182,54 @@ func (b BrowsersCmd) ReplaysStop(ctx context.Context, in BrowsersReplaysStopInpu
 	return nil
 }
 
-func (b BrowsersCmd) ReplaysDownload(ctx context.Context, in BrowsersReplaysDownloadInput) error {
-	br, err := b.browsers.Get(ctx, in.Identifier)
+// stopActiveReplays stops every replay of the session that is still
+// recording, reporting each result.
+func (b BrowsersCmd) stopActiveReplays(ctx context.Context, sessionID string) error {
+	res, err := b.replays.List(ctx, sessionID)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-	res, err := b.replays.Download(ctx, in.ReplayID, kernel.BrowserReplayDownloadParams{ID: br.SessionID})
-	if err != nil {
-		return util.CleanedUpSdkError{Err: err}
+	var active []kernel.BrowserReplayListResponse
+	if res != nil {
+		active = filterReplays(*res, true, false)
 	}
-	defer res.Body.Close()
-	if in.Output == "" {
-		pterm.Info.Printf("Downloaded replay %s (%s)\n", in.ReplayID, res.Header.Get("content-type"))
-		_, _ = io.Copy(io.Discard, res.Body)
+	if len(active) == 0 {
+		pterm.Info.Println("No active replays to stop")
 		return nil
 	}
-	f, err := os.Create(in.Output)
+	rows := pterm.TableData{{"Replay ID", "Result"}}
+	failed := 0
+	for _, r := range active {
+		result := "stopped"
+		if err := b.replays.Stop(ctx, r.ReplayID, kernel.BrowserReplayStopParams{ID: sessionID}); err != nil {
+			failed++
+			result = "error: " + util.CleanedUpSdkError{Err: err}.Error()
+		}
+		rows = append(rows, []string{r.ReplayID, result})
+	}
+	PrintTableNoPad(rows, true)
+	if failed > 0 {
+		return fmt.Errorf("failed to stop %d of %d replays", failed, len(active))
+	}
+	return nil
+}
+
+func (b BrowsersCmd) ReplaysDownload(ctx context.Context, in BrowsersReplaysDownloadInput) error {
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	res, err := b.replays.Download(ctx, in.ReplayID, kernel.BrowserReplayDownloadParams{ID: br.SessionID})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	defer res.Body.Close()
+	if in.Output == "" {
+		pterm.Info.Printf("Downloaded replay %s (%s)\n", in.ReplayID, res.Header.Get("content-type"))
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil
+	}
+	f, err := os.Create(in.Output)
 	if err != nil {
 		pterm.Error.Printf("Failed to create file: %v\n", err)
 		return nil
@@ -958,6 +2243,147 @@ func (b BrowsersCmd) ReplaysDownload(ctx context.Context, in BrowsersReplaysDown
 	return nil
 }
 
+type BrowsersExportInput struct {
+	Identifier string
+	// To is the bundle directory; with Zip it becomes To + ".zip".
+	To  string
+	Zip bool
+	// FSPath is the directory whose listing is included.
+	FSPath string
+	// LogTimeout bounds how long recent supervisor logs are read.
+	LogTimeout time.Duration
+}
+
+// defaultExportLogTimeout caps the log read so a chatty stream can't stall
+// the export.
+const defaultExportLogTimeout = 10 * time.Second
+
+// Export gathers what is usually asked for in a bug report into one
+// directory: the session, a screenshot, recent Chromium supervisor logs, a
+// file listing and the replays. Parts that can't be fetched are noted in
+// errors.txt instead of failing the export.
+func (b BrowsersCmd) Export(ctx context.Context, in BrowsersExportInput) error {
+	if in.To == "" {
+		pterm.Error.Println("--to is required")
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	if err := os.MkdirAll(in.To, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", in.To, err)
+	}
+
+	var problems []string
+	record := func(name string, err error) {
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			pterm.Warning.Printf("Skipped %s: %v\n", name, err)
+		}
+	}
+	writeJSON := func(name string, v any) error {
+		bs, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(in.To, name), append(bs, '\n'), 0o644)
+	}
+
+	record("browser.json", writeJSON("browser.json", br))
+	record("screenshot.png", b.exportScreenshot(ctx, br.SessionID, filepath.Join(in.To, "screenshot.png")))
+	record("logs.txt", b.exportLogs(ctx, br.SessionID, filepath.Join(in.To, "logs.txt"), in.LogTimeout))
+	if b.fs == nil {
+		record("files.json", errors.New("fs service not available"))
+	} else {
+		fsPath := in.FSPath
+		if fsPath == "" {
+			fsPath = "/tmp"
+		}
+		files, err := b.fs.ListFiles(ctx, br.SessionID, kernel.BrowserFListFilesParams{Path: fsPath})
+		if err != nil {
+			record("files.json", util.CleanedUpSdkError{Err: err})
+		} else {
+			record("files.json", writeJSON("files.json", files))
+		}
+	}
+	if b.replays == nil {
+		record("replays.json", errors.New("replays service not available"))
+	} else {
+		replays, err := b.replays.List(ctx, br.SessionID)
+		if err != nil {
+			record("replays.json", util.CleanedUpSdkError{Err: err})
+		} else {
+			record("replays.json", writeJSON("replays.json", replays))
+		}
+	}
+	if len(problems) > 0 {
+		record("errors.txt", os.WriteFile(filepath.Join(in.To, "errors.txt"), []byte(strings.Join(problems, "\n")+"\n"), 0o644))
+	}
+
+	dest := in.To
+	if in.Zip {
+		dest = strings.TrimSuffix(in.To, string(filepath.Separator)) + ".zip"
+		if err := util.ZipDirectory(in.To, dest); err != nil {
+			return fmt.Errorf("failed to zip bundle: %w", err)
+		}
+		if err := os.RemoveAll(in.To); err != nil {
+			return fmt.Errorf("failed to remove %s after zipping: %w", in.To, err)
+		}
+	}
+	pterm.Success.Printf("Exported browser %s to %s\n", br.SessionID, dest)
+	return nil
+}
+
+func (b BrowsersCmd) exportScreenshot(ctx context.Context, sessionID, dest string) error {
+	if b.computer == nil {
+		return errors.New("computer service not available")
+	}
+	res, err := b.computer.CaptureScreenshot(ctx, sessionID, kernel.BrowserComputerCaptureScreenshotParams{})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	defer res.Body.Close()
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, res.Body)
+	return err
+}
+
+// exportLogs writes the Chromium supervisor log backlog without following it.
+func (b BrowsersCmd) exportLogs(ctx context.Context, sessionID, dest string, timeout time.Duration) error {
+	if b.logs == nil {
+		return errors.New("logs service not available")
+	}
+	if timeout <= 0 {
+		timeout = defaultExportLogTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	stream := b.logs.StreamStreaming(ctx, sessionID, kernel.BrowserLogStreamParams{
+		Source:            kernel.BrowserLogStreamParamsSourceSupervisor,
+		SupervisorProcess: kernel.Opt("chromium"),
+		Follow:            kernel.Opt(false),
+	})
+	defer stream.Close()
+	for stream.Next() {
+		ev := stream.Current()
+		fmt.Fprintf(f, "[%s] %s\n", ev.Timestamp.UTC().Format(time.RFC3339Nano), ev.Message)
+	}
+	if err := stream.Err(); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	return nil
+}
+
 // Process
 type BrowsersProcessExecInput struct {
 	Identifier string
@@ -967,30 +2393,106 @@ type BrowsersProcessExecInput struct {
 	Timeout    int
 	AsUser     string
 	AsRoot     BoolFlag
+	// StdinFile is written to the process's stdin once it starts ("-" reads
+	// this CLI's stdin). Only spawn supports it: exec has no stdin.
+	StdinFile string
+	// OutputFile keeps a local copy of the process's stdout and stderr. The
+	// API does not retain output, so spawn follows the process until it
+	// exits to capture it.
+	OutputFile string
+	// Attach pipes this CLI's stdin and stdout to the spawned process until
+	// it exits, printing nothing else. Only spawn supports it.
+	Attach bool
 }
 
 type BrowsersProcessSpawnInput = BrowsersProcessExecInput
 
+// readStdinFile reads the --stdin-file source, where "-" is os.Stdin.
+func readStdinFile(path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return data, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdin file: %w", err)
+	}
+	return data, nil
+}
+
 type BrowsersProcessKillInput struct {
 	Identifier string
 	ProcessID  string
 	Signal     string
 }
 
+type BrowsersProcessKillAllInput struct {
+	Identifier string
+	Signal     string
+}
+
 type BrowsersProcessStatusInput struct {
 	Identifier string
 	ProcessID  string
+	// Watch re-renders the status every Interval until the process exits.
+	Watch    bool
+	Interval time.Duration
 }
 
+// defaultProcessWatchInterval is the polling interval for process status --watch.
+const defaultProcessWatchInterval = 2 * time.Second
+
 type BrowsersProcessStdinInput struct {
 	Identifier string
 	ProcessID  string
-	DataB64    string
+	// Exactly one of DataB64 (pre-encoded), Data or File is used as input.
+	DataB64 string
+	Data    string
+	File    string
+	// Newline appends "\n" to Data or File contents, e.g. to submit a line to
+	// an interactive process. DataB64 is always sent as given.
+	Newline bool
+}
+
+// stdinPayload returns the base64 data to send for a stdin request,
+// encoding Data or File contents when given.
+func (in BrowsersProcessStdinInput) stdinPayload() (string, error) {
+	sources := 0
+	for _, v := range []string{in.DataB64, in.Data, in.File} {
+		if v != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return "", fmt.Errorf("specify exactly one of --data, --data-file or --data-b64")
+	}
+	if in.DataB64 != "" {
+		return in.DataB64, nil
+	}
+	data := []byte(in.Data)
+	if in.File != "" {
+		var err error
+		if data, err = os.ReadFile(in.File); err != nil {
+			return "", fmt.Errorf("failed to read stdin file: %w", err)
+		}
+	}
+	if in.Newline {
+		data = append(data, '\n')
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
 }
 
 type BrowsersProcessStdoutStreamInput struct {
 	Identifier string
 	ProcessID  string
+	// OutputFile also receives the decoded output, including stderr unless
+	// StderrFile is set.
+	OutputFile string
+	// StderrFile receives stderr events instead of OutputFile.
+	StderrFile string
 }
 
 // Playwright
@@ -998,6 +2500,18 @@ type BrowsersPlaywrightExecuteInput struct {
 	Identifier string
 	Code       string
 	Timeout    int64
+	// JSON prints a single playwrightExecuteResult to stdout and sends
+	// everything else to stderr.
+	JSON bool
+}
+
+// playwrightExecuteResult is the --json output of `browsers playwright execute`.
+type playwrightExecuteResult struct {
+	Success bool   `json:"success"`
+	Stdout  string `json:"stdout"`
+	Stderr  string `json:"stderr"`
+	Result  any    `json:"result"`
+	Error   string `json:"error"`
 }
 
 func (b BrowsersCmd) PlaywrightExecute(ctx context.Context, in BrowsersPlaywrightExecuteInput) error {
@@ -1018,6 +2532,19 @@ func (b BrowsersCmd) PlaywrightExecute(ctx context.Context, in BrowsersPlaywrigh
 		return util.CleanedUpSdkError{Err: err}
 	}
 
+	if in.JSON {
+		bs, err := json.MarshalIndent(playwrightExecuteResult{Success: res.Success, Stdout: res.Stdout, Stderr: res.Stderr, Result: res.Result, Error: res.Error}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bs))
+		if !res.Success {
+			// The JSON already carries the error; keep stdout parseable.
+			return reportedError{fmt.Errorf("playwright execution failed: %s", res.Error)}
+		}
+		return nil
+	}
+
 	rows := pterm.TableData{{"Property", "Value"}, {"Success", fmt.Sprintf("%t", res.Success)}}
 	PrintTableNoPad(rows, true)
 
@@ -1042,6 +2569,177 @@ func (b BrowsersCmd) PlaywrightExecute(ctx context.Context, in BrowsersPlaywrigh
 	return nil
 }
 
+// browserAssertion is one check run by `browsers assert`. Text and Attr are
+// optional; with neither set the element only has to exist.
+type browserAssertion struct {
+	Selector string `json:"selector"`
+	// Text must appear in the element's inner text.
+	Text string `json:"text,omitempty"`
+	// Attr is "name" (attribute present) or "name=value".
+	Attr string `json:"attr,omitempty"`
+}
+
+// browserAssertionResult reports the outcome of one browserAssertion.
+type browserAssertionResult struct {
+	browserAssertion
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+type BrowsersAssertInput struct {
+	Identifier string
+	Assertions []browserAssertion
+	Output     string
+}
+
+// assertPageCode returns a Playwright snippet that looks up every selector
+// and returns what each assertion needs: whether it matched, its inner text
+// and the requested attribute.
+func assertPageCode(assertions []browserAssertion) string {
+	type check struct {
+		Selector string `json:"selector"`
+		Text     bool   `json:"text"`
+		Attr     string `json:"attr"`
+	}
+	checks := make([]check, len(assertions))
+	for i, a := range assertions {
+		name, _, _ := strings.Cut(a.Attr, "=")
+		checks[i] = check{Selector: a.Selector, Text: a.Text != "", Attr: name}
+	}
+	bs, _ := json.Marshal(checks)
+	return fmt.Sprintf("const checks = %s; const out = []; for (const c of checks) { const el = await page.$(c.selector); const r = { found: !!el }; if (el && c.text) r.text = await el.innerText(); if (el && c.attr) r.attr = await el.getAttribute(c.attr); out.push(r); } return out;", bs)
+}
+
+// evaluateAssertion checks one assertion against what assertPageCode found.
+func evaluateAssertion(a browserAssertion, found bool, text string, attr *string) browserAssertionResult {
+	res := browserAssertionResult{browserAssertion: a}
+	name, want, hasValue := strings.Cut(a.Attr, "=")
+	switch {
+	case !found:
+		res.Message = "no element matches selector"
+	case a.Text != "" && !strings.Contains(text, a.Text):
+		res.Message = fmt.Sprintf("text %q not found in %q", a.Text, truncateURL(text, 80))
+	case a.Attr != "" && attr == nil:
+		res.Message = fmt.Sprintf("attribute %q is missing", name)
+	case hasValue && *attr != want:
+		res.Message = fmt.Sprintf("attribute %q is %q, want %q", name, *attr, want)
+	default:
+		res.Passed = true
+	}
+	return res
+}
+
+// Assert runs every assertion against the current page in a single
+// Playwright call and fails if any of them does not hold.
+func (b BrowsersCmd) Assert(ctx context.Context, in BrowsersAssertInput) error {
+	if !checkOutput(in.Output, outputJSON) {
+		return nil
+	}
+	if b.playwright == nil {
+		pterm.Error.Println("playwright service not available")
+		return nil
+	}
+	if len(in.Assertions) == 0 {
+		pterm.Error.Println("at least one --selector is required")
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	res, err := b.playwright.Execute(ctx, br.SessionID, kernel.BrowserPlaywrightExecuteParams{Code: assertPageCode(in.Assertions)})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	if !res.Success {
+		return fmt.Errorf("assertion script failed: %s", res.Error)
+	}
+	var found []struct {
+		Found bool    `json:"found"`
+		Text  string  `json:"text"`
+		Attr  *string `json:"attr"`
+	}
+	if bs, err := json.Marshal(res.Result); err != nil || json.Unmarshal(bs, &found) != nil || len(found) != len(in.Assertions) {
+		return fmt.Errorf("unexpected result from assertion script")
+	}
+
+	results := make([]browserAssertionResult, len(in.Assertions))
+	failed := 0
+	for i, a := range in.Assertions {
+		results[i] = evaluateAssertion(a, found[i].Found, found[i].Text, found[i].Attr)
+		if !results[i].Passed {
+			failed++
+		}
+	}
+
+	if in.Output == outputJSON {
+		if err := printStructured(results, outputJSON); err != nil {
+			return err
+		}
+	} else {
+		rows := pterm.TableData{{"Selector", "Check", "Result"}}
+		for _, r := range results {
+			var checks []string
+			if r.Text != "" {
+				checks = append(checks, "text: "+r.Text)
+			}
+			if r.Attr != "" {
+				checks = append(checks, "attr: "+r.Attr)
+			}
+			if len(checks) == 0 {
+				checks = append(checks, "exists")
+			}
+			result := "pass"
+			if !r.Passed {
+				result = "FAIL: " + r.Message
+			}
+			rows = append(rows, []string{r.Selector, strings.Join(checks, ", "), result})
+		}
+		PrintTableNoPad(rows, true)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d assertions failed", failed, len(results))
+	}
+	return nil
+}
+
+type BrowsersSSHConfigInput struct {
+	Identifier string
+	// HostAlias is the Host entry name; defaults to kernel-<session id>.
+	HostAlias string
+	User      string
+	Port      int
+	// CLIPath is the kernel binary the ProxyCommand invokes.
+	CLIPath string
+}
+
+// SSHConfig prints an ssh_config Host entry whose ProxyCommand reaches the
+// session's sshd through `kernel browsers process spawn --attach`.
+func (b BrowsersCmd) SSHConfig(ctx context.Context, in BrowsersSSHConfigInput) error {
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	alias := in.HostAlias
+	if alias == "" {
+		alias = "kernel-" + br.SessionID
+	}
+	cli := in.CLIPath
+	if strings.ContainsAny(cli, " \t") {
+		cli = strconv.Quote(cli)
+	}
+	fmt.Printf(`# Kernel browser session %s
+Host %s
+  HostName localhost
+  Port %d
+  User %s
+  ProxyCommand %s browsers process spawn %s --as-root --attach -- nc localhost %%p
+  StrictHostKeyChecking no
+  UserKnownHostsFile /dev/null
+`, br.SessionID, alias, in.Port, in.User, cli, br.SessionID)
+	return nil
+}
+
 func (b BrowsersCmd) ProcessExec(ctx context.Context, in BrowsersProcessExecInput) error {
 	if b.process == nil {
 		pterm.Error.Println("process service not available")
@@ -1067,6 +2765,14 @@ func (b BrowsersCmd) ProcessExec(ctx context.Context, in BrowsersProcessExecInpu
 	if in.AsRoot.Set {
 		params.AsRoot = kernel.Opt(in.AsRoot.Value)
 	}
+	// Open the output file first so a bad path fails before the command runs.
+	var outFile io.WriteCloser
+	if in.OutputFile != "" {
+		if outFile, err = openLogOutput(in.OutputFile, false); err != nil {
+			return err
+		}
+		defer outFile.Close()
+	}
 	res, err := b.process.Exec(ctx, br.SessionID, params)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
@@ -1083,6 +2789,11 @@ func (b BrowsersCmd) ProcessExec(ctx context.Context, in BrowsersProcessExecInpu
 			if data[len(data)-1] != '\n' {
 				fmt.Println()
 			}
+			if outFile != nil {
+				if _, err := outFile.Write(data); err != nil {
+					return fmt.Errorf("failed to write output file: %w", err)
+				}
+			}
 		}
 	}
 	if res.StderrB64 != "" {
@@ -1095,8 +2806,16 @@ func (b BrowsersCmd) ProcessExec(ctx context.Context, in BrowsersProcessExecInpu
 			if data[len(data)-1] != '\n' {
 				fmt.Fprintln(os.Stderr)
 			}
+			if outFile != nil {
+				if _, err := outFile.Write(data); err != nil {
+					return fmt.Errorf("failed to write output file: %w", err)
+				}
+			}
 		}
 	}
+	if outFile != nil {
+		pterm.Success.Printf("Saved output to %s\n", in.OutputFile)
+	}
 	return nil
 }
 
@@ -1109,6 +2828,21 @@ func (b BrowsersCmd) ProcessSpawn(ctx context.Context, in BrowsersProcessSpawnIn
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
+	// Read input and open the output file before spawning so a bad path
+	// doesn't leave a process waiting on stdin or running unobserved.
+	var stdin []byte
+	if in.StdinFile != "" {
+		if stdin, err = readStdinFile(in.StdinFile); err != nil {
+			return err
+		}
+	}
+	var outFile io.WriteCloser
+	if in.OutputFile != "" {
+		if outFile, err = openLogOutput(in.OutputFile, false); err != nil {
+			return err
+		}
+		defer outFile.Close()
+	}
 	params := kernel.BrowserProcessSpawnParams{Command: in.Command}
 	if len(in.Args) > 0 {
 		params.Args = in.Args
@@ -1129,8 +2863,34 @@ func (b BrowsersCmd) ProcessSpawn(ctx context.Context, in BrowsersProcessSpawnIn
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
+	if b.tracker != nil {
+		if err := b.tracker.Add(br.SessionID, res.ProcessID); err != nil && logger != nil {
+			logger.Debug("failed to track spawned process", logger.Args("process", res.ProcessID, "error", err.Error()))
+		}
+	}
+	if in.Attach {
+		return b.attachProcess(ctx, br.SessionID, res.ProcessID, os.Stdin, os.Stdout, os.Stderr)
+	}
+	if len(stdin) > 0 {
+		payload := base64.StdEncoding.EncodeToString(stdin)
+		if _, err := b.process.Stdin(ctx, res.ProcessID, kernel.BrowserProcessStdinParams{ID: br.SessionID, DataB64: payload}); err != nil {
+			return fmt.Errorf("process %s started but writing stdin failed: %w", res.ProcessID, util.CleanedUpSdkError{Err: err})
+		}
+	}
 	rows := pterm.TableData{{"Property", "Value"}, {"Process ID", res.ProcessID}, {"PID", fmt.Sprintf("%d", res.Pid)}, {"Started At", util.FormatLocal(res.StartedAt)}}
 	PrintTableNoPad(rows, true)
+	if outFile == nil {
+		return nil
+	}
+	stream := b.process.StdoutStreamStreaming(ctx, res.ProcessID, kernel.BrowserProcessStdoutStreamParams{ID: br.SessionID})
+	if stream == nil {
+		return fmt.Errorf("process %s started but its output stream could not be opened", res.ProcessID)
+	}
+	defer stream.Close()
+	if err := copyProcessOutput(stream, outFile, outFile); err != nil {
+		return err
+	}
+	pterm.Success.Printf("Saved output to %s\n", in.OutputFile)
 	return nil
 }
 
@@ -1152,34 +2912,122 @@ func (b BrowsersCmd) ProcessKill(ctx context.Context, in BrowsersProcessKillInpu
 	return nil
 }
 
-func (b BrowsersCmd) ProcessStatus(ctx context.Context, in BrowsersProcessStatusInput) error {
+// ProcessKillAll signals every process spawned in the session through this
+// CLI. Processes that were signalled or no longer exist stop being tracked.
+func (b BrowsersCmd) ProcessKillAll(ctx context.Context, in BrowsersProcessKillAllInput) error {
 	if b.process == nil {
 		pterm.Error.Println("process service not available")
 		return nil
 	}
+	if b.tracker == nil {
+		pterm.Error.Println("process tracker not available")
+		return nil
+	}
 	br, err := b.browsers.Get(ctx, in.Identifier)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-	res, err := b.process.Status(ctx, in.ProcessID, kernel.BrowserProcessStatusParams{ID: br.SessionID})
+	ids, err := b.tracker.List(br.SessionID)
 	if err != nil {
-		return util.CleanedUpSdkError{Err: err}
+		return err
+	}
+	if len(ids) == 0 {
+		pterm.Info.Printf("No tracked processes for %s\n", br.SessionID)
+		return nil
+	}
+
+	rows := pterm.TableData{{"Process ID", "Result"}}
+	var done []string
+	failed := 0
+	for _, id := range ids {
+		params := kernel.BrowserProcessKillParams{ID: br.SessionID, Signal: kernel.BrowserProcessKillParamsSignal(in.Signal)}
+		_, err := b.process.Kill(ctx, id, params)
+		switch {
+		case err == nil:
+			rows = append(rows, []string{id, "sent " + in.Signal})
+			done = append(done, id)
+		case util.IsNotFound(err):
+			rows = append(rows, []string{id, "not running"})
+			done = append(done, id)
+		default:
+			rows = append(rows, []string{id, "error: " + util.CleanedUpSdkError{Err: err}.Error()})
+			failed++
+		}
 	}
-	rows := pterm.TableData{{"Property", "Value"}, {"State", string(res.State)}, {"CPU %", fmt.Sprintf("%.2f", res.CPUPct)}, {"Mem Bytes", fmt.Sprintf("%d", res.MemBytes)}, {"Exit Code", fmt.Sprintf("%d", res.ExitCode)}}
 	PrintTableNoPad(rows, true)
+	if err := b.tracker.Remove(br.SessionID, done...); err != nil && logger != nil {
+		logger.Debug("failed to update process tracking", logger.Args("error", err.Error()))
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to signal %d of %d processes", failed, len(ids))
+	}
 	return nil
 }
 
+func (b BrowsersCmd) ProcessStatus(ctx context.Context, in BrowsersProcessStatusInput) error {
+	if b.process == nil {
+		pterm.Error.Println("process service not available")
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	params := kernel.BrowserProcessStatusParams{ID: br.SessionID}
+	if !in.Watch {
+		res, err := b.process.Status(ctx, in.ProcessID, params)
+		if err != nil {
+			return util.CleanedUpSdkError{Err: err}
+		}
+		PrintTableNoPad(processStatusRows(res), true)
+		return nil
+	}
+
+	interval := in.Interval
+	if interval <= 0 {
+		interval = defaultProcessWatchInterval
+	}
+	area, _ := pterm.DefaultArea.Start()
+	defer area.Stop()
+	for {
+		res, err := b.process.Status(ctx, in.ProcessID, params)
+		if err != nil {
+			return util.CleanedUpSdkError{Err: err}
+		}
+		area.Update(table.SprintTableNoPad(processStatusRows(res), true))
+		if res.State == kernel.BrowserProcessStatusResponseStateExited {
+			area.Stop()
+			pterm.Info.Printf("Process %s exited with code %d\n", in.ProcessID, res.ExitCode)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// processStatusRows renders a process status as a property table.
+func processStatusRows(res *kernel.BrowserProcessStatusResponse) pterm.TableData {
+	return pterm.TableData{{"Property", "Value"}, {"State", string(res.State)}, {"CPU %", fmt.Sprintf("%.2f", res.CPUPct)}, {"Memory", util.HumanBytes(res.MemBytes)}, {"Exit Code", fmt.Sprintf("%d", res.ExitCode)}}
+}
+
 func (b BrowsersCmd) ProcessStdin(ctx context.Context, in BrowsersProcessStdinInput) error {
 	if b.process == nil {
 		pterm.Error.Println("process service not available")
 		return nil
 	}
+	payload, err := in.stdinPayload()
+	if err != nil {
+		pterm.Error.Println(err.Error())
+		return nil
+	}
 	br, err := b.browsers.Get(ctx, in.Identifier)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-	_, err = b.process.Stdin(ctx, in.ProcessID, kernel.BrowserProcessStdinParams{ID: br.SessionID, DataB64: in.DataB64})
+	_, err = b.process.Stdin(ctx, in.ProcessID, kernel.BrowserProcessStdinParams{ID: br.SessionID, DataB64: payload})
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
@@ -1196,17 +3044,112 @@ func (b BrowsersCmd) ProcessStdoutStream(ctx context.Context, in BrowsersProcess
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
+	var outFile, errFile io.Writer
+	if in.OutputFile != "" {
+		f, err := openLogOutput(in.OutputFile, false)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		outFile, errFile = f, f
+	}
+	if in.StderrFile != "" {
+		f, err := openLogOutput(in.StderrFile, false)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		errFile = f
+	}
 	stream := b.process.StdoutStreamStreaming(ctx, in.ProcessID, kernel.BrowserProcessStdoutStreamParams{ID: br.SessionID})
 	if stream == nil {
 		pterm.Error.Println("failed to open stdout stream")
 		return nil
 	}
 	defer stream.Close()
-	for stream.Next() {
-		ev := stream.Current()
-		if ev.Event == "exit" {
-			pterm.Info.Printf("process exited with code %d\n", ev.ExitCode)
-			continue
+	return copyProcessOutput(stream, outFile, errFile)
+}
+
+// attachProcess forwards stdin to a spawned process and copies its stdout and
+// stderr to the given writers until it exits. Nothing else is written to
+// stdout, so the process can serve as a raw byte pipe such as an ssh
+// ProxyCommand.
+func (b BrowsersCmd) attachProcess(ctx context.Context, sessionID, processID string, stdin io.Reader, stdout, stderr io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream := b.process.StdoutStreamStreaming(ctx, processID, kernel.BrowserProcessStdoutStreamParams{ID: sessionID})
+	if stream == nil {
+		return fmt.Errorf("process %s started but its output stream could not be opened", processID)
+	}
+	defer stream.Close()
+	stdinErr := make(chan error, 1)
+	go func() {
+		if err := b.pumpProcessStdin(ctx, sessionID, processID, stdin); err != nil {
+			stdinErr <- err
+			cancel()
+		}
+	}()
+	for stream.Next() {
+		ev := stream.Current()
+		if ev.Event == "exit" {
+			if ev.ExitCode != 0 {
+				return fmt.Errorf("process exited with code %d", ev.ExitCode)
+			}
+			return nil
+		}
+		data, err := base64.StdEncoding.DecodeString(ev.DataB64)
+		if err != nil {
+			return fmt.Errorf("failed to decode process output: %w", err)
+		}
+		w := stdout
+		if ev.Stream == kernel.BrowserProcessStdoutStreamResponseStreamStderr {
+			w = stderr
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	select {
+	case err := <-stdinErr:
+		return err
+	default:
+	}
+	if err := stream.Err(); err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	return nil
+}
+
+// pumpProcessStdin writes r to the process's stdin as data arrives, until r
+// reaches EOF.
+func (b BrowsersCmd) pumpProcessStdin(ctx context.Context, sessionID, processID string, r io.Reader) error {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			payload := base64.StdEncoding.EncodeToString(buf[:n])
+			if _, werr := b.process.Stdin(ctx, processID, kernel.BrowserProcessStdinParams{ID: sessionID, DataB64: payload}); werr != nil {
+				return util.CleanedUpSdkError{Err: werr}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+	}
+}
+
+// copyProcessOutput prints a process output stream to stdout until it ends,
+// also writing stdout events to outFile and stderr events to errFile when
+// they are non-nil.
+func copyProcessOutput(stream *ssestream.Stream[kernel.BrowserProcessStdoutStreamResponse], outFile, errFile io.Writer) error {
+	for stream.Next() {
+		ev := stream.Current()
+		if ev.Event == "exit" {
+			pterm.Info.Printf("process exited with code %d\n", ev.ExitCode)
+			continue
 		}
 		data, err := base64.StdEncoding.DecodeString(ev.DataB64)
 		if err != nil {
@@ -1214,6 +3157,15 @@ func (b BrowsersCmd) ProcessStdoutStream(ctx context.Context, in BrowsersProcess
 			continue
 		}
 		os.Stdout.Write(data)
+		file := outFile
+		if ev.Stream == kernel.BrowserProcessStdoutStreamResponseStreamStderr {
+			file = errFile
+		}
+		if file != nil {
+			if _, err := file.Write(data); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+		}
 	}
 	if err := stream.Err(); err != nil {
 		return util.CleanedUpSdkError{Err: err}
@@ -1242,6 +3194,11 @@ type BrowsersFSDownloadDirZipInput struct {
 	Identifier string
 	Path       string
 	Output     string
+	// ExtractDir, when set, is where the zip is unpacked. The zip itself is
+	// only kept if Output is also set.
+	ExtractDir string
+	// StripComponents drops leading path components when extracting.
+	StripComponents int
 }
 
 type BrowsersFSFileInfoInput struct {
@@ -1249,21 +3206,80 @@ type BrowsersFSFileInfoInput struct {
 	Path       string
 }
 
+type BrowsersFSStatInput struct {
+	Identifier  string
+	Paths       []string
+	PathsFile   string
+	Concurrency int
+	// FailFast stops starting new lookups after the first error. Missing
+	// paths are not errors.
+	FailFast bool
+	Output   string
+}
+
+// fsStatResult is one row of `browsers fs stat`.
+type fsStatResult struct {
+	Path      string `json:"path"`
+	Exists    bool   `json:"exists"`
+	IsDir     bool   `json:"is_dir,omitempty"`
+	SizeBytes int64  `json:"size_bytes,omitempty"`
+	Mode      string `json:"mode,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Skipped   bool   `json:"skipped,omitempty"`
+}
+
 type BrowsersFSListFilesInput struct {
 	Identifier string
 	Path       string
+	// Recursive lists subdirectories too, down to MaxDepth levels below Path.
+	Recursive bool
+	MaxDepth  int
 }
 
+const defaultListMaxDepth = 10
+
 type BrowsersFSMoveInput struct {
 	Identifier string
 	SrcPath    string
 	DestPath   string
+	// NoOverwrite refuses to move onto an existing destination unless Force
+	// is also set.
+	NoOverwrite bool
+	Force       bool
 }
 
 type BrowsersFSReadFileInput struct {
 	Identifier string
 	Path       string
 	Output     string
+	Retry      RetryOptions
+}
+
+type BrowsersFSChecksumInput struct {
+	Identifier string
+	Path       string
+	Algo       string
+}
+
+// newChecksumHash returns the hash for a --algo value.
+func newChecksumHash(algo string) (hash.Hash, error) {
+	switch strings.ToLower(algo) {
+	case "md5":
+		return md5.New(), nil
+	case "sha1":
+		return sha1.New(), nil
+	case "", "sha256":
+		return sha256.New(), nil
+	}
+	return nil, fmt.Errorf("unsupported --algo %q: use md5, sha1 or sha256", algo)
+}
+
+type BrowsersFSGrepInput struct {
+	Identifier  string
+	Path        string
+	Pattern     string
+	Recursive   bool
+	MaxFileSize int64
 }
 
 type BrowsersFSSetPermsInput struct {
@@ -1283,19 +3299,58 @@ type BrowsersFSUploadInput struct {
 	}
 	DestDir string
 	Paths   []string
+	// Manifest is a JSON or CSV file of additional local/dest pairs.
+	Manifest string
+	// ContentType is sent for every file; when empty it is sniffed per file.
+	ContentType string
+	// Verify re-reads each uploaded file and compares its sha256 with the
+	// local copy.
+	Verify bool
+	// Recursive is a local directory whose whole tree is uploaded under
+	// DestDir, keeping relative paths.
+	Recursive      string
+	FollowSymlinks bool
+	// AllowEmptyGlob lets a Paths pattern match no files instead of failing.
+	AllowEmptyGlob bool
+	// NoProgress replaces the progress bar with a single "Uploading…" line.
+	NoProgress bool
+}
+
+// uploadManifestEntry is one entry of a `browsers fs upload --manifest` file.
+type uploadManifestEntry struct {
+	Local string `json:"local"`
+	Dest  string `json:"dest"`
 }
 
 type BrowsersFSUploadZipInput struct {
 	Identifier string
 	ZipPath    string
 	DestDir    string
+	NoProgress bool
 }
 
 type BrowsersFSWriteFileInput struct {
 	Identifier string
 	DestPath   string
 	Mode       string
+	// SourcePath is a local file to upload, or "-" for stdin.
 	SourcePath string
+	// Content is written as-is when HasContent is set, so an empty string
+	// can truncate the remote file.
+	Content    string
+	HasContent bool
+}
+
+// BrowsersCpInput copies between the local machine and a browser. Exactly one
+// of Source and Dest is remote, written as <id>:<absolute path>.
+type BrowsersCpInput struct {
+	Source string
+	Dest   string
+}
+
+type BrowsersFSEditInput struct {
+	Identifier string
+	Path       string
 }
 
 type BrowsersExtensionsUploadInput struct {
@@ -1364,27 +3419,62 @@ func (b BrowsersCmd) FSDownloadDirZip(ctx context.Context, in BrowsersFSDownload
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
+	if in.StripComponents > 0 && in.ExtractDir == "" {
+		pterm.Error.Println("--strip-components requires --extract")
+		return nil
+	}
+	if in.ExtractDir != "" {
+		if err := prepareExtractDir(in.ExtractDir); err != nil {
+			pterm.Error.Println(err.Error())
+			return nil
+		}
+	}
 	res, err := b.fs.DownloadDirZip(ctx, br.SessionID, kernel.BrowserFDownloadDirZipParams{Path: in.Path})
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
 	defer res.Body.Close()
-	if in.Output == "" {
+	if in.Output == "" && in.ExtractDir == "" {
 		_, _ = io.Copy(io.Discard, res.Body)
-		pterm.Info.Println("Downloaded zip (discarded; specify --output to save)")
+		pterm.Info.Println("Downloaded zip (discarded; specify --output to save or --extract to unpack)")
 		return nil
 	}
-	f, err := os.Create(in.Output)
-	if err != nil {
-		pterm.Error.Printf("Failed to create file: %v\n", err)
-		return nil
+
+	zipPath := in.Output
+	var f *os.File
+	if zipPath != "" {
+		f, err = os.Create(zipPath)
+		if err != nil {
+			pterm.Error.Printf("Failed to create file: %v\n", err)
+			return nil
+		}
+	} else {
+		f, err = os.CreateTemp("", "kernel-dir-*.zip")
+		if err != nil {
+			pterm.Error.Printf("Failed to create temp zip: %v\n", err)
+			return nil
+		}
+		zipPath = f.Name()
+		defer func() { _ = os.Remove(zipPath) }()
 	}
-	defer f.Close()
-	if _, err := io.Copy(f, res.Body); err != nil {
+	_, err = io.Copy(f, res.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
 		pterm.Error.Printf("Failed to write file: %v\n", err)
 		return nil
 	}
-	pterm.Success.Printf("Saved zip to %s\n", in.Output)
+	if in.Output != "" {
+		pterm.Success.Printf("Saved zip to %s\n", in.Output)
+	}
+	if in.ExtractDir != "" {
+		if err := util.UnzipStrip(zipPath, in.ExtractDir, in.StripComponents); err != nil {
+			pterm.Error.Printf("Failed to extract zip: %v\n", err)
+			return nil
+		}
+		pterm.Success.Printf("Extracted %s to %s\n", in.Path, in.ExtractDir)
+	}
 	return nil
 }
 
@@ -1401,11 +3491,156 @@ func (b BrowsersCmd) FSFileInfo(ctx context.Context, in BrowsersFSFileInfoInput)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-	rows := pterm.TableData{{"Property", "Value"}, {"Path", res.Path}, {"Name", res.Name}, {"Mode", res.Mode}, {"IsDir", fmt.Sprintf("%t", res.IsDir)}, {"SizeBytes", fmt.Sprintf("%d", res.SizeBytes)}, {"ModTime", util.FormatLocal(res.ModTime)}}
+	rows := pterm.TableData{{"Property", "Value"}, {"Path", res.Path}, {"Name", res.Name}, {"Mode", res.Mode}, {"IsDir", fmt.Sprintf("%t", res.IsDir)}, {"Size", util.HumanBytes(res.SizeBytes)}, {"ModTime", util.FormatLocal(res.ModTime)}}
 	PrintTableNoPad(rows, true)
 	return nil
 }
 
+// readPathsFile returns the non-empty, non-comment lines of a paths file.
+func readPathsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read paths file: %w", err)
+	}
+	var paths []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	return paths, nil
+}
+
+// readUploadManifest parses a JSON array of {"local","dest"} objects, or a
+// CSV of local,dest rows (with an optional header) when the file ends in
+// .csv. Relative local paths are resolved against the manifest's directory.
+func readUploadManifest(path string) ([]uploadManifestEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var entries []uploadManifestEntry
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		r := csv.NewReader(bytes.NewReader(data))
+		r.FieldsPerRecord = 2
+		r.TrimLeadingSpace = true
+		r.Comment = '#'
+		rows, err := r.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+		}
+		for i, row := range rows {
+			if i == 0 && row[0] == "local" && row[1] == "dest" {
+				continue
+			}
+			entries = append(entries, uploadManifestEntry{Local: row[0], Dest: row[1]})
+		}
+	} else if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	for i, e := range entries {
+		if e.Local == "" || e.Dest == "" {
+			return nil, fmt.Errorf("invalid manifest %s: entry %d needs both local and dest", path, i+1)
+		}
+		if !filepath.IsAbs(e.Local) {
+			entries[i].Local = filepath.Join(filepath.Dir(path), e.Local)
+		}
+	}
+	return entries, nil
+}
+
+// FSStat looks up file info for many paths at once. Missing paths are
+// reported in the results instead of aborting the batch.
+func (b BrowsersCmd) FSStat(ctx context.Context, in BrowsersFSStatInput) error {
+	if in.Output != "" && in.Output != "json" {
+		pterm.Error.Println("unsupported --output value: use 'json'")
+		return nil
+	}
+	if b.fs == nil {
+		pterm.Error.Println("fs service not available")
+		return nil
+	}
+	paths := append([]string{}, in.Paths...)
+	if in.PathsFile != "" {
+		fromFile, err := readPathsFile(in.PathsFile)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, fromFile...)
+	}
+	if len(paths) == 0 {
+		pterm.Error.Println("no paths given: use --path or --paths-file")
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+
+	results := make([]fsStatResult, len(paths))
+	for i, p := range paths {
+		results[i] = fsStatResult{Path: p, Skipped: true}
+	}
+	forEachConcurrentUntil(len(paths), in.Concurrency, in.FailFast, func(i int) error {
+		results[i] = fsStatResult{Path: paths[i]}
+		info, err := b.fs.FileInfo(ctx, br.SessionID, kernel.BrowserFFileInfoParams{Path: paths[i]})
+		switch {
+		case err == nil:
+			results[i].Exists = true
+			results[i].IsDir = info.IsDir
+			results[i].SizeBytes = info.SizeBytes
+			results[i].Mode = info.Mode
+		case util.IsNotFound(err):
+		default:
+			results[i].Error = util.CleanedUpSdkError{Err: err}.Error()
+			return err
+		}
+		return nil
+	})
+	failed, skipped := 0, 0
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			failed++
+		case r.Skipped:
+			skipped++
+		}
+	}
+	var summaryErr error
+	switch {
+	case skipped > 0:
+		summaryErr = fmt.Errorf("%d of %d lookups failed (stopped early, %d not attempted)", failed, len(paths), skipped)
+	case failed > 0:
+		summaryErr = fmt.Errorf("%d of %d lookups failed", failed, len(paths))
+	}
+
+	if in.Output == "json" {
+		bs, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bs))
+		return summaryErr
+	}
+	rows := pterm.TableData{{"Path", "Exists", "Size", "Mode"}}
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			rows = append(rows, []string{r.Path, "error: " + r.Error, "-", "-"})
+		case r.Skipped:
+			rows = append(rows, []string{r.Path, "skipped", "-", "-"})
+		case !r.Exists:
+			rows = append(rows, []string{r.Path, "false", "-", "-"})
+		default:
+			rows = append(rows, []string{r.Path, "true", util.HumanBytes(r.SizeBytes), r.Mode})
+		}
+	}
+	PrintTableNoPad(rows, true)
+	return summaryErr
+}
+
 func (b BrowsersCmd) FSListFiles(ctx context.Context, in BrowsersFSListFilesInput) error {
 	if b.fs == nil {
 		pterm.Error.Println("fs service not available")
@@ -1423,11 +3658,59 @@ func (b BrowsersCmd) FSListFiles(ctx context.Context, in BrowsersFSListFilesInpu
 		pterm.Info.Println("No files found")
 		return nil
 	}
+	if in.Recursive {
+		return b.listFilesRecursive(ctx, br.SessionID, *res, in.MaxDepth)
+	}
 	rows := pterm.TableData{{"Mode", "Size", "ModTime", "Name", "Path"}}
 	for _, f := range *res {
-		rows = append(rows, []string{f.Mode, fmt.Sprintf("%d", f.SizeBytes), util.FormatLocal(f.ModTime), f.Name, f.Path})
+		rows = append(rows, []string{f.Mode, util.HumanBytes(f.SizeBytes), util.FormatLocal(f.ModTime), f.Name, f.Path})
+	}
+	PrintTableNoPad(rows, true)
+	return nil
+}
+
+// listFilesRecursive prints top and everything below it, listing each
+// subdirectory in turn. Directories are shown with a trailing slash. Symlinks
+// are not followed and nothing deeper than maxDepth is listed, which bounds
+// the walk even if the tree loops back on itself.
+func (b BrowsersCmd) listFilesRecursive(ctx context.Context, sessionID string, top []kernel.BrowserFListFilesResponse, maxDepth int) error {
+	if maxDepth <= 0 {
+		maxDepth = defaultListMaxDepth
+	}
+	rows := pterm.TableData{{"Mode", "Size", "ModTime", "Path"}}
+	seen := map[string]bool{}
+	truncated := false
+	var walk func(entries []kernel.BrowserFListFilesResponse, depth int)
+	walk = func(entries []kernel.BrowserFListFilesResponse, depth int) {
+		for _, f := range entries {
+			if !f.IsDir {
+				rows = append(rows, []string{f.Mode, util.HumanBytes(f.SizeBytes), util.FormatLocal(f.ModTime), f.Path})
+				continue
+			}
+			rows = append(rows, []string{f.Mode, "-", util.FormatLocal(f.ModTime), strings.TrimSuffix(f.Path, "/") + "/"})
+			if strings.HasPrefix(f.Mode, "L") || seen[f.Path] {
+				continue
+			}
+			seen[f.Path] = true
+			if depth >= maxDepth {
+				truncated = true
+				continue
+			}
+			children, err := b.fs.ListFiles(ctx, sessionID, kernel.BrowserFListFilesParams{Path: f.Path})
+			if err != nil {
+				pterm.Warning.Printf("Failed to list %s: %v\n", f.Path, util.CleanedUpSdkError{Err: err})
+				continue
+			}
+			if children != nil {
+				walk(*children, depth+1)
+			}
+		}
 	}
+	walk(top, 1)
 	PrintTableNoPad(rows, true)
+	if truncated {
+		pterm.Warning.Printf("Stopped at --max-depth %d; deeper directories were not listed\n", maxDepth)
+	}
 	return nil
 }
 
@@ -1440,6 +3723,15 @@ func (b BrowsersCmd) FSMove(ctx context.Context, in BrowsersFSMoveInput) error {
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
+	if in.NoOverwrite && !in.Force {
+		_, err := b.fs.FileInfo(ctx, br.SessionID, kernel.BrowserFFileInfoParams{Path: in.DestPath})
+		if err == nil {
+			return fmt.Errorf("destination %s already exists; use --force to overwrite it", in.DestPath)
+		}
+		if !util.IsNotFound(err) {
+			return util.CleanedUpSdkError{Err: err}
+		}
+	}
 	if err := b.fs.Move(ctx, br.SessionID, kernel.BrowserFMoveParams{SrcPath: in.SrcPath, DestPath: in.DestPath}); err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
@@ -1452,11 +3744,19 @@ func (b BrowsersCmd) FSReadFile(ctx context.Context, in BrowsersFSReadFileInput)
 		pterm.Error.Println("fs service not available")
 		return nil
 	}
-	br, err := b.browsers.Get(ctx, in.Identifier)
+	var br *kernel.BrowserGetResponse
+	err := withRetry(ctx, in.Retry, func() (err error) {
+		br, err = b.browsers.Get(ctx, in.Identifier)
+		return err
+	})
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-	res, err := b.fs.ReadFile(ctx, br.SessionID, kernel.BrowserFReadFileParams{Path: in.Path})
+	var res *http.Response
+	err = withRetry(ctx, in.Retry, func() (err error) {
+		res, err = b.fs.ReadFile(ctx, br.SessionID, kernel.BrowserFReadFileParams{Path: in.Path})
+		return err
+	})
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
@@ -1475,89 +3775,514 @@ func (b BrowsersCmd) FSReadFile(ctx context.Context, in BrowsersFSReadFileInput)
 		pterm.Error.Printf("Failed to write file: %v\n", err)
 		return nil
 	}
-	pterm.Success.Printf("Saved file to %s\n", in.Output)
+	pterm.Success.Printf("Saved file to %s\n", in.Output)
+	return nil
+}
+
+// FSChecksum prints "hash  path" for a remote file, in the format of
+// sha256sum. The API does not expose hashes, so the file is streamed and
+// hashed locally.
+func (b BrowsersCmd) FSChecksum(ctx context.Context, in BrowsersFSChecksumInput) error {
+	if b.fs == nil {
+		pterm.Error.Println("fs service not available")
+		return nil
+	}
+	h, err := newChecksumHash(in.Algo)
+	if err != nil {
+		pterm.Error.Println(err.Error())
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	sum, err := b.remoteDigest(ctx, br.SessionID, in.Path, h)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s  %s\n", sum, in.Path)
+	return nil
+}
+
+// remoteDigest streams a remote file through h and returns the hex digest.
+func (b BrowsersCmd) remoteDigest(ctx context.Context, sessionID, remotePath string, h hash.Hash) (string, error) {
+	res, err := b.fs.ReadFile(ctx, sessionID, kernel.BrowserFReadFileParams{Path: remotePath})
+	if err != nil {
+		return "", util.CleanedUpSdkError{Err: err}
+	}
+	defer res.Body.Close()
+	if _, err := io.Copy(h, res.Body); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", remotePath, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// localDigest returns the hex sha256 of a local file.
+func localDigest(localPath string) (string, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FSGrep prints lines matching in.Pattern as "path:line:text" for each file
+// under in.Path. Files larger than in.MaxFileSize and binary files are skipped.
+func (b BrowsersCmd) FSGrep(ctx context.Context, in BrowsersFSGrepInput) error {
+	if b.fs == nil {
+		pterm.Error.Println("fs service not available")
+		return nil
+	}
+	re, err := regexp.Compile(in.Pattern)
+	if err != nil {
+		pterm.Error.Printf("Invalid pattern: %v\n", err)
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+
+	matches := 0
+	dirs := []string{in.Path}
+	for len(dirs) > 0 {
+		dir := dirs[0]
+		dirs = dirs[1:]
+		res, err := b.fs.ListFiles(ctx, br.SessionID, kernel.BrowserFListFilesParams{Path: dir})
+		if err != nil {
+			return util.CleanedUpSdkError{Err: err}
+		}
+		if res == nil {
+			continue
+		}
+		for _, f := range *res {
+			if f.IsDir {
+				if in.Recursive {
+					dirs = append(dirs, f.Path)
+				}
+				continue
+			}
+			if in.MaxFileSize > 0 && f.SizeBytes > in.MaxFileSize {
+				if logger != nil {
+					logger.Debug("skipping large file", logger.Args("path", f.Path, "size", f.SizeBytes))
+				}
+				continue
+			}
+			n, err := b.grepFile(ctx, br.SessionID, f.Path, re)
+			if err != nil {
+				return err
+			}
+			matches += n
+		}
+	}
+	if matches == 0 {
+		pterm.Info.Println("No matches found")
+	}
+	return nil
+}
+
+// grepFile reads a single remote file and prints its matching lines.
+func (b BrowsersCmd) grepFile(ctx context.Context, sessionID, path string, re *regexp.Regexp) (int, error) {
+	res, err := b.fs.ReadFile(ctx, sessionID, kernel.BrowserFReadFileParams{Path: path})
+	if err != nil {
+		return 0, util.CleanedUpSdkError{Err: err}
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return 0, nil
+	}
+	matches := 0
+	for i, line := range strings.Split(string(data), "\n") {
+		if re.MatchString(line) {
+			pterm.Printfln("%s:%d:%s", path, i+1, strings.TrimSuffix(line, "\r"))
+			matches++
+		}
+	}
+	return matches, nil
+}
+
+func (b BrowsersCmd) FSSetPermissions(ctx context.Context, in BrowsersFSSetPermsInput) error {
+	if b.fs == nil {
+		pterm.Error.Println("fs service not available")
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	params := kernel.BrowserFSetFilePermissionsParams{Path: in.Path, Mode: in.Mode}
+	if in.Owner != "" {
+		params.Owner = kernel.Opt(in.Owner)
+	}
+	if in.Group != "" {
+		params.Group = kernel.Opt(in.Group)
+	}
+	if err := b.fs.SetFilePermissions(ctx, br.SessionID, params); err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	pterm.Success.Printf("Updated permissions for %s\n", in.Path)
+	return nil
+}
+
+func (b BrowsersCmd) FSUpload(ctx context.Context, in BrowsersFSUploadInput) error {
+	if b.fs == nil {
+		pterm.Error.Println("fs service not available")
+		return nil
+	}
+	mappings := in.Mappings
+	if in.Manifest != "" {
+		entries, err := readUploadManifest(in.Manifest)
+		if err != nil {
+			pterm.Error.Println(err.Error())
+			return nil
+		}
+		for _, e := range entries {
+			mappings = append(mappings, struct {
+				Local string
+				Dest  string
+			}{Local: e.Local, Dest: e.Dest})
+		}
+	}
+	// Check every local path up front so a typo doesn't surface halfway
+	// through a large upload set.
+	var missing []string
+	for _, m := range mappings {
+		if _, err := os.Stat(m.Local); err != nil {
+			missing = append(missing, m.Local)
+		}
+	}
+	if len(missing) > 0 {
+		pterm.Error.Printf("Local files not found: %s\n", strings.Join(missing, ", "))
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	paths, err := expandUploadPaths(in.Paths, in.AllowEmptyGlob)
+	if err != nil {
+		pterm.Error.Println(err.Error())
+		return nil
+	}
+	var uploaded [][2]string
+	for _, m := range mappings {
+		uploaded = append(uploaded, [2]string{m.Local, m.Dest})
+	}
+	if in.DestDir != "" && len(paths) > 0 {
+		for _, lp := range paths {
+			uploaded = append(uploaded, [2]string{lp, filepath.Join(in.DestDir, filepath.Base(lp))})
+		}
+	}
+	if in.Recursive != "" {
+		tree, err := collectUploadTree(in.Recursive, in.DestDir, in.FollowSymlinks)
+		if err != nil {
+			pterm.Error.Printf("Failed to walk %s: %v\n", in.Recursive, err)
+			return nil
+		}
+		uploaded = append(uploaded, tree...)
+	}
+	if len(uploaded) == 0 {
+		pterm.Error.Println("no files specified for upload")
+		return nil
+	}
+	// Files are opened lazily as the request body is written, so only one
+	// is open at a time no matter how many are queued.
+	contentTypes := make([]string, len(uploaded))
+	var total int64
+	for i, u := range uploaded {
+		st, err := os.Stat(u[0])
+		if err != nil {
+			pterm.Error.Printf("Failed to read %s: %v\n", u[0], err)
+			return nil
+		}
+		total += st.Size()
+		if contentTypes[i], err = sniffFileContentType(u[0], in.ContentType); err != nil {
+			pterm.Error.Printf("Failed to read %s: %v\n", u[0], err)
+			return nil
+		}
+	}
+	if in.Recursive != "" {
+		pterm.Info.Printf("Queued %d files for upload\n", len(uploaded))
+	}
+	progress := startUploadProgress(total, !in.NoProgress)
+	defer progress.stop()
+	files := make([]kernel.BrowserFUploadParamsFile, 0, len(uploaded))
+	for i, u := range uploaded {
+		lf := &lazyFile{path: u[0]}
+		defer lf.Close()
+		files = append(files, kernel.BrowserFUploadParamsFile{DestPath: u[1], File: kernel.File(progress.wrap(lf), filepath.Base(u[0]), contentTypes[i])})
+	}
+	if err := b.fs.Upload(ctx, br.SessionID, kernel.BrowserFUploadParams{Files: files}); err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	if len(files) == 1 {
+		pterm.Success.Println("Uploaded 1 file")
+	} else {
+		pterm.Success.Printf("Uploaded %d files\n", len(files))
+	}
+	if in.Verify {
+		return b.verifyUploads(ctx, br.SessionID, uploaded)
+	}
+	return nil
+}
+
+// expandUploadPaths expands glob patterns in --paths and drops duplicates,
+// keeping first-seen order. Arguments without glob characters pass through
+// untouched so a missing file still reports as such.
+func expandUploadPaths(args []string, allowEmpty bool) ([]string, error) {
+	var out []string
+	seen := map[string]bool{}
+	add := func(p string) {
+		if key := filepath.Clean(p); !seen[key] {
+			seen[key] = true
+			out = append(out, p)
+		}
+	}
+	for _, arg := range args {
+		if !strings.ContainsAny(arg, "*?[") {
+			add(arg)
+			continue
+		}
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", arg, err)
+		}
+		if len(matches) == 0 && !allowEmpty {
+			return nil, fmt.Errorf("pattern %q matched no files (use --allow-empty-glob to ignore)", arg)
+		}
+		for _, m := range matches {
+			if st, err := os.Stat(m); err == nil && st.IsDir() {
+				continue
+			}
+			add(m)
+		}
+	}
+	return out, nil
+}
+
+// collectUploadTree lists every regular file under root as a local/dest
+// pair rooted at destDir. Symlinks are skipped unless follow is set, in which
+// case linked directories are walked too (each real directory only once).
+func collectUploadTree(root, destDir string, follow bool) ([][2]string, error) {
+	var pairs [][2]string
+	seen := map[string]bool{}
+	var walk func(dir, rel string) error
+	walk = func(dir, rel string) error {
+		if real, err := filepath.EvalSymlinks(dir); err == nil {
+			if seen[real] {
+				return nil
+			}
+			seen[real] = true
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			local := filepath.Join(dir, e.Name())
+			childRel := path.Join(rel, e.Name())
+			mode := e.Type()
+			if mode&os.ModeSymlink != 0 {
+				if !follow {
+					continue
+				}
+				st, err := os.Stat(local)
+				if err != nil {
+					return err
+				}
+				mode = st.Mode().Type()
+			}
+			switch {
+			case mode.IsDir():
+				if err := walk(local, childRel); err != nil {
+					return err
+				}
+			case mode.IsRegular():
+				pairs = append(pairs, [2]string{local, path.Join(destDir, childRel)})
+			}
+		}
+		return nil
+	}
+	if err := walk(root, ""); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// lazyFile opens path on the first Read and closes it at EOF, so a large
+// multipart upload never holds more than one descriptor at a time.
+type lazyFile struct {
+	path string
+	f    *os.File
+	done bool
+}
+
+func (l *lazyFile) Read(p []byte) (int, error) {
+	if l.done {
+		return 0, io.EOF
+	}
+	if l.f == nil {
+		f, err := os.Open(l.path)
+		if err != nil {
+			return 0, err
+		}
+		l.f = f
+	}
+	n, err := l.f.Read(p)
+	if err != nil {
+		_ = l.Close()
+	}
+	return n, err
+}
+
+// Close releases the file if a failed upload stopped reading it early.
+func (l *lazyFile) Close() error {
+	l.done = true
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+// sniffFileContentType is sniffContentType for a file on disk.
+func sniffFileContentType(local, contentType string) (string, error) {
+	if contentType != "" {
+		return contentType, nil
+	}
+	f, err := os.Open(local)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	contentType, _, err = sniffContentType(f, "")
+	return contentType, err
+}
+
+// verifyUploads compares the sha256 of each uploaded local file with the
+// file read back from the browser.
+func (b BrowsersCmd) verifyUploads(ctx context.Context, sessionID string, pairs [][2]string) error {
+	failed := 0
+	for _, p := range pairs {
+		local, dest := p[0], p[1]
+		want, err := localDigest(local)
+		if err != nil {
+			return fmt.Errorf("failed to hash %s: %w", local, err)
+		}
+		got, err := b.remoteDigest(ctx, sessionID, dest, sha256.New())
+		if err != nil {
+			pterm.Error.Printf("Could not verify %s: %v\n", dest, err)
+			failed++
+			continue
+		}
+		if got != want {
+			pterm.Error.Printf("Checksum mismatch for %s: local %s, remote %s\n", dest, want, got)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d uploaded files failed verification", failed, len(pairs))
+	}
+	if len(pairs) == 1 {
+		pterm.Success.Println("Verified 1 file")
+	} else {
+		pterm.Success.Printf("Verified %d files\n", len(pairs))
+	}
 	return nil
 }
 
-func (b BrowsersCmd) FSSetPermissions(ctx context.Context, in BrowsersFSSetPermsInput) error {
-	if b.fs == nil {
-		pterm.Error.Println("fs service not available")
-		return nil
+// parseCpPath splits an scp-style "<id>:<path>" argument. Anything else,
+// including Windows drive paths like C:\dir, is a local path.
+func parseCpPath(arg string) (id, path string, remote bool) {
+	before, after, ok := strings.Cut(arg, ":")
+	if !ok || len(before) < 2 || strings.ContainsAny(before, `/\`) || !strings.HasPrefix(after, "/") {
+		return "", arg, false
 	}
-	br, err := b.browsers.Get(ctx, in.Identifier)
-	if err != nil {
-		return util.CleanedUpSdkError{Err: err}
+	return before, after, true
+}
+
+// Cp copies a file or directory to or from a browser, picking the file or
+// zip endpoints depending on whether the source is a directory.
+func (b BrowsersCmd) Cp(ctx context.Context, in BrowsersCpInput) error {
+	srcID, srcPath, srcRemote := parseCpPath(in.Source)
+	dstID, dstPath, dstRemote := parseCpPath(in.Dest)
+	switch {
+	case srcRemote == dstRemote:
+		pterm.Error.Println("exactly one of source and destination must be <id>:<path>")
+		return nil
 	}
-	params := kernel.BrowserFSetFilePermissionsParams{Path: in.Path, Mode: in.Mode}
-	if in.Owner != "" {
-		params.Owner = kernel.Opt(in.Owner)
+	id := srcID
+	if dstRemote {
+		id = dstID
 	}
-	if in.Group != "" {
-		params.Group = kernel.Opt(in.Group)
+	id, err := resolveSessionRef(id)
+	if err != nil {
+		return err
 	}
-	if err := b.fs.SetFilePermissions(ctx, br.SessionID, params); err != nil {
-		return util.CleanedUpSdkError{Err: err}
+	if srcRemote {
+		return b.cpDownload(ctx, id, srcPath, dstPath)
 	}
-	pterm.Success.Printf("Updated permissions for %s\n", in.Path)
-	return nil
+	return b.cpUpload(ctx, id, srcPath, dstPath)
 }
 
-func (b BrowsersCmd) FSUpload(ctx context.Context, in BrowsersFSUploadInput) error {
+func (b BrowsersCmd) cpDownload(ctx context.Context, id, remote, local string) error {
 	if b.fs == nil {
 		pterm.Error.Println("fs service not available")
 		return nil
 	}
-	br, err := b.browsers.Get(ctx, in.Identifier)
+	br, err := b.browsers.Get(ctx, id)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-	var files []kernel.BrowserFUploadParamsFile
-	var toClose []io.Closer
-	for _, m := range in.Mappings {
-		f, err := os.Open(m.Local)
-		if err != nil {
-			pterm.Error.Printf("Failed to open %s: %v\n", m.Local, err)
-			for _, c := range toClose {
-				_ = c.Close()
-			}
-			return nil
-		}
-		toClose = append(toClose, f)
-		files = append(files, kernel.BrowserFUploadParamsFile{DestPath: m.Dest, File: f})
+	info, err := b.fs.FileInfo(ctx, br.SessionID, kernel.BrowserFFileInfoParams{Path: remote})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
 	}
-	if in.DestDir != "" && len(in.Paths) > 0 {
-		for _, lp := range in.Paths {
-			f, err := os.Open(lp)
-			if err != nil {
-				pterm.Error.Printf("Failed to open %s: %v\n", lp, err)
-				for _, c := range toClose {
-					_ = c.Close()
-				}
-				return nil
-			}
-			toClose = append(toClose, f)
-			dest := filepath.Join(in.DestDir, filepath.Base(lp))
-			files = append(files, kernel.BrowserFUploadParamsFile{DestPath: dest, File: f})
-		}
+	if info.IsDir {
+		return b.FSDownloadDirZip(ctx, BrowsersFSDownloadDirZipInput{Identifier: id, Path: remote, ExtractDir: local})
 	}
-	if len(files) == 0 {
-		pterm.Error.Println("no files specified for upload")
+	if st, err := os.Stat(local); err == nil && st.IsDir() {
+		local = filepath.Join(local, path.Base(remote))
+	}
+	return b.FSReadFile(ctx, BrowsersFSReadFileInput{Identifier: id, Path: remote, Output: local})
+}
+
+func (b BrowsersCmd) cpUpload(ctx context.Context, id, local, remote string) error {
+	st, err := os.Stat(local)
+	if err != nil {
+		pterm.Error.Printf("Failed to read %s: %v\n", local, err)
 		return nil
 	}
-	defer func() {
-		for _, c := range toClose {
-			_ = c.Close()
+	if !st.IsDir() {
+		if strings.HasSuffix(remote, "/") {
+			remote = path.Join(remote, filepath.Base(local))
 		}
-	}()
-	if err := b.fs.Upload(ctx, br.SessionID, kernel.BrowserFUploadParams{Files: files}); err != nil {
-		return util.CleanedUpSdkError{Err: err}
+		return b.FSWriteFile(ctx, BrowsersFSWriteFileInput{Identifier: id, DestPath: remote, SourcePath: local})
 	}
-	if len(files) == 1 {
-		pterm.Success.Println("Uploaded 1 file")
-	} else {
-		pterm.Success.Printf("Uploaded %d files\n", len(files))
+
+	tmpZip, err := os.CreateTemp("", "kernel-cp-*.zip")
+	if err != nil {
+		pterm.Error.Printf("Failed to create temp zip: %v\n", err)
+		return nil
 	}
-	return nil
+	tmpName := tmpZip.Name()
+	_ = tmpZip.Close()
+	defer func() { _ = os.Remove(tmpName) }()
+	if err := util.ZipDirectory(local, tmpName); err != nil {
+		pterm.Error.Printf("Failed to zip %s: %v\n", local, err)
+		return nil
+	}
+	return b.FSUploadZip(ctx, BrowsersFSUploadZipInput{Identifier: id, ZipPath: tmpName, DestDir: remote, NoProgress: !stderrIsTerminal()})
 }
 
 func (b BrowsersCmd) FSUploadZip(ctx context.Context, in BrowsersFSUploadZipInput) error {
@@ -1575,7 +4300,14 @@ func (b BrowsersCmd) FSUploadZip(ctx context.Context, in BrowsersFSUploadZipInpu
 		return nil
 	}
 	defer f.Close()
-	if err := b.fs.UploadZip(ctx, br.SessionID, kernel.BrowserFUploadZipParams{DestPath: in.DestDir, ZipFile: f}); err != nil {
+	st, err := f.Stat()
+	if err != nil {
+		pterm.Error.Printf("Failed to open zip: %v\n", err)
+		return nil
+	}
+	progress := startUploadProgress(st.Size(), !in.NoProgress)
+	defer progress.stop()
+	if err := b.fs.UploadZip(ctx, br.SessionID, kernel.BrowserFUploadZipParams{DestPath: in.DestDir, ZipFile: progress.wrap(f)}); err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
 	pterm.Success.Printf("Uploaded zip to %s\n", in.DestDir)
@@ -1592,7 +4324,15 @@ func (b BrowsersCmd) FSWriteFile(ctx context.Context, in BrowsersFSWriteFileInpu
 		return util.CleanedUpSdkError{Err: err}
 	}
 	var reader io.Reader
-	if in.SourcePath != "" {
+	switch {
+	case (in.SourcePath != "") == in.HasContent:
+		pterm.Error.Println("specify exactly one of --source or --content")
+		return nil
+	case in.HasContent:
+		reader = strings.NewReader(in.Content)
+	case in.SourcePath == "-":
+		reader = os.Stdin
+	default:
 		f, err := os.Open(in.SourcePath)
 		if err != nil {
 			pterm.Error.Printf("Failed to open input: %v\n", err)
@@ -1600,9 +4340,6 @@ func (b BrowsersCmd) FSWriteFile(ctx context.Context, in BrowsersFSWriteFileInpu
 		}
 		defer f.Close()
 		reader = f
-	} else {
-		pterm.Error.Println("--source is required")
-		return nil
 	}
 	params := kernel.BrowserFWriteFileParams{Path: in.DestPath}
 	if in.Mode != "" {
@@ -1615,6 +4352,119 @@ func (b BrowsersCmd) FSWriteFile(ctx context.Context, in BrowsersFSWriteFileInpu
 	return nil
 }
 
+// sniffContentType returns contentType when set and otherwise the type
+// detected from the first 512 bytes of r. The returned reader still yields
+// all of r.
+func sniffContentType(r io.Reader, contentType string) (string, io.Reader, error) {
+	if contentType != "" {
+		return contentType, r, nil
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, err
+	}
+	head = head[:n]
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), r), nil
+}
+
+// openEditor opens path in the user's editor and waits for it to exit.
+var openEditor = func(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	parts := strings.Fields(editor)
+	c := exec.Command(parts[0], append(parts[1:], path)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return c.Run()
+}
+
+// FSEdit downloads a remote file, opens it in the local editor and uploads
+// it back when its contents changed.
+func (b BrowsersCmd) FSEdit(ctx context.Context, in BrowsersFSEditInput) error {
+	if b.fs == nil {
+		pterm.Error.Println("fs service not available")
+		return nil
+	}
+	br, err := b.browsers.Get(ctx, in.Identifier)
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	res, err := b.fs.ReadFile(ctx, br.SessionID, kernel.BrowserFReadFileParams{Path: in.Path})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	original, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", in.Path, err)
+	}
+
+	tmp, err := os.CreateTemp("", "kernel-edit-*"+filepath.Ext(in.Path))
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(original)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	if err := openEditor(tmp.Name()); err != nil {
+		pterm.Warning.Printf("Editor exited with error, leaving %s unchanged: %v\n", in.Path, err)
+		return nil
+	}
+	edited, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		return fmt.Errorf("failed to read edited file: %w", err)
+	}
+	if sha256.Sum256(edited) == sha256.Sum256(original) {
+		pterm.Info.Println("No changes made")
+		return nil
+	}
+
+	// Keep the file's permissions; WriteFile would otherwise reset them to 644.
+	params := kernel.BrowserFWriteFileParams{Path: in.Path}
+	if info, err := b.fs.FileInfo(ctx, br.SessionID, kernel.BrowserFFileInfoParams{Path: in.Path}); err == nil {
+		if mode, ok := symbolicModeToOctal(info.Mode); ok {
+			params.Mode = kernel.Opt(mode)
+		}
+	}
+	if err := b.fs.WriteFile(ctx, br.SessionID, bytes.NewReader(edited), params); err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	pterm.Success.Printf("Saved changes to %s\n", in.Path)
+	return nil
+}
+
+// symbolicModeToOctal converts the permission part of an ls-style mode
+// (e.g. "-rwxr-xr-x") to an octal string such as "755".
+func symbolicModeToOctal(mode string) (string, bool) {
+	if len(mode) < 9 {
+		return "", false
+	}
+	perms := mode[len(mode)-9:]
+	var bits uint32
+	for i := 0; i < len(perms); i++ {
+		c := perms[i]
+		bits <<= 1
+		if c != '-' {
+			if c != "rwxrwxrwx"[i] {
+				return "", false
+			}
+			bits |= 1
+		}
+	}
+	return strconv.FormatUint(uint64(bits), 8), true
+}
+
 func (b BrowsersCmd) ExtensionsUpload(ctx context.Context, in BrowsersExtensionsUploadInput) error {
 	if b.browsers == nil {
 		pterm.Error.Println("browsers service not available")
@@ -1724,50 +4574,99 @@ var browsersCreateCmd = &cobra.Command{
 }
 
 var browsersDeleteCmd = &cobra.Command{
-	Use:   "delete <id> [ids...]",
-	Short: "Delete a browser",
-	Args:  cobra.MinimumNArgs(1),
-	RunE:  runBrowsersDelete,
+	Use:         "delete <id> [ids...]",
+	Annotations: map[string]string{acceptsPersistentIDAnnotation: "true"},
+	Short:       "Delete a browser",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			if len(args) > 0 {
+				return fmt.Errorf("--all cannot be combined with browser IDs")
+			}
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	RunE: runBrowsersDelete,
 }
 
 var browsersViewCmd = &cobra.Command{
-	Use:   "view <id>",
-	Short: "Get the live view URL for a browser",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runBrowsersView,
+	Use:         "view <id>",
+	Annotations: map[string]string{acceptsPersistentIDAnnotation: "true"},
+	Short:       "Get the live view URL for a browser",
+	Args:        cobra.ExactArgs(1),
+	RunE:        runBrowsersView,
 }
 
 var browsersGetCmd = &cobra.Command{
-	Use:   "get <id>",
-	Short: "Get detailed information about a browser session",
-	Long:  "Retrieve and display detailed information about a specific browser session including configuration, URLs, and status.",
+	Use:         "get <id>",
+	Annotations: map[string]string{acceptsPersistentIDAnnotation: "true"},
+	Short:       "Get detailed information about a browser session",
+	Long:        "Retrieve and display detailed information about a specific browser session including configuration, URLs, and status.",
+	Args:        cobra.ExactArgs(1),
+	RunE:        runBrowsersGet,
+}
+
+var browsersRebootCmd = &cobra.Command{
+	Use:   "reboot <id>",
+	Short: "Restart Chromium within a browser session",
+	Long:  "Restart the Chromium process inside an existing browser session (keeping the session itself) and wait until it is reachable again.",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runBrowsersGet,
+	RunE:  runBrowsersReboot,
 }
 
 func init() {
 	// list flags
-	browsersListCmd.Flags().StringP("output", "o", "", "Output format: json for raw API response")
+	browsersListCmd.Flags().StringP("output", "o", "", "Output format: json or yaml for raw API response, ndjson for one object per line, csv for the table columns")
+	browsersListCmd.Flags().Bool("all", false, "Fetch every page of results (--limit sets the page size)")
 	browsersListCmd.Flags().Bool("include-deleted", false, "Include soft-deleted browser sessions in the results")
 	browsersListCmd.Flags().Int("limit", 0, "Maximum number of results to return (default 20, max 100)")
 	browsersListCmd.Flags().Int("offset", 0, "Number of results to skip (for pagination)")
+	browsersListCmd.Flags().Bool("count", false, "Print only the number of matching browsers")
+	browsersListCmd.Flags().String("sort", "", "Sort by created, -created, session-id or profile")
+	browsersListCmd.Flags().String("profile", "", "Only show sessions using this profile (name or ID)")
+	addRetryFlags(browsersListCmd)
 
 	// get flags
-	browsersGetCmd.Flags().StringP("output", "o", "", "Output format: json for raw API response")
+	browsersGetCmd.Flags().StringP("output", "o", "", "Output format: json or yaml for raw API response")
+	browsersGetCmd.Flags().String("watch-until", "", "Poll until the browser satisfies a condition: "+strings.Join(browserConditionNames(), ", "))
+	browsersGetCmd.Flags().Duration("timeout", 60*time.Second, "How long --watch-until waits before failing")
+	addRetryFlags(browsersGetCmd)
 
 	browsersCmd.AddCommand(browsersListCmd)
 	browsersCmd.AddCommand(browsersCreateCmd)
 	browsersCmd.AddCommand(browsersDeleteCmd)
+	browsersViewCmd.Flags().Bool("open", false, "Open the live view in your default browser")
+	browsersViewCmd.Flags().StringP("output", "o", "", "Output format: json for {live_view_url, headless}")
+	browsersViewCmd.MarkFlagsMutuallyExclusive("open", "output")
 	browsersCmd.AddCommand(browsersViewCmd)
 	browsersCmd.AddCommand(browsersGetCmd)
 
+	// reboot flags
+	browsersRebootCmd.Flags().Int("timeout", 60, "Seconds to wait for Chromium to become reachable again")
+	browsersCmd.AddCommand(browsersRebootCmd)
+
+	// export
+	exportCmd := &cobra.Command{Use: "export <id>", Short: "Collect session details, a screenshot, logs, files and replays for debugging", Args: cobra.ExactArgs(1), RunE: runBrowsersExport}
+	exportCmd.Flags().String("to", "", "Directory to write the bundle to")
+	_ = exportCmd.MarkFlagRequired("to")
+	exportCmd.Flags().Bool("zip", false, "Write <to>.zip instead of leaving a directory")
+	exportCmd.Flags().String("fs-path", "/tmp", "Directory to include a file listing of")
+	exportCmd.Flags().Duration("log-timeout", defaultExportLogTimeout, "How long to spend reading recent logs")
+	browsersCmd.AddCommand(exportCmd)
+
 	// logs
 	logsRoot := &cobra.Command{Use: "logs", Short: "Browser logs operations"}
 	logsStream := &cobra.Command{Use: "stream <id>", Short: "Stream browser logs", Args: cobra.ExactArgs(1), RunE: runBrowsersLogsStream}
-	logsStream.Flags().String("source", "", "Log source: path or supervisor")
+	logsStream.Flags().StringSlice("source", nil, "Log source: path or supervisor; repeat or use all to interleave both")
 	logsStream.Flags().Bool("follow", true, "Follow the log stream")
 	logsStream.Flags().String("path", "", "File path when source=path")
 	logsStream.Flags().String("supervisor-process", "", "Supervisor process name when source=supervisor. Useful values to use: chromium, kernel-images-api, neko")
+	logsStream.Flags().String("level", "", "Only show events at or above this severity: debug, info, warn or error")
+	logsStream.Flags().Bool("json", false, "Print one JSON object per event instead of the human-readable format")
+	logsStream.Flags().String("output-file", "", "Also write each log line to this file")
+	logsStream.Flags().Int64("rotate-size", 0, "Rotate --output-file to <file>.1, <file>.2, ... once it reaches this many bytes")
+	logsStream.Flags().Int("rotate-keep", defaultRotateKeep, "Number of rotated files to keep with --rotate-size")
+	logsStream.Flags().String("order-by", logOrderArrival, "Event ordering: arrival, or timestamp to reorder events within a short window")
 	_ = logsStream.MarkFlagRequired("source")
 	logsRoot.AddCommand(logsStream)
 	browsersCmd.AddCommand(logsRoot)
@@ -1775,10 +4674,15 @@ func init() {
 	// replays
 	replaysRoot := &cobra.Command{Use: "replays", Short: "Manage browser replays"}
 	replaysList := &cobra.Command{Use: "list <id>", Short: "List replays for a browser", Args: cobra.ExactArgs(1), RunE: runBrowsersReplaysList}
+	replaysList.Flags().Bool("active", false, "Only show replays that are still recording")
+	replaysList.Flags().Bool("finished", false, "Only show replays that have finished")
+	replaysList.MarkFlagsMutuallyExclusive("active", "finished")
+	replaysList.Flags().StringP("output", "o", "", "Output format: json for raw API response")
 	replaysStart := &cobra.Command{Use: "start <id>", Short: "Start a replay recording", Args: cobra.ExactArgs(1), RunE: runBrowsersReplaysStart}
 	replaysStart.Flags().Int("framerate", 0, "Recording framerate (fps)")
 	replaysStart.Flags().Int("max-duration", 0, "Maximum duration in seconds")
-	replaysStop := &cobra.Command{Use: "stop <id> <replay-id>", Short: "Stop a replay recording", Args: cobra.ExactArgs(2), RunE: runBrowsersReplaysStop}
+	replaysStop := &cobra.Command{Use: "stop <id> [replay-id]", Short: "Stop a replay recording", Args: cobra.RangeArgs(1, 2), RunE: runBrowsersReplaysStop}
+	replaysStop.Flags().Bool("all", false, "Stop every replay that is still recording")
 	replaysDownload := &cobra.Command{Use: "download <id> <replay-id>", Short: "Download a replay video", Args: cobra.ExactArgs(2), RunE: runBrowsersReplaysDownload}
 	replaysDownload.Flags().StringP("output", "o", "", "Output file path for the replay video")
 	replaysRoot.AddCommand(replaysList, replaysStart, replaysStop, replaysDownload)
@@ -1786,13 +4690,14 @@ func init() {
 
 	// process
 	procRoot := &cobra.Command{Use: "process", Short: "Manage processes inside the browser VM"}
-	procExec := &cobra.Command{Use: "exec <id> [--] [command...]", Short: "Execute a command synchronously", Args: cobra.MinimumNArgs(1), RunE: runBrowsersProcessExec}
+	procExec := &cobra.Command{Use: "exec <id> [--] [command...]", Short: "Execute a command synchronously", Long: "Execute a command synchronously and print its output.\n\nexec cannot feed the command any input; use spawn with --stdin-file to pipe local data to a process.", Args: cobra.MinimumNArgs(1), RunE: runBrowsersProcessExec}
 	procExec.Flags().String("command", "", "Command to execute (optional; if omitted, trailing args are executed via /bin/bash -c)")
 	procExec.Flags().StringSlice("args", []string{}, "Command arguments")
 	procExec.Flags().String("cwd", "", "Working directory")
 	procExec.Flags().Int("timeout", 0, "Timeout in seconds")
 	procExec.Flags().String("as-user", "", "Run as user")
 	procExec.Flags().Bool("as-root", false, "Run as root")
+	procExec.Flags().String("output-file", "", "Also save the command's decoded stdout and stderr to this file")
 	procSpawn := &cobra.Command{Use: "spawn <id> [--] [command...]", Short: "Execute a command asynchronously", Args: cobra.MinimumNArgs(1), RunE: runBrowsersProcessSpawn}
 	procSpawn.Flags().String("command", "", "Command to execute (optional; if omitted, trailing args are executed via /bin/bash -c)")
 	procSpawn.Flags().StringSlice("args", []string{}, "Command arguments")
@@ -1800,14 +4705,30 @@ func init() {
 	procSpawn.Flags().Int("timeout", 0, "Timeout in seconds")
 	procSpawn.Flags().String("as-user", "", "Run as user")
 	procSpawn.Flags().Bool("as-root", false, "Run as root")
+	procSpawn.Flags().String("stdin-file", "", "File whose contents are written to the process's stdin after it starts (- for this terminal's stdin)")
+	procSpawn.Flags().String("output-file", "", "Follow the process until it exits and save its stdout and stderr to this file")
+	procSpawn.Flags().Bool("attach", false, "Pipe this terminal's stdin and stdout to the process until it exits, printing nothing else")
+	procSpawn.MarkFlagsMutuallyExclusive("attach", "stdin-file")
+	procSpawn.MarkFlagsMutuallyExclusive("attach", "output-file")
 	procKill := &cobra.Command{Use: "kill <id> <process-id>", Short: "Send a signal to a process", Args: cobra.ExactArgs(2), RunE: runBrowsersProcessKill}
 	procKill.Flags().String("signal", "TERM", "Signal to send (TERM, KILL, INT, HUP)")
 	procStatus := &cobra.Command{Use: "status <id> <process-id>", Short: "Get process status", Args: cobra.ExactArgs(2), RunE: runBrowsersProcessStatus}
-	procStdin := &cobra.Command{Use: "stdin <id> <process-id>", Short: "Write to process stdin (base64)", Args: cobra.ExactArgs(2), RunE: runBrowsersProcessStdin}
+	procStdin := &cobra.Command{Use: "stdin <id> <process-id>", Short: "Write to process stdin", Args: cobra.ExactArgs(2), RunE: runBrowsersProcessStdin}
+	procStdin.Flags().String("data", "", "Text to write to stdin")
+	procStdin.Flags().String("data-file", "", "File whose raw contents are written to stdin")
+	procStdin.Flags().String("file", "", "Alias for --data-file")
 	procStdin.Flags().String("data-b64", "", "Base64-encoded data to write to stdin")
-	_ = procStdin.MarkFlagRequired("data-b64")
+	procStdin.Flags().Bool("newline", false, "Append a newline to --data or --data-file input (by default input is sent exactly as given)")
+	procStdin.MarkFlagsMutuallyExclusive("data", "data-file", "file", "data-b64")
+	procStdin.MarkFlagsOneRequired("data", "data-file", "file", "data-b64")
 	procStdoutStream := &cobra.Command{Use: "stdout-stream <id> <process-id>", Short: "Stream process stdout/stderr", Args: cobra.ExactArgs(2), RunE: runBrowsersProcessStdoutStream}
-	procRoot.AddCommand(procExec, procSpawn, procKill, procStatus, procStdin, procStdoutStream)
+	procStdoutStream.Flags().String("output-file", "", "Also write the decoded output to this file")
+	procStdoutStream.Flags().String("separate-stderr", "", "Write stderr output to this file instead of --output-file")
+	procKillAll := &cobra.Command{Use: "kill-all <id>", Short: "Send a signal to every process spawned in the session by this CLI", Args: cobra.ExactArgs(1), RunE: runBrowsersProcessKillAll}
+	procKillAll.Flags().String("signal", "TERM", "Signal to send (TERM, KILL, INT, HUP)")
+	procStatus.Flags().Bool("watch", false, "Keep polling and re-rendering the status until the process exits")
+	procStatus.Flags().Duration("interval", defaultProcessWatchInterval, "Polling interval for --watch")
+	procRoot.AddCommand(procExec, procSpawn, procKill, procKillAll, procStatus, procStdin, procStdoutStream)
 	browsersCmd.AddCommand(procRoot)
 
 	// fs
@@ -1826,21 +4747,44 @@ func init() {
 	fsDownloadZip.Flags().String("path", "", "Absolute directory path to download")
 	_ = fsDownloadZip.MarkFlagRequired("path")
 	fsDownloadZip.Flags().StringP("output", "o", "", "Output zip file path")
+	fsDownloadZip.Flags().String("extract", "", "Extract the zip into this directory (created if missing, must be empty); the zip is also kept if --output is set")
+	fsDownloadZip.Flags().Int("strip-components", 0, "With --extract, drop this many leading path components from each entry")
 	fsFileInfo := &cobra.Command{Use: "file-info <id>", Short: "Get file or directory info", Args: cobra.ExactArgs(1), RunE: runBrowsersFSFileInfo}
 	fsFileInfo.Flags().String("path", "", "Absolute file or directory path")
 	_ = fsFileInfo.MarkFlagRequired("path")
 	fsListFiles := &cobra.Command{Use: "list-files <id>", Short: "List files in a directory", Args: cobra.ExactArgs(1), RunE: runBrowsersFSListFiles}
 	fsListFiles.Flags().String("path", "", "Absolute directory path")
 	_ = fsListFiles.MarkFlagRequired("path")
+	fsListFiles.Flags().BoolP("recursive", "r", false, "List subdirectories too, showing full paths (directories end in /)")
+	fsListFiles.Flags().Int("max-depth", defaultListMaxDepth, "With --recursive, how many directory levels to descend")
 	fsMove := &cobra.Command{Use: "move <id>", Short: "Move or rename a file or directory", Args: cobra.ExactArgs(1), RunE: runBrowsersFSMove}
 	fsMove.Flags().String("src", "", "Absolute source path")
 	fsMove.Flags().String("dest", "", "Absolute destination path")
 	_ = fsMove.MarkFlagRequired("src")
 	_ = fsMove.MarkFlagRequired("dest")
+	fsMove.Flags().Bool("no-overwrite", false, "Fail instead of replacing an existing destination")
+	fsMove.Flags().Bool("force", false, "With --no-overwrite, replace the destination anyway")
 	fsReadFile := &cobra.Command{Use: "read-file <id>", Short: "Read a file", Args: cobra.ExactArgs(1), RunE: runBrowsersFSReadFile}
 	fsReadFile.Flags().String("path", "", "Absolute file path")
 	_ = fsReadFile.MarkFlagRequired("path")
 	fsReadFile.Flags().StringP("output", "o", "", "Output file path (optional)")
+	addRetryFlags(fsReadFile)
+	fsCat := &cobra.Command{Use: "cat <id> <path>", Short: "Print a file to stdout", Long: "Print a remote file to stdout with no other output, for piping into tools like grep or jq.", Args: cobra.ExactArgs(2), RunE: runBrowsersFSCat}
+	addRetryFlags(fsCat)
+	fsChecksum := &cobra.Command{Use: "checksum <id>", Short: "Compute the hash of a remote file", Args: cobra.ExactArgs(1), RunE: runBrowsersFSChecksum}
+	fsChecksum.Flags().String("path", "", "Absolute file path")
+	_ = fsChecksum.MarkFlagRequired("path")
+	fsChecksum.Flags().String("algo", "sha256", "Hash algorithm: md5, sha1 or sha256")
+	fsEdit := &cobra.Command{Use: "edit <id>", Short: "Edit a remote file in $EDITOR", Args: cobra.ExactArgs(1), RunE: runBrowsersFSEdit}
+	fsEdit.Flags().String("path", "", "Absolute file path to edit")
+	_ = fsEdit.MarkFlagRequired("path")
+	fsGrep := &cobra.Command{Use: "grep <id>", Short: "Search file contents for a regular expression", Args: cobra.ExactArgs(1), RunE: runBrowsersFSGrep}
+	fsGrep.Flags().String("path", "", "Absolute directory path to search")
+	fsGrep.Flags().String("pattern", "", "Regular expression to match (Go RE2 syntax)")
+	_ = fsGrep.MarkFlagRequired("path")
+	_ = fsGrep.MarkFlagRequired("pattern")
+	fsGrep.Flags().BoolP("recursive", "r", false, "Search subdirectories")
+	fsGrep.Flags().Int64("max-file-size", 1<<20, "Skip files larger than this many bytes")
 	fsSetPerms := &cobra.Command{Use: "set-permissions <id>", Short: "Set file permissions or ownership", Args: cobra.ExactArgs(1), RunE: runBrowsersFSSetPermissions}
 	fsSetPerms.Flags().String("path", "", "Absolute path")
 	fsSetPerms.Flags().String("mode", "", "File mode bits (octal string)")
@@ -1853,13 +4797,21 @@ func init() {
 	fsUpload := &cobra.Command{Use: "upload <id>", Short: "Upload one or more files", Args: cobra.ExactArgs(1), RunE: runBrowsersFSUpload}
 	fsUpload.Flags().StringSlice("file", []string{}, "Mapping local:remote (repeatable)")
 	fsUpload.Flags().String("dest-dir", "", "Destination directory for uploads")
-	fsUpload.Flags().StringSlice("paths", []string{}, "Local file paths to upload")
+	fsUpload.Flags().StringSlice("paths", []string{}, "Local file paths or glob patterns (e.g. \"dist/*.js\") to upload")
+	fsUpload.Flags().Bool("allow-empty-glob", false, "Don't fail when a --paths pattern matches no files")
+	fsUpload.Flags().String("manifest", "", "JSON or CSV file listing local/dest pairs to upload")
+	fsUpload.Flags().String("content-type", "", "Content type for every uploaded file (default: sniffed from each file)")
+	fsUpload.Flags().Bool("verify", false, "Read each file back after uploading and compare sha256 checksums")
+	fsUpload.Flags().String("recursive", "", "Upload every file under this local directory into --dest-dir, keeping relative paths")
+	fsUpload.Flags().Bool("no-progress", false, "Print a single line instead of a progress bar (implied when stderr is not a terminal)")
+	fsUpload.Flags().Bool("follow-symlinks", false, "Include symlinked files and directories with --recursive")
 
 	// fs upload-zip
 	fsUploadZip := &cobra.Command{Use: "upload-zip <id>", Short: "Upload a zip and extract it", Args: cobra.ExactArgs(1), RunE: runBrowsersFSUploadZip}
 	fsUploadZip.Flags().String("zip", "", "Local zip file path")
 	_ = fsUploadZip.MarkFlagRequired("zip")
 	fsUploadZip.Flags().String("dest-dir", "", "Destination directory to extract to")
+	fsUploadZip.Flags().Bool("no-progress", false, "Print a single line instead of a progress bar (implied when stderr is not a terminal)")
 	_ = fsUploadZip.MarkFlagRequired("dest-dir")
 
 	// fs write-file
@@ -1867,10 +4819,44 @@ func init() {
 	fsWriteFile.Flags().String("path", "", "Destination absolute file path")
 	_ = fsWriteFile.MarkFlagRequired("path")
 	fsWriteFile.Flags().String("mode", "", "File mode (octal string)")
-	fsWriteFile.Flags().String("source", "", "Local source file path")
-	_ = fsWriteFile.MarkFlagRequired("source")
-
-	fsRoot.AddCommand(fsNewDir, fsDelDir, fsDelFile, fsDownloadZip, fsFileInfo, fsListFiles, fsMove, fsReadFile, fsSetPerms, fsUpload, fsUploadZip, fsWriteFile)
+	fsWriteFile.Flags().String("source", "", "Local source file path, or - to read from stdin")
+	fsWriteFile.Flags().String("content", "", "Literal content to write instead of --source")
+	fsWriteFile.MarkFlagsOneRequired("source", "content")
+	fsWriteFile.MarkFlagsMutuallyExclusive("source", "content")
+
+	fsStat := &cobra.Command{Use: "stat <id>", Short: "Get file info for many paths at once", Args: cobra.ExactArgs(1), RunE: runBrowsersFSStat}
+	fsStat.Flags().StringArray("path", nil, "Absolute file or directory path (repeatable)")
+	fsStat.Flags().String("paths-file", "", "File listing one path per line")
+	fsStat.Flags().StringP("output", "o", "", "Output format: json for per-path results")
+	addConcurrencyFlag(fsStat)
+	addFailurePolicyFlags(fsStat, false)
+
+	sshConfig := &cobra.Command{
+		Use:   "ssh-config <id>",
+		Short: "Print an ssh_config entry that tunnels through the browser session",
+		Long: `Print an ssh_config Host entry whose ProxyCommand reaches the session through
+` + "`kernel browsers process spawn --attach`" + `, which pipes ssh's connection to nc
+running inside the session. Append it to ~/.ssh/config to point ssh-based tools
+at the session; an sshd must be listening inside it and nc must be installed.`,
+		Example: `  kernel browsers ssh-config <id> >> ~/.ssh/config`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runBrowsersSSHConfig,
+	}
+	sshConfig.Flags().String("host-alias", "", "Name of the Host entry (default kernel-<session id>)")
+	sshConfig.Flags().String("user", "root", "SSH user")
+	sshConfig.Flags().Int("port", 22, "Port sshd listens on inside the session")
+	browsersCmd.AddCommand(sshConfig)
+	browsersCmd.AddCommand(&cobra.Command{
+		Use:   "cp <source> <dest>",
+		Short: "Copy files or directories to or from a browser",
+		Long: `Copy a file or directory between this machine and a browser, scp-style.
+Write the browser side as <id>:<absolute path>; directories are transferred as zips.`,
+		Example: `  kernel browsers cp <id>:/tmp/downloads ./downloads
+  kernel browsers cp ./fixtures <id>:/tmp/fixtures`,
+		Args: cobra.ExactArgs(2),
+		RunE: runBrowsersCp,
+	})
+	fsRoot.AddCommand(fsNewDir, fsCat, fsDelDir, fsDelFile, fsChecksum, fsDownloadZip, fsEdit, fsFileInfo, fsGrep, fsListFiles, fsMove, fsReadFile, fsSetPerms, fsStat, fsUpload, fsUploadZip, fsWriteFile)
 	browsersCmd.AddCommand(fsRoot)
 
 	// extensions
@@ -1881,15 +4867,19 @@ func init() {
 
 	// computer
 	computerRoot := &cobra.Command{Use: "computer", Short: "OS-level mouse & screen controls"}
-	computerClick := &cobra.Command{Use: "click-mouse <id>", Short: "Click mouse at coordinates", Args: cobra.ExactArgs(1), RunE: runBrowsersComputerClickMouse}
+	computerClick := &cobra.Command{Use: "click-mouse <id>", Short: "Click mouse at coordinates or on an element", Args: cobra.ExactArgs(1), RunE: runBrowsersComputerClickMouse}
 	computerClick.Flags().Int64("x", 0, "X coordinate")
 	computerClick.Flags().Int64("y", 0, "Y coordinate")
-	_ = computerClick.MarkFlagRequired("x")
-	_ = computerClick.MarkFlagRequired("y")
+	computerClick.Flags().String("selector", "", "CSS selector of the element to click via Playwright (instead of --x/--y)")
+	computerClick.MarkFlagsRequiredTogether("x", "y")
+	computerClick.MarkFlagsOneRequired("x", "selector")
+	computerClick.MarkFlagsMutuallyExclusive("x", "selector")
+	computerClick.MarkFlagsMutuallyExclusive("y", "selector")
 	computerClick.Flags().Int64("num-clicks", 1, "Number of clicks")
 	computerClick.Flags().String("button", "left", "Mouse button: left,right,middle,back,forward")
 	computerClick.Flags().String("click-type", "click", "Click type: down,up,click")
 	computerClick.Flags().StringSlice("hold-key", []string{}, "Modifier keys to hold (repeatable)")
+	computerClick.MarkFlagsMutuallyExclusive("hold-key", "selector")
 
 	computerMove := &cobra.Command{Use: "move-mouse <id>", Short: "Move mouse to coordinates", Args: cobra.ExactArgs(1), RunE: runBrowsersComputerMoveMouse}
 	computerMove.Flags().Int64("x", 0, "X coordinate")
@@ -1903,7 +4893,12 @@ func init() {
 	computerScreenshot.Flags().Int64("y", 0, "Top-left Y")
 	computerScreenshot.Flags().Int64("width", 0, "Region width")
 	computerScreenshot.Flags().Int64("height", 0, "Region height")
-	computerScreenshot.Flags().String("to", "", "Output file path for the PNG image")
+	computerScreenshot.Flags().String("to", "", "Output file path for the image (the format's extension is added if missing)")
+	computerScreenshot.Flags().String("format", "png", "Image format: png or jpeg")
+	computerScreenshot.Flags().Int("quality", defaultJPEGQuality, "JPEG quality (1-100); only used with --format jpeg")
+	computerScreenshot.Flags().Bool("full-page", false, "Capture the full scrollable page via Playwright instead of the visible screen")
+	computerScreenshot.Flags().StringP("output", "o", "", "Output format: json for {path, bytes, content_type, width, height}")
+	addRetryFlags(computerScreenshot)
 	_ = computerScreenshot.MarkFlagRequired("to")
 
 	computerType := &cobra.Command{Use: "type <id>", Short: "Type text on the browser instance", Args: cobra.ExactArgs(1), RunE: runBrowsersComputerTypeText}
@@ -1914,23 +4909,29 @@ func init() {
 	// computer press-key
 	computerPressKey := &cobra.Command{Use: "press-key <id>", Short: "Press one or more keys", Args: cobra.ExactArgs(1), RunE: runBrowsersComputerPressKey}
 	computerPressKey.Flags().StringSlice("key", []string{}, "Key symbols to press (repeatable)")
-	_ = computerPressKey.MarkFlagRequired("key")
 	computerPressKey.Flags().Int64("duration", 0, "Duration to hold keys down in ms (0=tap)")
 	computerPressKey.Flags().StringSlice("hold-key", []string{}, "Modifier keys to hold (repeatable)")
+	computerPressKey.Flags().String("combo", "", "Shortcut such as ctrl+shift+t: the last key is pressed while the others are held")
+	computerPressKey.MarkFlagsOneRequired("key", "combo")
+	computerPressKey.MarkFlagsMutuallyExclusive("combo", "key")
+	computerPressKey.MarkFlagsMutuallyExclusive("combo", "hold-key")
 
 	// computer scroll
 	computerScroll := &cobra.Command{Use: "scroll <id>", Short: "Scroll the mouse wheel", Args: cobra.ExactArgs(1), RunE: runBrowsersComputerScroll}
 	computerScroll.Flags().Int64("x", 0, "X coordinate")
 	computerScroll.Flags().Int64("y", 0, "Y coordinate")
-	_ = computerScroll.MarkFlagRequired("x")
-	_ = computerScroll.MarkFlagRequired("y")
 	computerScroll.Flags().Int64("delta-x", 0, "Horizontal scroll amount (+right, -left)")
 	computerScroll.Flags().Int64("delta-y", 0, "Vertical scroll amount (+down, -up)")
 	computerScroll.Flags().StringSlice("hold-key", []string{}, "Modifier keys to hold (repeatable)")
+	computerScroll.Flags().Bool("to-bottom", false, "Scroll the page to the bottom (x/y not required)")
+	computerScroll.Flags().Bool("to-top", false, "Scroll the page to the top (x/y not required)")
+	computerScroll.MarkFlagsMutuallyExclusive("to-bottom", "to-top")
 
 	// computer drag-mouse
 	computerDrag := &cobra.Command{Use: "drag-mouse <id>", Short: "Drag the mouse along a path", Args: cobra.ExactArgs(1), RunE: runBrowsersComputerDragMouse}
 	computerDrag.Flags().StringArray("point", []string{}, "Add a point as x,y (repeatable)")
+	computerDrag.Flags().String("path-file", "", "JSON file with the path as an array of [x, y] pairs, e.g. [[0,0],[50,50]]")
+	computerDrag.MarkFlagsMutuallyExclusive("point", "path-file")
 	computerDrag.Flags().Int64("delay", 0, "Delay before dragging starts in ms")
 	computerDrag.Flags().Int64("step-delay-ms", 0, "Delay between steps while dragging (ms)")
 	computerDrag.Flags().Int64("steps-per-segment", 0, "Number of move steps per path segment")
@@ -1942,35 +4943,79 @@ func init() {
 	computerSetCursor.Flags().String("hidden", "", "Whether to hide the cursor: true or false")
 	_ = computerSetCursor.MarkFlagRequired("hidden")
 
-	computerRoot.AddCommand(computerClick, computerMove, computerScreenshot, computerType, computerPressKey, computerScroll, computerDrag, computerSetCursor)
+	// computer scroll-into-view
+	computerScrollIntoView := &cobra.Command{Use: "scroll-into-view <id>", Short: "Scroll an element into view", Args: cobra.ExactArgs(1), RunE: runBrowsersComputerScrollIntoView}
+	computerScrollIntoView.Flags().String("selector", "", "CSS selector of the element")
+	_ = computerScrollIntoView.MarkFlagRequired("selector")
+
+	computerRoot.AddCommand(computerClick, computerMove, computerScreenshot, computerType, computerPressKey, computerScroll, computerScrollIntoView, computerDrag, computerSetCursor)
 	browsersCmd.AddCommand(computerRoot)
 
 	// playwright
 	playwrightRoot := &cobra.Command{Use: "playwright", Short: "Playwright operations"}
 	playwrightExecute := &cobra.Command{Use: "execute <id> [code]", Short: "Execute Playwright/TypeScript code against the browser", Args: cobra.MinimumNArgs(1), RunE: runBrowsersPlaywrightExecute}
 	playwrightExecute.Flags().Int64("timeout", 0, "Maximum execution time in seconds (default per server)")
+	playwrightExecute.Flags().Bool("json", false, "Print a single JSON object with success, stdout, stderr, result and error; exits non-zero on failure")
 	playwrightRoot.AddCommand(playwrightExecute)
 	browsersCmd.AddCommand(playwrightRoot)
 
+	assertCmd := &cobra.Command{
+		Use:   "assert <id>",
+		Short: "Check that elements exist on the current page",
+		Long: `Check that elements exist on the current page, optionally with matching text or
+attributes. Repeat --selector for several assertions; the Nth --text and --attr
+apply to the Nth --selector (pass "" to skip one). Exits non-zero if any
+assertion fails.`,
+		Example: `  kernel browsers assert <id> --selector h1 --text Welcome
+  kernel browsers assert <id> --selector "#login" --attr disabled= --selector footer`,
+		Args: cobra.ExactArgs(1),
+		RunE: runBrowsersAssert,
+	}
+	assertCmd.Flags().StringArray("selector", nil, "CSS selector that must match an element (repeatable)")
+	assertCmd.Flags().StringArray("text", nil, "Text the matching element must contain (paired with --selector by position)")
+	assertCmd.Flags().StringArray("attr", nil, "Attribute the element must have, as name or name=value (paired with --selector by position)")
+	assertCmd.Flags().StringP("output", "o", "", "Output format: json for per-assertion results")
+	_ = assertCmd.MarkFlagRequired("selector")
+	browsersCmd.AddCommand(assertCmd)
+
+	enableSessionRefs(browsersCmd)
+
 	// Add flags for create command
 	browsersCreateCmd.Flags().StringP("persistent-id", "p", "", "[DEPRECATED] Use --timeout and profiles instead. Unique identifier for browser session persistence")
 	_ = browsersCreateCmd.Flags().MarkDeprecated("persistent-id", "use --timeout (up to 72 hours) and profiles instead")
 	browsersCreateCmd.Flags().BoolP("stealth", "s", false, "Launch browser in stealth mode to avoid detection")
 	browsersCreateCmd.Flags().BoolP("headless", "H", false, "Launch browser without GUI access")
 	browsersCreateCmd.Flags().Bool("kiosk", false, "Launch browser in kiosk mode")
-	browsersCreateCmd.Flags().IntP("timeout", "t", 60, "Timeout in seconds for the browser session")
+	addSecondsFlag(browsersCreateCmd, "timeout", "t", 60, maxBrowserTimeout, "Inactivity timeout: the session is closed after this long with no CDP or live view connection, in seconds or as a duration (e.g. 90m, 2h; max 72h)")
 	browsersCreateCmd.Flags().String("profile-id", "", "Profile ID to load into the browser session (mutually exclusive with --profile-name)")
 	browsersCreateCmd.Flags().String("profile-name", "", "Profile name to load into the browser session (mutually exclusive with --profile-id)")
-	browsersCreateCmd.Flags().Bool("save-changes", false, "If set, save changes back to the profile when the session ends")
+	addSaveChangesFlags(browsersCreateCmd, "If set, save changes back to the profile when the session ends")
+	browsersCreateCmd.Flags().Bool("create-if-missing", false, "Create the profile named by --profile-name if it does not exist")
+	browsersCreateCmd.Flags().String("save-session", "", "Write the new session ID to this file (use with --session-from)")
+	browsersCreateCmd.Flags().String("screenshot-on-create", "", "Once the browser is ready, save a screenshot to this path")
 	browsersCreateCmd.Flags().String("proxy-id", "", "Proxy ID to use for the browser session")
 	browsersCreateCmd.Flags().StringSlice("extension", []string{}, "Extension IDs or names to load (repeatable; may be passed multiple times or comma-separated)")
+	browsersCreateCmd.Flags().StringArray("extension-from-dir", nil, "Local unpacked extension directory to upload and load (repeatable)")
 	browsersCreateCmd.Flags().String("viewport", "", "Browser viewport size (e.g., 1920x1080@25). Supported: 2560x1440@10, 1920x1080@25, 1920x1200@25, 1440x900@25, 1024x768@60, 1200x800@60")
 	browsersCreateCmd.Flags().Bool("viewport-interactive", false, "Interactively select viewport size from list")
+	browsersCreateCmd.Flags().Bool("force-viewport", false, "Send --viewport even if it is not in the supported list")
 	browsersCreateCmd.Flags().String("pool-id", "", "Browser pool ID to acquire from (mutually exclusive with --pool-name)")
 	browsersCreateCmd.Flags().String("pool-name", "", "Browser pool name to acquire from (mutually exclusive with --pool-id)")
+	addYesFlag(browsersCreateCmd)
+	browsersCreateCmd.Flags().StringP("output", "o", "", "Output format: json for the raw API response")
+	browsersCreateCmd.Flags().Bool("wait", false, "Wait until the new browser is ready to accept connections before printing it")
+	browsersCreateCmd.Flags().Duration("wait-timeout", 30*time.Second, "How long --wait waits for the browser to become ready")
+	browsersCreateCmd.Flags().Bool("open", false, "Once the browser is ready, open its live view in your default browser (implies --wait)")
+	browsersCreateCmd.MarkFlagsMutuallyExclusive("open", "output")
+	browsersCreateCmd.Flags().Bool("retry-on-capacity", false, "Wait and retry when the org is at its browser limit")
+	browsersCreateCmd.Flags().Duration("capacity-timeout", 2*time.Minute, "How long --retry-on-capacity waits for capacity to free up")
 
 	// Add flags for delete command
-	browsersDeleteCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	addYesFlag(browsersDeleteCmd)
+	browsersDeleteCmd.Flags().Bool("all", false, "Delete every running browser")
+	browsersDeleteCmd.Flags().Int("concurrency", 0, fmt.Sprintf("Number of deletions to run in parallel (default: $%s or %d)", concurrencyEnvVar, defaultDeleteConcurrency))
+	addFailurePolicyFlags(browsersDeleteCmd, false)
+	browsersDeleteCmd.Flags().StringP("output", "o", "", "Output format: json for per-identifier results (requires --yes)")
 
 	// no flags for view; it takes a single positional argument
 }
@@ -1983,12 +5028,55 @@ func runBrowsersList(cmd *cobra.Command, args []string) error {
 	includeDeleted, _ := cmd.Flags().GetBool("include-deleted")
 	limit, _ := cmd.Flags().GetInt("limit")
 	offset, _ := cmd.Flags().GetInt("offset")
+	count, _ := cmd.Flags().GetBool("count")
+	all, _ := cmd.Flags().GetBool("all")
+	sortKey, _ := cmd.Flags().GetString("sort")
+	profile, _ := cmd.Flags().GetString("profile")
+	retry, err := getRetryOptions(cmd)
+	if err != nil {
+		return err
+	}
 	return b.List(cmd.Context(), BrowsersListInput{
+		Sort:           sortKey,
+		Profile:        profile,
 		Output:         out,
 		IncludeDeleted: includeDeleted,
+		Count:          count,
+		All:            all,
 		Limit:          limit,
 		Offset:         offset,
+		Retry:          retry,
+	})
+}
+
+// poolAllowedFlags are the browsers create flags that still apply when the
+// browser comes from a pool.
+var poolAllowedFlags = map[string]bool{
+	"pool-id":      true,
+	"pool-name":    true,
+	"timeout":      true,
+	"save-session": true,
+	"yes":          true,
+	"output":       true,
+	"session-from": true,
+	"strict-id":    true,
+	// Global persistent flags that don't configure browsers
+	"no-color":  true,
+	"log-level": true,
+	"raw":       true,
+	"plain":     true,
+}
+
+// poolFlagConflicts returns the set flags that configure the browser and are
+// therefore ignored when acquiring from a pool.
+func poolFlagConflicts(flags *pflag.FlagSet) []string {
+	var conflicts []string
+	flags.Visit(func(f *pflag.Flag) {
+		if !poolAllowedFlags[f.Name] {
+			conflicts = append(conflicts, "--"+f.Name)
+		}
 	})
+	return conflicts
 }
 
 func runBrowsersCreate(cmd *cobra.Command, args []string) error {
@@ -2002,16 +5090,30 @@ func runBrowsersCreate(cmd *cobra.Command, args []string) error {
 	stealthVal, _ := cmd.Flags().GetBool("stealth")
 	headlessVal, _ := cmd.Flags().GetBool("headless")
 	kioskVal, _ := cmd.Flags().GetBool("kiosk")
-	timeout, _ := cmd.Flags().GetInt("timeout")
+	timeout := int(getSeconds(cmd, "timeout"))
 	profileID, _ := cmd.Flags().GetString("profile-id")
 	profileName, _ := cmd.Flags().GetString("profile-name")
-	saveChanges, _ := cmd.Flags().GetBool("save-changes")
+	createIfMissing, _ := cmd.Flags().GetBool("create-if-missing")
 	proxyID, _ := cmd.Flags().GetString("proxy-id")
 	extensions, _ := cmd.Flags().GetStringSlice("extension")
+	extensionDirs, _ := cmd.Flags().GetStringArray("extension-from-dir")
 	viewport, _ := cmd.Flags().GetString("viewport")
 	viewportInteractive, _ := cmd.Flags().GetBool("viewport-interactive")
+	forceViewport, _ := cmd.Flags().GetBool("force-viewport")
 	poolID, _ := cmd.Flags().GetString("pool-id")
 	poolName, _ := cmd.Flags().GetString("pool-name")
+	saveSession, _ := cmd.Flags().GetString("save-session")
+	screenshotPath, _ := cmd.Flags().GetString("screenshot-on-create")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	retryOnCapacity, _ := cmd.Flags().GetBool("retry-on-capacity")
+	capacityTimeout, _ := cmd.Flags().GetDuration("capacity-timeout")
+	wait, _ := cmd.Flags().GetBool("wait")
+	waitTimeout, _ := cmd.Flags().GetDuration("wait-timeout")
+	open, _ := cmd.Flags().GetBool("open")
+	output, _ := cmd.Flags().GetString("output")
+	if !checkOutput(output, outputJSON) {
+		return nil
+	}
 
 	if poolID != "" && poolName != "" {
 		pterm.Error.Println("must specify at most one of --pool-id or --pool-name")
@@ -2020,24 +5122,11 @@ func runBrowsersCreate(cmd *cobra.Command, args []string) error {
 
 	if poolID != "" || poolName != "" {
 		// When using a pool, configuration comes from the pool itself.
-		allowedFlags := map[string]bool{
-			"pool-id":   true,
-			"pool-name": true,
-			"timeout":   true,
-			// Global persistent flags that don't configure browsers
-			"no-color":  true,
-			"log-level": true,
-		}
-
-		// Check if any browser configuration flags were set (which would conflict).
-		var conflicts []string
-		cmd.Flags().Visit(func(f *pflag.Flag) {
-			if !allowedFlags[f.Name] {
-				conflicts = append(conflicts, "--"+f.Name)
-			}
-		})
+		conflicts := poolFlagConflicts(cmd.Flags())
 
-		if len(conflicts) > 0 {
+		// With --output json the conflicting flags are ignored without
+		// comment so stdout stays valid JSON.
+		if len(conflicts) > 0 && output == "" {
 			flagLabel := "--pool-id"
 			if poolName != "" {
 				flagLabel = "--pool-name"
@@ -2046,8 +5135,7 @@ func runBrowsersCreate(cmd *cobra.Command, args []string) error {
 			pterm.Info.Println("When using a pool, all browser configuration comes from the pool itself.")
 			pterm.Info.Println("The conflicting flags will be ignored.")
 
-			result, _ := pterm.DefaultInteractiveConfirm.Show("Continue with pool configuration?")
-			if !result {
+			if !skipConfirm && !confirmPrompt("Continue with pool configuration?") {
 				pterm.Info.Println("Cancelled. Remove conflicting flags or omit the pool flag.")
 				return nil
 			}
@@ -2059,7 +5147,9 @@ func runBrowsersCreate(cmd *cobra.Command, args []string) error {
 			pool = poolName
 		}
 
-		pterm.Info.Printf("Acquiring browser from pool %s...\n", pool)
+		if output == "" {
+			pterm.Info.Printf("Acquiring browser from pool %s...\n", pool)
+		}
 		poolSvc := client.BrowserPools
 
 		acquireParams := kernel.BrowserPoolAcquireParams{}
@@ -2075,12 +5165,19 @@ func runBrowsersCreate(cmd *cobra.Command, args []string) error {
 			pterm.Error.Println("Acquire request timed out (no browser available). Retry to continue waiting.")
 			return nil
 		}
-		printBrowserSessionResult(resp.SessionID, resp.CdpWsURL, resp.BrowserLiveViewURL, resp.Persistence, resp.Profile)
-		return nil
+		if output == outputJSON {
+			if err := printStructured(resp, outputJSON); err != nil {
+				return err
+			}
+		} else {
+			printBrowserSessionResult(resp.SessionID, resp.CdpWsURL, resp.BrowserLiveViewURL, resp.Persistence, resp.Profile)
+		}
+		return recordSession(resp.SessionID, saveSession, true)
 	}
 
-	// Handle interactive viewport selection
-	if viewportInteractive {
+	// Handle interactive viewport selection; skipped with --output json since
+	// the prompt would write to stdout.
+	if viewportInteractive && output == "" {
 		if viewport != "" {
 			pterm.Warning.Println("Both --viewport and --viewport-interactive specified; using interactive mode")
 		}
@@ -2104,30 +5201,39 @@ func runBrowsersCreate(cmd *cobra.Command, args []string) error {
 		Kiosk:              BoolFlag{Set: cmd.Flags().Changed("kiosk"), Value: kioskVal},
 		ProfileID:          profileID,
 		ProfileName:        profileName,
-		ProfileSaveChanges: BoolFlag{Set: cmd.Flags().Changed("save-changes"), Value: saveChanges},
+		ProfileSaveChanges: getSaveChangesFlag(cmd),
+		CreateIfMissing:    createIfMissing,
 		ProxyID:            proxyID,
 		Extensions:         extensions,
+		ExtensionDirs:      extensionDirs,
 		Viewport:           viewport,
+		ForceViewport:      forceViewport,
+		SaveSession:        saveSession,
+		RememberSession:    true,
+		ScreenshotOnCreate: screenshotPath,
+		RetryOnCapacity:    retryOnCapacity,
+		CapacityTimeout:    capacityTimeout,
+		Wait:               wait,
+		WaitTimeout:        waitTimeout,
+		Open:               open,
+		Output:             output,
 	}
 
 	svc := client.Browsers
-	b := BrowsersCmd{browsers: &svc}
+	b := BrowsersCmd{browsers: &svc, profiles: &client.Profiles, computer: &svc.Computer, playwright: &svc.Playwright, extensions: &client.Extensions}
 	return b.Create(cmd.Context(), in)
 }
 
 func runBrowsersDelete(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	output, _ := cmd.Flags().GetString("output")
+	all, _ := cmd.Flags().GetBool("all")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
 
 	svc := client.Browsers
 	b := BrowsersCmd{browsers: &svc}
-	// Iterate all provided identifiers
-	for _, identifier := range args {
-		if err := b.Delete(cmd.Context(), BrowsersDeleteInput{Identifier: identifier, SkipConfirm: skipConfirm}); err != nil {
-			return err
-		}
-	}
-	return nil
+	return b.DeleteMany(cmd.Context(), BrowsersDeleteManyInput{Identifiers: args, All: all, SkipConfirm: skipConfirm, Output: output, Concurrency: resolveConcurrencyDefault(concurrency, defaultDeleteConcurrency), FailFast: getFailFast(cmd)})
 }
 
 func runBrowsersView(cmd *cobra.Command, args []string) error {
@@ -2135,21 +5241,56 @@ func runBrowsersView(cmd *cobra.Command, args []string) error {
 
 	identifier := args[0]
 
-	in := BrowsersViewInput{Identifier: identifier}
+	open, _ := cmd.Flags().GetBool("open")
+	output, _ := cmd.Flags().GetString("output")
+	in := BrowsersViewInput{Identifier: identifier, Open: open, Output: output}
 	svc := client.Browsers
 	b := BrowsersCmd{browsers: &svc}
 	return b.View(cmd.Context(), in)
 }
 
+func runBrowsersReboot(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	timeout, _ := cmd.Flags().GetInt("timeout")
+	if timeout <= 0 {
+		pterm.Error.Println("--timeout must be greater than 0")
+		return nil
+	}
+	svc := client.Browsers
+	b := BrowsersCmd{browsers: &svc, process: &svc.Process, playwright: &svc.Playwright}
+	return b.Reboot(cmd.Context(), BrowsersRebootInput{Identifier: args[0], Timeout: time.Duration(timeout) * time.Second})
+}
+
+func runBrowsersExport(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	to, _ := cmd.Flags().GetString("to")
+	zip, _ := cmd.Flags().GetBool("zip")
+	fsPath, _ := cmd.Flags().GetString("fs-path")
+	logTimeout, _ := cmd.Flags().GetDuration("log-timeout")
+	svc := client.Browsers
+	b := BrowsersCmd{browsers: &svc, computer: &svc.Computer, logs: &svc.Logs, fs: &svc.Fs, replays: &svc.Replays}
+	return b.Export(cmd.Context(), BrowsersExportInput{Identifier: args[0], To: to, Zip: zip, FSPath: fsPath, LogTimeout: logTimeout})
+}
+
 func runBrowsersGet(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	out, _ := cmd.Flags().GetString("output")
+	retry, err := getRetryOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	watchUntil, _ := cmd.Flags().GetString("watch-until")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
 
 	svc := client.Browsers
-	b := BrowsersCmd{browsers: &svc}
+	b := BrowsersCmd{browsers: &svc, playwright: &svc.Playwright}
 	return b.Get(cmd.Context(), BrowsersGetInput{
 		Identifier: args[0],
 		Output:     out,
+		Retry:      retry,
+		WatchUntil: watchUntil,
+		Timeout:    timeout,
 	})
 }
 
@@ -2157,9 +5298,24 @@ func runBrowsersLogsStream(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	svc := client.Browsers
 	followVal, _ := cmd.Flags().GetBool("follow")
-	source, _ := cmd.Flags().GetString("source")
+	sources, _ := cmd.Flags().GetStringSlice("source")
+	source := strings.Join(sources, ",")
 	path, _ := cmd.Flags().GetString("path")
 	supervisor, _ := cmd.Flags().GetString("supervisor-process")
+	orderBy, _ := cmd.Flags().GetString("order-by")
+	outputFile, _ := cmd.Flags().GetString("output-file")
+	rotateSize, _ := cmd.Flags().GetInt64("rotate-size")
+	rotateKeep, _ := cmd.Flags().GetInt("rotate-keep")
+	if (cmd.Flags().Changed("rotate-size") || cmd.Flags().Changed("rotate-keep")) && outputFile == "" {
+		pterm.Error.Println("--rotate-size and --rotate-keep require --output-file")
+		return nil
+	}
+	if rotateSize < 0 || rotateKeep < 0 {
+		pterm.Error.Println("--rotate-size and --rotate-keep must not be negative")
+		return nil
+	}
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	level, _ := cmd.Flags().GetString("level")
 	b := BrowsersCmd{browsers: &svc, logs: &svc.Logs}
 	return b.LogsStream(cmd.Context(), BrowsersLogsStreamInput{
 		Identifier:        args[0],
@@ -2167,14 +5323,23 @@ func runBrowsersLogsStream(cmd *cobra.Command, args []string) error {
 		Follow:            BoolFlag{Set: cmd.Flags().Changed("follow"), Value: followVal},
 		Path:              path,
 		SupervisorProcess: supervisor,
+		OrderBy:           orderBy,
+		OutputFile:        outputFile,
+		RotateSize:        rotateSize,
+		RotateKeep:        rotateKeep,
+		JSON:              jsonOut,
+		Level:             level,
 	})
 }
 
 func runBrowsersReplaysList(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	svc := client.Browsers
+	active, _ := cmd.Flags().GetBool("active")
+	finished, _ := cmd.Flags().GetBool("finished")
+	out, _ := cmd.Flags().GetString("output")
 	b := BrowsersCmd{browsers: &svc, replays: &svc.Replays}
-	return b.ReplaysList(cmd.Context(), BrowsersReplaysListInput{Identifier: args[0]})
+	return b.ReplaysList(cmd.Context(), BrowsersReplaysListInput{Identifier: args[0], Active: active, Finished: finished, Output: out})
 }
 
 func runBrowsersReplaysStart(cmd *cobra.Command, args []string) error {
@@ -2189,8 +5354,13 @@ func runBrowsersReplaysStart(cmd *cobra.Command, args []string) error {
 func runBrowsersReplaysStop(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	svc := client.Browsers
+	all, _ := cmd.Flags().GetBool("all")
+	in := BrowsersReplaysStopInput{Identifier: args[0], All: all}
+	if len(args) > 1 {
+		in.ReplayID = args[1]
+	}
 	b := BrowsersCmd{browsers: &svc, replays: &svc.Replays}
-	return b.ReplaysStop(cmd.Context(), BrowsersReplaysStopInput{Identifier: args[0], ReplayID: args[1]})
+	return b.ReplaysStop(cmd.Context(), in)
 }
 
 func runBrowsersReplaysDownload(cmd *cobra.Command, args []string) error {
@@ -2210,6 +5380,7 @@ func runBrowsersProcessExec(cmd *cobra.Command, args []string) error {
 	timeout, _ := cmd.Flags().GetInt("timeout")
 	asUser, _ := cmd.Flags().GetString("as-user")
 	asRoot, _ := cmd.Flags().GetBool("as-root")
+	outputFile, _ := cmd.Flags().GetString("output-file")
 	if command == "" && len(args) > 1 {
 		// Treat trailing args after identifier as a shell command
 		shellCmd := strings.Join(args[1:], " ")
@@ -2217,7 +5388,7 @@ func runBrowsersProcessExec(cmd *cobra.Command, args []string) error {
 		argv = []string{"-c", shellCmd}
 	}
 	b := BrowsersCmd{browsers: &svc, process: &svc.Process}
-	return b.ProcessExec(cmd.Context(), BrowsersProcessExecInput{Identifier: args[0], Command: command, Args: argv, Cwd: cwd, Timeout: timeout, AsUser: asUser, AsRoot: BoolFlag{Set: cmd.Flags().Changed("as-root"), Value: asRoot}})
+	return b.ProcessExec(cmd.Context(), BrowsersProcessExecInput{Identifier: args[0], Command: command, Args: argv, Cwd: cwd, Timeout: timeout, AsUser: asUser, AsRoot: BoolFlag{Set: cmd.Flags().Changed("as-root"), Value: asRoot}, OutputFile: outputFile})
 }
 
 func runBrowsersProcessSpawn(cmd *cobra.Command, args []string) error {
@@ -2229,13 +5400,19 @@ func runBrowsersProcessSpawn(cmd *cobra.Command, args []string) error {
 	timeout, _ := cmd.Flags().GetInt("timeout")
 	asUser, _ := cmd.Flags().GetString("as-user")
 	asRoot, _ := cmd.Flags().GetBool("as-root")
+	stdinFile, _ := cmd.Flags().GetString("stdin-file")
+	outputFile, _ := cmd.Flags().GetString("output-file")
+	attach, _ := cmd.Flags().GetBool("attach")
 	if command == "" && len(args) > 1 {
 		shellCmd := strings.Join(args[1:], " ")
 		command = "/bin/bash"
 		argv = []string{"-c", shellCmd}
 	}
 	b := BrowsersCmd{browsers: &svc, process: &svc.Process}
-	return b.ProcessSpawn(cmd.Context(), BrowsersProcessSpawnInput{Identifier: args[0], Command: command, Args: argv, Cwd: cwd, Timeout: timeout, AsUser: asUser, AsRoot: BoolFlag{Set: cmd.Flags().Changed("as-root"), Value: asRoot}})
+	if tracker, err := newFileProcessTracker(); err == nil {
+		b.tracker = tracker
+	}
+	return b.ProcessSpawn(cmd.Context(), BrowsersProcessSpawnInput{Identifier: args[0], Command: command, Args: argv, Cwd: cwd, Timeout: timeout, AsUser: asUser, AsRoot: BoolFlag{Set: cmd.Flags().Changed("as-root"), Value: asRoot}, StdinFile: stdinFile, OutputFile: outputFile, Attach: attach})
 }
 
 func runBrowsersProcessKill(cmd *cobra.Command, args []string) error {
@@ -2246,31 +5423,83 @@ func runBrowsersProcessKill(cmd *cobra.Command, args []string) error {
 	return b.ProcessKill(cmd.Context(), BrowsersProcessKillInput{Identifier: args[0], ProcessID: args[1], Signal: signal})
 }
 
+func runBrowsersProcessKillAll(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	svc := client.Browsers
+	signal, _ := cmd.Flags().GetString("signal")
+	tracker, err := newFileProcessTracker()
+	if err != nil {
+		return err
+	}
+	b := BrowsersCmd{browsers: &svc, process: &svc.Process, tracker: tracker}
+	return b.ProcessKillAll(cmd.Context(), BrowsersProcessKillAllInput{Identifier: args[0], Signal: signal})
+}
+
 func runBrowsersProcessStatus(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	svc := client.Browsers
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("interval")
 	b := BrowsersCmd{browsers: &svc, process: &svc.Process}
-	return b.ProcessStatus(cmd.Context(), BrowsersProcessStatusInput{Identifier: args[0], ProcessID: args[1]})
+	return b.ProcessStatus(cmd.Context(), BrowsersProcessStatusInput{Identifier: args[0], ProcessID: args[1], Watch: watch, Interval: interval})
 }
 
 func runBrowsersProcessStdin(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	svc := client.Browsers
-	data, _ := cmd.Flags().GetString("data-b64")
+	dataB64, _ := cmd.Flags().GetString("data-b64")
+	data, _ := cmd.Flags().GetString("data")
+	file, _ := cmd.Flags().GetString("data-file")
+	if file == "" {
+		file, _ = cmd.Flags().GetString("file")
+	}
+	newline, _ := cmd.Flags().GetBool("newline")
 	b := BrowsersCmd{browsers: &svc, process: &svc.Process}
-	return b.ProcessStdin(cmd.Context(), BrowsersProcessStdinInput{Identifier: args[0], ProcessID: args[1], DataB64: data})
+	return b.ProcessStdin(cmd.Context(), BrowsersProcessStdinInput{Identifier: args[0], ProcessID: args[1], DataB64: dataB64, Data: data, File: file, Newline: newline})
 }
 
 func runBrowsersProcessStdoutStream(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	svc := client.Browsers
+	outputFile, _ := cmd.Flags().GetString("output-file")
+	stderrFile, _ := cmd.Flags().GetString("separate-stderr")
 	b := BrowsersCmd{browsers: &svc, process: &svc.Process}
-	return b.ProcessStdoutStream(cmd.Context(), BrowsersProcessStdoutStreamInput{Identifier: args[0], ProcessID: args[1]})
+	return b.ProcessStdoutStream(cmd.Context(), BrowsersProcessStdoutStreamInput{Identifier: args[0], ProcessID: args[1], OutputFile: outputFile, StderrFile: stderrFile})
+}
+
+func runBrowsersAssert(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	svc := client.Browsers
+	selectors, _ := cmd.Flags().GetStringArray("selector")
+	texts, _ := cmd.Flags().GetStringArray("text")
+	attrs, _ := cmd.Flags().GetStringArray("attr")
+	output, _ := cmd.Flags().GetString("output")
+	if len(texts) > len(selectors) || len(attrs) > len(selectors) {
+		pterm.Error.Println("each --text and --attr must pair with a --selector")
+		return nil
+	}
+	assertions := make([]browserAssertion, len(selectors))
+	for i, sel := range selectors {
+		assertions[i].Selector = sel
+		if i < len(texts) {
+			assertions[i].Text = texts[i]
+		}
+		if i < len(attrs) {
+			assertions[i].Attr = attrs[i]
+		}
+	}
+	b := BrowsersCmd{browsers: &svc, playwright: &svc.Playwright}
+	return b.Assert(cmd.Context(), BrowsersAssertInput{Identifier: args[0], Assertions: assertions, Output: output})
 }
 
 func runBrowsersPlaywrightExecute(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	svc := client.Browsers
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	errPrinter := &pterm.Error
+	if jsonOutput {
+		errPrinter = pterm.Error.WithWriter(os.Stderr)
+	}
 
 	var code string
 	if len(args) >= 2 {
@@ -2279,19 +5508,25 @@ func runBrowsersPlaywrightExecute(cmd *cobra.Command, args []string) error {
 		// Read code from stdin
 		stat, _ := os.Stdin.Stat()
 		if (stat.Mode() & os.ModeCharDevice) != 0 {
-			pterm.Error.Println("no code provided. Provide code as an argument or pipe via stdin")
+			errPrinter.Println("no code provided. Provide code as an argument or pipe via stdin")
 			return nil
 		}
 		data, err := io.ReadAll(os.Stdin)
 		if err != nil {
-			pterm.Error.Printf("failed to read stdin: %v\n", err)
+			errPrinter.Printf("failed to read stdin: %v\n", err)
 			return nil
 		}
 		code = string(data)
 	}
 	timeout, _ := cmd.Flags().GetInt64("timeout")
 	b := BrowsersCmd{browsers: &svc, playwright: &svc.Playwright}
-	return b.PlaywrightExecute(cmd.Context(), BrowsersPlaywrightExecuteInput{Identifier: args[0], Code: strings.TrimSpace(code), Timeout: timeout})
+	err := b.PlaywrightExecute(cmd.Context(), BrowsersPlaywrightExecuteInput{Identifier: args[0], Code: strings.TrimSpace(code), Timeout: timeout, JSON: jsonOutput})
+	if err != nil && jsonOutput && !errors.As(err, new(reportedError)) {
+		// Keep stdout empty rather than mixing an error into it.
+		errPrinter.Println(err.Error())
+		return reportedError{err}
+	}
+	return err
 }
 
 func runBrowsersFSNewDirectory(cmd *cobra.Command, args []string) error {
@@ -2319,13 +5554,36 @@ func runBrowsersFSDeleteFile(cmd *cobra.Command, args []string) error {
 	return b.FSDeleteFile(cmd.Context(), BrowsersFSDeleteFileInput{Identifier: args[0], Path: path})
 }
 
+func runBrowsersSSHConfig(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	svc := client.Browsers
+	alias, _ := cmd.Flags().GetString("host-alias")
+	user, _ := cmd.Flags().GetString("user")
+	port, _ := cmd.Flags().GetInt("port")
+	cliPath, err := os.Executable()
+	if err != nil {
+		cliPath = "kernel"
+	}
+	b := BrowsersCmd{browsers: &svc}
+	return b.SSHConfig(cmd.Context(), BrowsersSSHConfigInput{Identifier: args[0], HostAlias: alias, User: user, Port: port, CLIPath: cliPath})
+}
+
+func runBrowsersCp(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	svc := client.Browsers
+	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
+	return b.Cp(cmd.Context(), BrowsersCpInput{Source: args[0], Dest: args[1]})
+}
+
 func runBrowsersFSDownloadDirZip(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	svc := client.Browsers
 	path, _ := cmd.Flags().GetString("path")
 	out, _ := cmd.Flags().GetString("output")
+	extract, _ := cmd.Flags().GetString("extract")
+	strip, _ := cmd.Flags().GetInt("strip-components")
 	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
-	return b.FSDownloadDirZip(cmd.Context(), BrowsersFSDownloadDirZipInput{Identifier: args[0], Path: path, Output: out})
+	return b.FSDownloadDirZip(cmd.Context(), BrowsersFSDownloadDirZipInput{Identifier: args[0], Path: path, Output: out, ExtractDir: extract, StripComponents: strip})
 }
 
 func runBrowsersFSFileInfo(cmd *cobra.Command, args []string) error {
@@ -2336,12 +5594,47 @@ func runBrowsersFSFileInfo(cmd *cobra.Command, args []string) error {
 	return b.FSFileInfo(cmd.Context(), BrowsersFSFileInfoInput{Identifier: args[0], Path: path})
 }
 
+func runBrowsersFSStat(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	svc := client.Browsers
+	paths, _ := cmd.Flags().GetStringArray("path")
+	pathsFile, _ := cmd.Flags().GetString("paths-file")
+	out, _ := cmd.Flags().GetString("output")
+	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
+	return b.FSStat(cmd.Context(), BrowsersFSStatInput{Identifier: args[0], Paths: paths, PathsFile: pathsFile, Concurrency: getConcurrency(cmd), FailFast: getFailFast(cmd), Output: out})
+}
+
 func runBrowsersFSListFiles(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	svc := client.Browsers
 	path, _ := cmd.Flags().GetString("path")
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	maxDepth, _ := cmd.Flags().GetInt("max-depth")
+	if maxDepth < 1 {
+		pterm.Error.Println("--max-depth must be at least 1")
+		return nil
+	}
+	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
+	return b.FSListFiles(cmd.Context(), BrowsersFSListFilesInput{Identifier: args[0], Path: path, Recursive: recursive, MaxDepth: maxDepth})
+}
+
+func runBrowsersFSEdit(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	svc := client.Browsers
+	path, _ := cmd.Flags().GetString("path")
+	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
+	return b.FSEdit(cmd.Context(), BrowsersFSEditInput{Identifier: args[0], Path: path})
+}
+
+func runBrowsersFSGrep(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	svc := client.Browsers
+	path, _ := cmd.Flags().GetString("path")
+	pattern, _ := cmd.Flags().GetString("pattern")
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	maxSize, _ := cmd.Flags().GetInt64("max-file-size")
 	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
-	return b.FSListFiles(cmd.Context(), BrowsersFSListFilesInput{Identifier: args[0], Path: path})
+	return b.FSGrep(cmd.Context(), BrowsersFSGrepInput{Identifier: args[0], Path: path, Pattern: pattern, Recursive: recursive, MaxFileSize: maxSize})
 }
 
 func runBrowsersFSMove(cmd *cobra.Command, args []string) error {
@@ -2349,8 +5642,19 @@ func runBrowsersFSMove(cmd *cobra.Command, args []string) error {
 	svc := client.Browsers
 	src, _ := cmd.Flags().GetString("src")
 	dest, _ := cmd.Flags().GetString("dest")
+	noOverwrite, _ := cmd.Flags().GetBool("no-overwrite")
+	force, _ := cmd.Flags().GetBool("force")
+	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
+	return b.FSMove(cmd.Context(), BrowsersFSMoveInput{Identifier: args[0], SrcPath: src, DestPath: dest, NoOverwrite: noOverwrite, Force: force})
+}
+
+func runBrowsersFSChecksum(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	svc := client.Browsers
+	path, _ := cmd.Flags().GetString("path")
+	algo, _ := cmd.Flags().GetString("algo")
 	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
-	return b.FSMove(cmd.Context(), BrowsersFSMoveInput{Identifier: args[0], SrcPath: src, DestPath: dest})
+	return b.FSChecksum(cmd.Context(), BrowsersFSChecksumInput{Identifier: args[0], Path: path, Algo: algo})
 }
 
 func runBrowsersFSReadFile(cmd *cobra.Command, args []string) error {
@@ -2358,8 +5662,23 @@ func runBrowsersFSReadFile(cmd *cobra.Command, args []string) error {
 	svc := client.Browsers
 	path, _ := cmd.Flags().GetString("path")
 	out, _ := cmd.Flags().GetString("output")
+	retry, err := getRetryOptions(cmd)
+	if err != nil {
+		return err
+	}
+	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
+	return b.FSReadFile(cmd.Context(), BrowsersFSReadFileInput{Identifier: args[0], Path: path, Output: out, Retry: retry})
+}
+
+func runBrowsersFSCat(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	svc := client.Browsers
+	retry, err := getRetryOptions(cmd)
+	if err != nil {
+		return err
+	}
 	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
-	return b.FSReadFile(cmd.Context(), BrowsersFSReadFileInput{Identifier: args[0], Path: path, Output: out})
+	return b.FSReadFile(cmd.Context(), BrowsersFSReadFileInput{Identifier: args[0], Path: args[1], Retry: retry})
 }
 
 func runBrowsersFSSetPermissions(cmd *cobra.Command, args []string) error {
@@ -2379,6 +5698,8 @@ func runBrowsersFSUpload(cmd *cobra.Command, args []string) error {
 	fileMaps, _ := cmd.Flags().GetStringSlice("file")
 	destDir, _ := cmd.Flags().GetString("dest-dir")
 	paths, _ := cmd.Flags().GetStringSlice("paths")
+	manifest, _ := cmd.Flags().GetString("manifest")
+	contentType, _ := cmd.Flags().GetString("content-type")
 	var mappings []struct {
 		Local string
 		Dest  string
@@ -2396,7 +5717,16 @@ func runBrowsersFSUpload(cmd *cobra.Command, args []string) error {
 		}{Local: parts[0], Dest: parts[1]})
 	}
 	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
-	return b.FSUpload(cmd.Context(), BrowsersFSUploadInput{Identifier: args[0], Mappings: mappings, DestDir: destDir, Paths: paths})
+	verify, _ := cmd.Flags().GetBool("verify")
+	recursive, _ := cmd.Flags().GetString("recursive")
+	followSymlinks, _ := cmd.Flags().GetBool("follow-symlinks")
+	allowEmptyGlob, _ := cmd.Flags().GetBool("allow-empty-glob")
+	noProgress, _ := cmd.Flags().GetBool("no-progress")
+	if recursive != "" && destDir == "" {
+		pterm.Error.Println("--recursive requires --dest-dir")
+		return nil
+	}
+	return b.FSUpload(cmd.Context(), BrowsersFSUploadInput{Identifier: args[0], Mappings: mappings, DestDir: destDir, Paths: paths, Manifest: manifest, ContentType: contentType, Verify: verify, Recursive: recursive, FollowSymlinks: followSymlinks, AllowEmptyGlob: allowEmptyGlob, NoProgress: noProgress || !stderrIsTerminal()})
 }
 
 func runBrowsersFSUploadZip(cmd *cobra.Command, args []string) error {
@@ -2404,8 +5734,9 @@ func runBrowsersFSUploadZip(cmd *cobra.Command, args []string) error {
 	svc := client.Browsers
 	zipPath, _ := cmd.Flags().GetString("zip")
 	destDir, _ := cmd.Flags().GetString("dest-dir")
+	noProgress, _ := cmd.Flags().GetBool("no-progress")
 	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
-	return b.FSUploadZip(cmd.Context(), BrowsersFSUploadZipInput{Identifier: args[0], ZipPath: zipPath, DestDir: destDir})
+	return b.FSUploadZip(cmd.Context(), BrowsersFSUploadZipInput{Identifier: args[0], ZipPath: zipPath, DestDir: destDir, NoProgress: noProgress || !stderrIsTerminal()})
 }
 
 func runBrowsersFSWriteFile(cmd *cobra.Command, args []string) error {
@@ -2414,8 +5745,9 @@ func runBrowsersFSWriteFile(cmd *cobra.Command, args []string) error {
 	path, _ := cmd.Flags().GetString("path")
 	mode, _ := cmd.Flags().GetString("mode")
 	input, _ := cmd.Flags().GetString("source")
+	content, _ := cmd.Flags().GetString("content")
 	b := BrowsersCmd{browsers: &svc, fs: &svc.Fs}
-	return b.FSWriteFile(cmd.Context(), BrowsersFSWriteFileInput{Identifier: args[0], DestPath: path, Mode: mode, SourcePath: input})
+	return b.FSWriteFile(cmd.Context(), BrowsersFSWriteFileInput{Identifier: args[0], DestPath: path, Mode: mode, SourcePath: input, Content: content, HasContent: cmd.Flags().Changed("content")})
 }
 
 func runBrowsersExtensionsUpload(cmd *cobra.Command, args []string) error {
@@ -2434,8 +5766,9 @@ func runBrowsersComputerClickMouse(cmd *cobra.Command, args []string) error {
 	button, _ := cmd.Flags().GetString("button")
 	clickType, _ := cmd.Flags().GetString("click-type")
 	holdKeys, _ := cmd.Flags().GetStringSlice("hold-key")
-	b := BrowsersCmd{browsers: &svc, computer: &svc.Computer}
-	return b.ComputerClickMouse(cmd.Context(), BrowsersComputerClickMouseInput{Identifier: args[0], X: x, Y: y, NumClicks: numClicks, Button: button, ClickType: clickType, HoldKeys: holdKeys})
+	selector, _ := cmd.Flags().GetString("selector")
+	b := BrowsersCmd{browsers: &svc, computer: &svc.Computer, playwright: &svc.Playwright}
+	return b.ComputerClickMouse(cmd.Context(), BrowsersComputerClickMouseInput{Identifier: args[0], X: x, Y: y, NumClicks: numClicks, Button: button, ClickType: clickType, HoldKeys: holdKeys, Selector: selector})
 }
 
 func runBrowsersComputerMoveMouse(cmd *cobra.Command, args []string) error {
@@ -2471,8 +5804,22 @@ func runBrowsersComputerScreenshot(cmd *cobra.Command, args []string) error {
 			return nil
 		}
 	}
-	b := BrowsersCmd{browsers: &svc, computer: &svc.Computer}
-	return b.ComputerScreenshot(cmd.Context(), BrowsersComputerScreenshotInput{Identifier: args[0], X: x, Y: y, Width: w, Height: h, To: to, HasRegion: useRegion})
+	retry, err := getRetryOptions(cmd)
+	if err != nil {
+		return err
+	}
+	fullPage, _ := cmd.Flags().GetBool("full-page")
+	format, _ := cmd.Flags().GetString("format")
+	quality, _ := cmd.Flags().GetInt("quality")
+	if cmd.Flags().Changed("quality") {
+		if f, err := parseScreenshotFormat(format); err == nil && f != "jpeg" {
+			pterm.Error.Println("--quality only applies to --format jpeg")
+			return nil
+		}
+	}
+	output, _ := cmd.Flags().GetString("output")
+	b := BrowsersCmd{browsers: &svc, computer: &svc.Computer, playwright: &svc.Playwright}
+	return b.ComputerScreenshot(cmd.Context(), BrowsersComputerScreenshotInput{Identifier: args[0], X: x, Y: y, Width: w, Height: h, To: to, HasRegion: useRegion, Retry: retry, FullPage: fullPage, Format: format, Quality: quality, Output: output})
 }
 
 func runBrowsersComputerTypeText(cmd *cobra.Command, args []string) error {
@@ -2490,6 +5837,14 @@ func runBrowsersComputerPressKey(cmd *cobra.Command, args []string) error {
 	keys, _ := cmd.Flags().GetStringSlice("key")
 	duration, _ := cmd.Flags().GetInt64("duration")
 	holdKeys, _ := cmd.Flags().GetStringSlice("hold-key")
+	if combo, _ := cmd.Flags().GetString("combo"); combo != "" {
+		key, holds, err := parseKeyCombo(combo)
+		if err != nil {
+			pterm.Error.Println(err.Error())
+			return nil
+		}
+		keys, holdKeys = []string{key}, holds
+	}
 	b := BrowsersCmd{browsers: &svc, computer: &svc.Computer}
 	return b.ComputerPressKey(cmd.Context(), BrowsersComputerPressKeyInput{Identifier: args[0], Keys: keys, Duration: duration, HoldKeys: holdKeys})
 }
@@ -2504,8 +5859,13 @@ func runBrowsersComputerScroll(cmd *cobra.Command, args []string) error {
 	dxSet := cmd.Flags().Changed("delta-x")
 	dySet := cmd.Flags().Changed("delta-y")
 	holdKeys, _ := cmd.Flags().GetStringSlice("hold-key")
-	b := BrowsersCmd{browsers: &svc, computer: &svc.Computer}
-	return b.ComputerScroll(cmd.Context(), BrowsersComputerScrollInput{Identifier: args[0], X: x, Y: y, DeltaX: dx, DeltaXSet: dxSet, DeltaY: dy, DeltaYSet: dySet, HoldKeys: holdKeys})
+	toBottom, _ := cmd.Flags().GetBool("to-bottom")
+	toTop, _ := cmd.Flags().GetBool("to-top")
+	if !toBottom && !toTop && (!cmd.Flags().Changed("x") || !cmd.Flags().Changed("y")) {
+		return fmt.Errorf("--x and --y are required unless --to-bottom or --to-top is set")
+	}
+	b := BrowsersCmd{browsers: &svc, computer: &svc.Computer, playwright: &svc.Playwright}
+	return b.ComputerScroll(cmd.Context(), BrowsersComputerScrollInput{Identifier: args[0], X: x, Y: y, DeltaX: dx, DeltaXSet: dxSet, DeltaY: dy, DeltaYSet: dySet, HoldKeys: holdKeys, ToBottom: toBottom, ToTop: toTop})
 }
 
 func runBrowsersComputerDragMouse(cmd *cobra.Command, args []string) error {
@@ -2517,9 +5877,18 @@ func runBrowsersComputerDragMouse(cmd *cobra.Command, args []string) error {
 	stepsPerSegment, _ := cmd.Flags().GetInt64("steps-per-segment")
 	button, _ := cmd.Flags().GetString("button")
 	holdKeys, _ := cmd.Flags().GetStringSlice("hold-key")
+	pathFile, _ := cmd.Flags().GetString("path-file")
 
 	// Parse points of form x,y into [][]int64
 	var path [][]int64
+	if pathFile != "" {
+		p, err := loadDragPath(pathFile)
+		if err != nil {
+			pterm.Error.Println(err.Error())
+			return nil
+		}
+		path = p
+	}
 	for _, p := range points {
 		parts := strings.SplitN(p, ",", 2)
 		if len(parts) != 2 {
@@ -2543,6 +5912,14 @@ func runBrowsersComputerDragMouse(cmd *cobra.Command, args []string) error {
 	return b.ComputerDragMouse(cmd.Context(), BrowsersComputerDragMouseInput{Identifier: args[0], Path: path, Delay: delay, StepDelayMs: stepDelayMs, StepsPerSegment: stepsPerSegment, Button: button, HoldKeys: holdKeys})
 }
 
+func runBrowsersComputerScrollIntoView(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	svc := client.Browsers
+	selector, _ := cmd.Flags().GetString("selector")
+	b := BrowsersCmd{browsers: &svc, playwright: &svc.Playwright}
+	return b.ComputerScrollIntoView(cmd.Context(), BrowsersComputerScrollIntoViewInput{Identifier: args[0], Selector: selector})
+}
+
 func runBrowsersComputerSetCursor(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 	svc := client.Browsers
@@ -1,15 +1,24 @@
 package cmd
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,12 +28,37 @@ import (
 	"github.com/onkernel/kernel-go-sdk/packages/ssestream"
 	"github.com/onkernel/kernel-go-sdk/shared"
 	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // outBuf captures pterm output during tests.
 var outBuf bytes.Buffer
 
+// stubConfirm replaces the interactive prompt with a fixed answer for the test.
+func stubConfirm(t *testing.T, answer bool) {
+	t.Helper()
+	orig := confirmPrompt
+	confirmPrompt = func(string) bool { return answer }
+	t.Cleanup(func() { confirmPrompt = orig })
+}
+
+// captureStdout runs fn and returns what it wrote to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	fn()
+	w.Close()
+	os.Stdout = old
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	return buf.String()
+}
+
 // setupStdoutCapture sets pterm's default output to an in-memory buffer.
 func setupStdoutCapture(t *testing.T) {
 	outBuf.Reset()
@@ -135,6 +169,184 @@ func TestBrowsersList_PrintsEmptyMessagePageIsNil(t *testing.T) {
 	assert.Contains(t, out, "No running browsers found")
 }
 
+func TestBrowsersList_Count(t *testing.T) {
+	var query kernel.BrowserListParams
+	fake := &FakeBrowsersService{
+		ListFunc: func(ctx context.Context, q kernel.BrowserListParams, opts ...option.RequestOption) (*pagination.OffsetPagination[kernel.BrowserListResponse], error) {
+			query = q
+			items := []kernel.BrowserListResponse{{SessionID: "sess-1"}, {SessionID: "sess-2"}}
+			return &pagination.OffsetPagination[kernel.BrowserListResponse]{Items: items}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+
+	out := captureStdout(t, func() {
+		assert.NoError(t, b.List(context.Background(), BrowsersListInput{Count: true, IncludeDeleted: true}))
+	})
+	assert.Equal(t, "2\n", out)
+	assert.True(t, query.IncludeDeleted.Value)
+
+	out = captureStdout(t, func() {
+		assert.NoError(t, b.List(context.Background(), BrowsersListInput{Count: true, Output: "json"}))
+	})
+	assert.JSONEq(t, `{"count":2}`, out)
+}
+
+func TestBrowsersList_CSVAndYAML(t *testing.T) {
+	longURL := "https://live.example.com/" + strings.Repeat("x", 80)
+	fake := &FakeBrowsersService{
+		ListFunc: func(ctx context.Context, q kernel.BrowserListParams, opts ...option.RequestOption) (*pagination.OffsetPagination[kernel.BrowserListResponse], error) {
+			items := []kernel.BrowserListResponse{{SessionID: "sess-1", CdpWsURL: "ws://cdp", BrowserLiveViewURL: longURL, Profile: kernel.Profile{Name: "work"}}}
+			return &pagination.OffsetPagination[kernel.BrowserListResponse]{Items: items}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+
+	out := captureStdout(t, func() {
+		assert.NoError(t, b.List(context.Background(), BrowsersListInput{Output: "csv", IncludeDeleted: true}))
+	})
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"Browser ID", "Created At", "Persistent ID", "Profile", "CDP WS URL", "Live View URL", "Deleted At"}, rows[0])
+	assert.Equal(t, []string{"sess-1", "-", "-", "work", "ws://cdp", longURL, "-"}, rows[1])
+
+	out = captureStdout(t, func() {
+		assert.NoError(t, b.List(context.Background(), BrowsersListInput{Output: "yaml"}))
+	})
+	assert.Contains(t, out, "  session_id: sess-1\n")
+	assert.Contains(t, out, "browser_live_view_url: "+longURL)
+}
+
+func TestBrowsersList_UnsupportedOutput(t *testing.T) {
+	setupStdoutCapture(t)
+	b := BrowsersCmd{browsers: &FakeBrowsersService{}}
+	assert.NoError(t, b.List(context.Background(), BrowsersListInput{Output: "xml"}))
+	assert.Contains(t, outBuf.String(), "unsupported --output value: use 'json', 'ndjson', 'yaml' or 'csv'")
+}
+
+func TestBrowsersList_SortIsStable(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := &FakeBrowsersService{
+		ListFunc: func(ctx context.Context, q kernel.BrowserListParams, opts ...option.RequestOption) (*pagination.OffsetPagination[kernel.BrowserListResponse], error) {
+			items := []kernel.BrowserListResponse{
+				{SessionID: "c", CreatedAt: t0.Add(time.Hour)},
+				{SessionID: "a", CreatedAt: t0},
+				{SessionID: "b", CreatedAt: t0.Add(time.Hour)},
+			}
+			return &pagination.OffsetPagination[kernel.BrowserListResponse]{Items: items}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+
+	ids := func(sortKey string) []string {
+		out := captureStdout(t, func() {
+			assert.NoError(t, b.List(context.Background(), BrowsersListInput{Output: "json", Sort: sortKey}))
+		})
+		var got []kernel.BrowserListResponse
+		require.NoError(t, json.Unmarshal([]byte(out), &got))
+		var ids []string
+		for _, item := range got {
+			ids = append(ids, item.SessionID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"a", "c", "b"}, ids("created"))
+	assert.Equal(t, []string{"c", "b", "a"}, ids("-created"))
+	assert.Equal(t, []string{"a", "b", "c"}, ids("session-id"))
+}
+
+func TestBrowsersList_UnknownSortKey(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := &FakeBrowsersService{
+		ListFunc: func(ctx context.Context, q kernel.BrowserListParams, opts ...option.RequestOption) (*pagination.OffsetPagination[kernel.BrowserListResponse], error) {
+			t.Fatal("List should not be called with an unknown --sort key")
+			return nil, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	assert.NoError(t, b.List(context.Background(), BrowsersListInput{Sort: "age"}))
+	assert.Contains(t, outBuf.String(), `unknown --sort key "age": use one of -created, created, profile, session-id`)
+}
+
+func TestBrowsersList_ProfileFilterMatchesNameOrID(t *testing.T) {
+	fake := &FakeBrowsersService{
+		ListFunc: func(ctx context.Context, q kernel.BrowserListParams, opts ...option.RequestOption) (*pagination.OffsetPagination[kernel.BrowserListResponse], error) {
+			items := []kernel.BrowserListResponse{
+				{SessionID: "by-name", Profile: kernel.Profile{ID: "prof_1", Name: "work"}},
+				{SessionID: "other", Profile: kernel.Profile{ID: "prof_2", Name: "personal"}},
+				{SessionID: "by-id", Profile: kernel.Profile{ID: "work"}},
+				{SessionID: "none"},
+			}
+			return &pagination.OffsetPagination[kernel.BrowserListResponse]{Items: items}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+
+	out := captureStdout(t, func() {
+		assert.NoError(t, b.List(context.Background(), BrowsersListInput{Output: "ndjson", Profile: "work"}))
+	})
+	assert.Equal(t, 2, strings.Count(out, "\n"))
+	assert.Contains(t, out, `"session_id":"by-name"`)
+	assert.Contains(t, out, `"session_id":"by-id"`)
+
+	out = captureStdout(t, func() {
+		assert.NoError(t, b.List(context.Background(), BrowsersListInput{Count: true, Profile: "prof_2"}))
+	})
+	assert.Equal(t, "1\n", out)
+}
+
+func TestBrowsersList_AllNDJSON(t *testing.T) {
+	pages := map[int64][]kernel.BrowserListResponse{
+		0: {{SessionID: "sess-1"}, {SessionID: "sess-2"}},
+		2: {{SessionID: "sess-3"}},
+	}
+	var offsets []int64
+	fake := &FakeBrowsersService{
+		ListFunc: func(ctx context.Context, q kernel.BrowserListParams, opts ...option.RequestOption) (*pagination.OffsetPagination[kernel.BrowserListResponse], error) {
+			assert.Equal(t, int64(2), q.Limit.Value)
+			offsets = append(offsets, q.Offset.Value)
+			return &pagination.OffsetPagination[kernel.BrowserListResponse]{Items: pages[q.Offset.Value]}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+
+	out := captureStdout(t, func() {
+		assert.NoError(t, b.List(context.Background(), BrowsersListInput{All: true, Limit: 2, Output: "ndjson"}))
+	})
+	assert.Equal(t, []int64{0, 2, 3}, offsets)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if assert.Len(t, lines, 3) {
+		for i, line := range lines {
+			var got kernel.BrowserListResponse
+			assert.NoError(t, json.Unmarshal([]byte(line), &got), line)
+			assert.Equal(t, fmt.Sprintf("sess-%d", i+1), got.SessionID)
+		}
+	}
+}
+
+func TestBrowsersList_AllContinuesPastServerCappedPages(t *testing.T) {
+	var offsets []int64
+	fake := &FakeBrowsersService{
+		ListFunc: func(ctx context.Context, q kernel.BrowserListParams, opts ...option.RequestOption) (*pagination.OffsetPagination[kernel.BrowserListResponse], error) {
+			offsets = append(offsets, q.Offset.Value)
+			// The server returns at most 2 items however large --limit is.
+			var items []kernel.BrowserListResponse
+			for i := q.Offset.Value; i < 5 && i < q.Offset.Value+2; i++ {
+				items = append(items, kernel.BrowserListResponse{SessionID: fmt.Sprintf("sess-%d", i+1)})
+			}
+			return &pagination.OffsetPagination[kernel.BrowserListResponse]{Items: items}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+
+	out := captureStdout(t, func() {
+		assert.NoError(t, b.List(context.Background(), BrowsersListInput{All: true, Limit: 10, Output: "ndjson"}))
+	})
+	assert.Equal(t, []int64{0, 2, 4, 5}, offsets)
+	assert.Len(t, strings.Split(strings.TrimSpace(out), "\n"), 5)
+}
+
 func TestBrowsersList_PrintsTableWithRows(t *testing.T) {
 	setupStdoutCapture(t)
 
@@ -220,6 +432,247 @@ func TestBrowsersCreate_PrintsResponse(t *testing.T) {
 	assert.Contains(t, out, "pid-new")
 }
 
+func TestBrowsersCreate_ReadOnlyProfile(t *testing.T) {
+	setupStdoutCapture(t)
+
+	cmd := &cobra.Command{Use: "create"}
+	addSaveChangesFlags(cmd, "Save changes")
+	require.NoError(t, cmd.Flags().Parse([]string{"--read-only"}))
+	saveChanges := getSaveChangesFlag(cmd)
+	assert.Equal(t, BoolFlag{Set: true, Value: false}, saveChanges)
+
+	var got kernel.BrowserNewParams
+	fake := &FakeBrowsersService{
+		NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+			got = body
+			return &kernel.BrowserNewResponse{SessionID: "sess-new"}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	require.NoError(t, b.Create(context.Background(), BrowsersCreateInput{ProfileName: "work", ProfileSaveChanges: saveChanges}))
+
+	assert.True(t, got.Profile.SaveChanges.Valid())
+	assert.False(t, got.Profile.SaveChanges.Value)
+	assert.Contains(t, outBuf.String(), "Profile work is read-only for this session: changes will not be saved")
+}
+
+func TestBrowsersCreate_JSONOutput(t *testing.T) {
+	setupStdoutCapture(t)
+
+	fake := &FakeBrowsersService{
+		NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+			return &kernel.BrowserNewResponse{SessionID: "sess-new", CdpWsURL: "ws://cdp-new"}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	var err error
+	out := captureStdout(t, func() {
+		err = b.Create(context.Background(), BrowsersCreateInput{Output: "json"})
+	})
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	assert.Equal(t, "sess-new", got["session_id"])
+	assert.Equal(t, "ws://cdp-new", got["cdp_ws_url"])
+	assert.NotContains(t, outBuf.String(), "Creating browser session")
+}
+
+func TestBrowsersCreate_JSONOutputKeepsStatusOffStdout(t *testing.T) {
+	setupStdoutCapture(t)
+	orig := openInBrowser
+	openInBrowser = func(url string) error { return nil }
+	t.Cleanup(func() { openInBrowser = orig })
+
+	fakeProfiles := &FakeProfilesService{
+		GetFunc: func(ctx context.Context, idOrName string, opts ...option.RequestOption) (*kernel.Profile, error) {
+			return nil, &kernel.Error{StatusCode: http.StatusNotFound}
+		},
+		NewFunc: func(ctx context.Context, body kernel.ProfileNewParams, opts ...option.RequestOption) (*kernel.Profile, error) {
+			return &kernel.Profile{ID: "prof_1", Name: body.Name.Value}, nil
+		},
+	}
+	fakeBrowsers := &FakeBrowsersService{
+		NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+			return &kernel.BrowserNewResponse{SessionID: "sess-new"}, nil
+		},
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserGetResponse, error) {
+			return &kernel.BrowserGetResponse{SessionID: id, BrowserLiveViewURL: "http://view-ready"}, nil
+		},
+	}
+	fakePW := &FakePlaywrightService{ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+		return &kernel.BrowserPlaywrightExecuteResponse{Success: true}, nil
+	}}
+	fakeComp := &FakeComputerService{CaptureScreenshotFunc: func(ctx context.Context, id string, body kernel.BrowserComputerCaptureScreenshotParams, opts ...option.RequestOption) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("pngDATA"))}, nil
+	}}
+	b := BrowsersCmd{browsers: fakeBrowsers, profiles: fakeProfiles, computer: fakeComp, playwright: fakePW}
+
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	var err error
+	out := captureStdout(t, func() {
+		err = b.Create(context.Background(), BrowsersCreateInput{
+			Output:             "json",
+			ProfileName:        "first-run",
+			CreateIfMissing:    true,
+			Open:               true,
+			WaitTimeout:        time.Second,
+			ScreenshotOnCreate: filepath.Join(t.TempDir(), "initial.png"),
+		})
+	})
+	w.Close()
+	os.Stderr = oldStderr
+	stderr, _ := io.ReadAll(r)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &got), "stdout: %s", out)
+	assert.Equal(t, "sess-new", got["session_id"])
+	assert.Empty(t, outBuf.String())
+	assert.Contains(t, string(stderr), "Created profile 'first-run'")
+	assert.Contains(t, string(stderr), "Opened live view")
+	assert.Contains(t, string(stderr), "Saved screenshot to")
+}
+
+func TestBrowsersCreate_WaitPollsUntilLiveView(t *testing.T) {
+	setupStdoutCapture(t)
+	orig := watchPollInterval
+	watchPollInterval = time.Millisecond
+	t.Cleanup(func() { watchPollInterval = orig })
+
+	calls := 0
+	fake := &FakeBrowsersService{
+		NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+			return &kernel.BrowserNewResponse{SessionID: "sess-new", CdpWsURL: "ws://cdp-new"}, nil
+		},
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserGetResponse, error) {
+			calls++
+			resp := &kernel.BrowserGetResponse{SessionID: id, CdpWsURL: "ws://cdp-new"}
+			if calls >= 3 {
+				resp.BrowserLiveViewURL = "http://view-ready"
+			}
+			return resp, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	require.NoError(t, b.Create(context.Background(), BrowsersCreateInput{Wait: true, WaitTimeout: time.Second}))
+
+	assert.Equal(t, 3, calls)
+	out := outBuf.String()
+	assert.Contains(t, out, "http://view-ready")
+	assert.NotContains(t, out, "not ready")
+}
+
+func TestBrowsersCreate_WaitTimeoutStillPrintsSession(t *testing.T) {
+	setupStdoutCapture(t)
+	orig := watchPollInterval
+	watchPollInterval = time.Millisecond
+	t.Cleanup(func() { watchPollInterval = orig })
+
+	fake := &FakeBrowsersService{
+		NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+			return &kernel.BrowserNewResponse{SessionID: "sess-new", CdpWsURL: "ws://cdp-new"}, nil
+		},
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserGetResponse, error) {
+			return &kernel.BrowserGetResponse{SessionID: id, CdpWsURL: "ws://cdp-new"}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	require.NoError(t, b.Create(context.Background(), BrowsersCreateInput{Wait: true, WaitTimeout: 5 * time.Millisecond}))
+
+	out := outBuf.String()
+	assert.Contains(t, out, "Browser sess-new not ready after 5ms")
+	assert.Contains(t, out, "ws://cdp-new")
+}
+
+func TestBrowsersCreate_OpenLaunchesLiveView(t *testing.T) {
+	setupStdoutCapture(t)
+	var opened []string
+	orig := openInBrowser
+	openInBrowser = func(url string) error { opened = append(opened, url); return nil }
+	t.Cleanup(func() { openInBrowser = orig })
+
+	headless := false
+	fake := &FakeBrowsersService{
+		NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+			return &kernel.BrowserNewResponse{SessionID: "sess-new", Headless: headless}, nil
+		},
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserGetResponse, error) {
+			if headless {
+				return &kernel.BrowserGetResponse{SessionID: id, Headless: true}, nil
+			}
+			return &kernel.BrowserGetResponse{SessionID: id, BrowserLiveViewURL: "http://view-ready"}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	require.NoError(t, b.Create(context.Background(), BrowsersCreateInput{Open: true, WaitTimeout: time.Second}))
+	assert.Equal(t, []string{"http://view-ready"}, opened)
+	assert.Contains(t, outBuf.String(), "Opened live view")
+
+	headless = true
+	require.NoError(t, b.Create(context.Background(), BrowsersCreateInput{Open: true, WaitTimeout: time.Second}))
+	assert.Len(t, opened, 1)
+	assert.Contains(t, outBuf.String(), "Headless browsers have no live view")
+}
+
+func TestBrowsersCreate_CreateIfMissing_CreatesProfile(t *testing.T) {
+	setupStdoutCapture(t)
+	var created kernel.ProfileNewParams
+	fakeProfiles := &FakeProfilesService{
+		GetFunc: func(ctx context.Context, idOrName string, opts ...option.RequestOption) (*kernel.Profile, error) {
+			return nil, &kernel.Error{StatusCode: http.StatusNotFound}
+		},
+		NewFunc: func(ctx context.Context, body kernel.ProfileNewParams, opts ...option.RequestOption) (*kernel.Profile, error) {
+			created = body
+			return &kernel.Profile{ID: "prof_1", Name: body.Name.Value}, nil
+		},
+	}
+	var newParams kernel.BrowserNewParams
+	fakeBrowsers := &FakeBrowsersService{
+		NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+			newParams = body
+			return &kernel.BrowserNewResponse{SessionID: "sess-new"}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fakeBrowsers, profiles: fakeProfiles}
+	err := b.Create(context.Background(), BrowsersCreateInput{ProfileName: "first-run", CreateIfMissing: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "first-run", created.Name.Value)
+	assert.Equal(t, "first-run", newParams.Profile.Name.Value)
+	assert.Contains(t, outBuf.String(), "Created profile 'first-run'")
+}
+
+func TestBrowsersCreate_CreateIfMissing_ExistingProfileNotRecreated(t *testing.T) {
+	setupStdoutCapture(t)
+	fakeProfiles := &FakeProfilesService{
+		GetFunc: func(ctx context.Context, idOrName string, opts ...option.RequestOption) (*kernel.Profile, error) {
+			return &kernel.Profile{ID: "prof_1", Name: idOrName}, nil
+		},
+		NewFunc: func(ctx context.Context, body kernel.ProfileNewParams, opts ...option.RequestOption) (*kernel.Profile, error) {
+			t.Fatal("New should not be called for an existing profile")
+			return nil, nil
+		},
+	}
+	b := BrowsersCmd{browsers: &FakeBrowsersService{}, profiles: fakeProfiles}
+	err := b.Create(context.Background(), BrowsersCreateInput{ProfileName: "existing", CreateIfMissing: true})
+	assert.NoError(t, err)
+}
+
+func TestBrowsersCreate_CreateIfMissing_NoProfilesService(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := &FakeBrowsersService{
+		NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+			t.Fatal("browser should not be created when the profile cannot be ensured")
+			return nil, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	err := b.Create(context.Background(), BrowsersCreateInput{ProfileName: "first-run", CreateIfMissing: true})
+	assert.ErrorContains(t, err, "profiles service not available")
+}
+
 func TestBrowsersCreate_PrintsErrorOnFailure(t *testing.T) {
 	setupStdoutCapture(t)
 
@@ -272,6 +725,169 @@ func TestBrowsersDelete_SkipConfirm_Failure(t *testing.T) {
 	assert.True(t, strings.Contains(errMsg, "right failed") || strings.Contains(errMsg, "left failed"), "expected error message to contain either 'right failed' or 'left failed', got: %s", errMsg)
 }
 
+func TestBrowsersDeleteMany_JSONReportsEachIdentifier(t *testing.T) {
+	setupStdoutCapture(t)
+
+	fake := &FakeBrowsersService{
+		DeleteFunc: func(ctx context.Context, body kernel.BrowserDeleteParams, opts ...option.RequestOption) error {
+			if body.PersistentID == "bad" {
+				return errors.New("left failed")
+			}
+			return nil
+		},
+		DeleteByIDFunc: func(ctx context.Context, id string, opts ...option.RequestOption) error {
+			if id == "bad" {
+				return errors.New("right failed")
+			}
+			return nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	var err error
+	out := captureStdout(t, func() {
+		err = b.DeleteMany(context.Background(), BrowsersDeleteManyInput{Identifiers: []string{"ok", "bad"}, SkipConfirm: true, Output: "json"})
+	})
+	assert.EqualError(t, err, "failed to delete 1 of 2 browsers")
+
+	var results []browserDeleteResult
+	require.NoError(t, json.Unmarshal([]byte(out), &results))
+	require.Len(t, results, 2)
+	assert.Equal(t, browserDeleteResult{Identifier: "ok", Deleted: true}, results[0])
+	assert.Equal(t, "bad", results[1].Identifier)
+	assert.False(t, results[1].Deleted)
+	assert.Equal(t, "right failed", results[1].Error)
+	assert.NotContains(t, outBuf.String(), "Successfully deleted")
+}
+
+func TestBrowsersDeleteMany_ParallelReportsStatusInOrder(t *testing.T) {
+	setupStdoutCapture(t)
+	stubConfirm(t, true)
+
+	notFound := &kernel.Error{StatusCode: http.StatusNotFound}
+	fake := &FakeBrowsersService{
+		DeleteFunc: func(ctx context.Context, body kernel.BrowserDeleteParams, opts ...option.RequestOption) error {
+			switch body.PersistentID {
+			case "gone":
+				return notFound
+			case "bad":
+				return errors.New("left failed")
+			}
+			return notFound
+		},
+		DeleteByIDFunc: func(ctx context.Context, id string, opts ...option.RequestOption) error {
+			switch id {
+			case "gone":
+				return notFound
+			case "bad":
+				return errors.New("right failed")
+			}
+			return nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	err := b.DeleteMany(context.Background(), BrowsersDeleteManyInput{Identifiers: []string{"bad", "ok", "gone"}, Concurrency: 3})
+	assert.EqualError(t, err, "failed to delete 1 of 3 browsers")
+
+	out := outBuf.String()
+	bad := strings.Index(out, "bad")
+	ok := strings.Index(out, "ok")
+	gone := strings.Index(out, "gone")
+	assert.True(t, bad < ok && ok < gone, "rows should follow input order:\n%s", out)
+	assert.Contains(t, out, "error: right failed")
+	assert.Contains(t, out, "deleted")
+	assert.Contains(t, out, "not found")
+}
+
+func TestBrowsersDeleteMany_FailFastVersusKeepGoing(t *testing.T) {
+	run := func(failFast bool) ([]string, error) {
+		setupStdoutCapture(t)
+		var attempted []string
+		fake := &FakeBrowsersService{
+			DeleteFunc: func(ctx context.Context, body kernel.BrowserDeleteParams, opts ...option.RequestOption) error {
+				if body.PersistentID == "bad" {
+					return errors.New("boom")
+				}
+				return &kernel.Error{StatusCode: http.StatusNotFound}
+			},
+			DeleteByIDFunc: func(ctx context.Context, id string, opts ...option.RequestOption) error {
+				attempted = append(attempted, id)
+				if id == "bad" {
+					return errors.New("boom")
+				}
+				return nil
+			},
+		}
+		b := BrowsersCmd{browsers: fake}
+		err := b.DeleteMany(context.Background(), BrowsersDeleteManyInput{Identifiers: []string{"a", "bad", "c", "d"}, SkipConfirm: true, Concurrency: 1, FailFast: failFast})
+		return attempted, err
+	}
+
+	attempted, err := run(true)
+	assert.Equal(t, []string{"a", "bad"}, attempted)
+	assert.EqualError(t, err, "failed to delete 1 of 4 browsers (stopped early, 2 not attempted)")
+	assert.Contains(t, outBuf.String(), "skipped")
+
+	attempted, err = run(false)
+	assert.Equal(t, []string{"a", "bad", "c", "d"}, attempted)
+	assert.EqualError(t, err, "failed to delete 1 of 4 browsers")
+}
+
+func TestBrowsersDeleteMany_AllPromptsOnceAndSummarizes(t *testing.T) {
+	setupStdoutCapture(t)
+
+	var prompts []string
+	orig := confirmPrompt
+	confirmPrompt = func(msg string) bool { prompts = append(prompts, msg); return true }
+	t.Cleanup(func() { confirmPrompt = orig })
+
+	var deleted []string
+	fake := &FakeBrowsersService{
+		ListFunc: func(ctx context.Context, query kernel.BrowserListParams, opts ...option.RequestOption) (*pagination.OffsetPagination[kernel.BrowserListResponse], error) {
+			items := []kernel.BrowserListResponse{{SessionID: "s1"}, {SessionID: "s2"}, {SessionID: "s3"}}
+			if query.Offset.Value > 0 {
+				items = nil
+			}
+			return &pagination.OffsetPagination[kernel.BrowserListResponse]{Items: items}, nil
+		},
+		DeleteByIDFunc: func(ctx context.Context, id string, opts ...option.RequestOption) error {
+			if id == "s2" {
+				return &kernel.Error{StatusCode: http.StatusNotFound}
+			}
+			deleted = append(deleted, id)
+			return nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	err := b.DeleteMany(context.Background(), BrowsersDeleteManyInput{All: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Delete 3 running browsers?"}, prompts)
+	assert.Equal(t, []string{"s1", "s3"}, deleted)
+	assert.Contains(t, outBuf.String(), "Deleted 2 browsers, skipped 1 already gone")
+}
+
+func TestBrowsersDeleteMany_AllDeclined(t *testing.T) {
+	setupStdoutCapture(t)
+	stubConfirm(t, false)
+
+	fake := &FakeBrowsersService{
+		ListFunc: func(ctx context.Context, query kernel.BrowserListParams, opts ...option.RequestOption) (*pagination.OffsetPagination[kernel.BrowserListResponse], error) {
+			items := []kernel.BrowserListResponse{{SessionID: "s1"}}
+			if query.Offset.Value > 0 {
+				items = nil
+			}
+			return &pagination.OffsetPagination[kernel.BrowserListResponse]{Items: items}, nil
+		},
+		DeleteByIDFunc: func(ctx context.Context, id string, opts ...option.RequestOption) error {
+			t.Fatalf("unexpected delete of %s", id)
+			return nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	require.NoError(t, b.DeleteMany(context.Background(), BrowsersDeleteManyInput{All: true}))
+	assert.Contains(t, outBuf.String(), "Deletion cancelled")
+}
+
 func TestBrowsersDelete_WithConfirm_NotFound(t *testing.T) {
 	setupStdoutCapture(t)
 
@@ -312,6 +928,34 @@ func TestBrowsersView_ByID_PrintsURL(t *testing.T) {
 	assert.Contains(t, stdoutBuf.String(), "http://live-url")
 }
 
+func TestBrowsersView_Open(t *testing.T) {
+	setupStdoutCapture(t)
+	var opened []string
+	openErr := error(nil)
+	orig := openInBrowser
+	openInBrowser = func(url string) error { opened = append(opened, url); return openErr }
+	t.Cleanup(func() { openInBrowser = orig })
+
+	fake := &FakeBrowsersService{
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserGetResponse, error) {
+			return &kernel.BrowserGetResponse{SessionID: "abc", BrowserLiveViewURL: "http://live-url"}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	out := captureStdout(t, func() {
+		require.NoError(t, b.View(context.Background(), BrowsersViewInput{Identifier: "abc", Open: true}))
+	})
+	assert.Equal(t, []string{"http://live-url"}, opened)
+	assert.Empty(t, out)
+
+	openErr = errors.New("no display")
+	out = captureStdout(t, func() {
+		require.NoError(t, b.View(context.Background(), BrowsersViewInput{Identifier: "abc", Open: true}))
+	})
+	assert.Contains(t, out, "http://live-url")
+	assert.Contains(t, outBuf.String(), "Could not open a browser (no display)")
+}
+
 func TestBrowsersView_NotFound(t *testing.T) {
 	setupStdoutCapture(t)
 
@@ -342,6 +986,28 @@ func TestBrowsersView_HeadlessBrowser_ShowsWarning(t *testing.T) {
 	assert.Contains(t, out, "headless mode")
 }
 
+func TestBrowsersView_JSONOutput(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		resp kernel.BrowserGetResponse
+		want string
+	}{
+		{"with url", kernel.BrowserGetResponse{SessionID: "abc", BrowserLiveViewURL: "https://live.example/abc"}, `{"live_view_url":"https://live.example/abc","headless":false}`},
+		{"headless", kernel.BrowserGetResponse{SessionID: "abc", Headless: true}, `{"live_view_url":null,"headless":true}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &FakeBrowsersService{GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserGetResponse, error) {
+				return &tc.resp, nil
+			}}
+			b := BrowsersCmd{browsers: fake}
+			out := captureStdout(t, func() {
+				assert.NoError(t, b.View(context.Background(), BrowsersViewInput{Identifier: "abc", Output: "json"}))
+			})
+			assert.JSONEq(t, tc.want, out)
+		})
+	}
+}
+
 func TestBrowsersView_PrintsErrorOnGetFailure(t *testing.T) {
 	setupStdoutCapture(t)
 
@@ -437,6 +1103,44 @@ func TestBrowsersGet_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestBrowsersGet_WatchUntilSecondPoll(t *testing.T) {
+	setupStdoutCapture(t)
+	orig := watchPollInterval
+	watchPollInterval = time.Millisecond
+	t.Cleanup(func() { watchPollInterval = orig })
+
+	calls := 0
+	fake := &FakeBrowsersService{
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserGetResponse, error) {
+			calls++
+			br := &kernel.BrowserGetResponse{SessionID: id}
+			if calls >= 2 {
+				br.BrowserLiveViewURL = "https://live.example/" + id
+			}
+			return br, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	err := b.Get(context.Background(), BrowsersGetInput{Identifier: "sess-1", WatchUntil: "has-live-view", Timeout: time.Second})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Contains(t, outBuf.String(), "https://live.example/sess-1")
+}
+
+func TestBrowsersGet_WatchUntilTimesOut(t *testing.T) {
+	setupStdoutCapture(t)
+	b := BrowsersCmd{browsers: &FakeBrowsersService{
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserGetResponse, error) {
+			return &kernel.BrowserGetResponse{SessionID: id}, nil
+		},
+	}}
+	err := b.Get(context.Background(), BrowsersGetInput{Identifier: "sess-1", WatchUntil: "reachable"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "did not become reachable")
+}
+
 func TestBrowsersGet_Error(t *testing.T) {
 	setupStdoutCapture(t)
 
@@ -724,6 +1428,48 @@ func (f *FakeComputerService) SetCursorVisibility(ctx context.Context, id string
 	return &kernel.BrowserComputerSetCursorVisibilityResponse{}, nil
 }
 
+// --- Fake for Playwright ---
+
+type FakePlaywrightService struct {
+	ExecuteFunc func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error)
+}
+
+func (f *FakePlaywrightService) Execute(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+	if f.ExecuteFunc != nil {
+		return f.ExecuteFunc(ctx, id, body, opts...)
+	}
+	return &kernel.BrowserPlaywrightExecuteResponse{Success: true}, nil
+}
+
+func TestBrowsersPlaywrightExecute_JSON(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := &FakePlaywrightService{ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+		return &kernel.BrowserPlaywrightExecuteResponse{Success: false, Stdout: "log line", Error: "Timeout 30000ms exceeded"}, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), playwright: fake}
+
+	var err error
+	out := captureStdout(t, func() {
+		err = b.PlaywrightExecute(context.Background(), BrowsersPlaywrightExecuteInput{Identifier: "id", Code: "await page.click('#x')", JSON: true})
+	})
+	assert.ErrorAs(t, err, new(reportedError))
+	var res playwrightExecuteResult
+	require.NoError(t, json.Unmarshal([]byte(out), &res))
+	assert.Equal(t, playwrightExecuteResult{Success: false, Stdout: "log line", Error: "Timeout 30000ms exceeded"}, res)
+	assert.Empty(t, outBuf.String())
+
+	fake.ExecuteFunc = func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+		return &kernel.BrowserPlaywrightExecuteResponse{Success: true, Result: "Example Domain"}, nil
+	}
+	out = captureStdout(t, func() {
+		err = b.PlaywrightExecute(context.Background(), BrowsersPlaywrightExecuteInput{Identifier: "id", Code: "return await page.title()", JSON: true})
+	})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(out), &res))
+	assert.True(t, res.Success)
+	assert.Equal(t, "Example Domain", res.Result)
+}
+
 // --- Tests for Logs ---
 
 // newFakeBrowsersServiceWithSimpleGet returns a FakeBrowsersService with a GetFunc that returns a browser with SessionID "id".
@@ -745,6 +1491,116 @@ func TestBrowsersLogsStream_PrintsEvents(t *testing.T) {
 	assert.Contains(t, out, "m2")
 }
 
+func TestBrowsersLogsStream_OutputFile(t *testing.T) {
+	setupStdoutCapture(t)
+	path := filepath.Join(t.TempDir(), "chromium.log")
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), logs: &FakeLogService{}}
+	err := b.LogsStream(context.Background(), BrowsersLogsStreamInput{Identifier: "id", Source: string(kernel.BrowserLogStreamParamsSourcePath), Follow: BoolFlag{Set: true, Value: false}, Path: "/var/log.txt", OutputFile: path})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^\[\d{4}-\d{2}-\d{2}T[^\]]+Z\] m1$`, lines[0])
+	assert.True(t, strings.HasSuffix(lines[1], "] m2"))
+	assert.Contains(t, outBuf.String(), "m1")
+}
+
+func TestBrowsersLogsStream_JSON(t *testing.T) {
+	setupStdoutCapture(t)
+	ts := time.Date(2025, 3, 4, 5, 6, 7, 890, time.FixedZone("X", 3600))
+	logs := &FakeLogService{StreamFunc: func(ctx context.Context, id string, query kernel.BrowserLogStreamParams, opts ...option.RequestOption) *ssestream.Stream[shared.LogEvent] {
+		return makeStream([]shared.LogEvent{{Message: "m1", Timestamp: ts}})
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), logs: logs}
+	out := captureStdout(t, func() {
+		require.NoError(t, b.LogsStream(context.Background(), BrowsersLogsStreamInput{Identifier: "id", Source: "supervisor", JSON: true}))
+	})
+	assert.Equal(t, `{"timestamp":"2025-03-04T04:06:07.00000089Z","message":"m1","source":"supervisor"}`+"\n", out)
+	assert.Empty(t, outBuf.String())
+}
+
+func TestBrowsersLogsStream_JSONErrorGoesToStderr(t *testing.T) {
+	setupStdoutCapture(t)
+	logs := &FakeLogService{StreamFunc: func(ctx context.Context, id string, query kernel.BrowserLogStreamParams, opts ...option.RequestOption) *ssestream.Stream[shared.LogEvent] {
+		return ssestream.NewStream[shared.LogEvent](&testDecoder{}, errors.New("stream dropped"))
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), logs: logs}
+
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	var err error
+	out := captureStdout(t, func() {
+		err = b.LogsStream(context.Background(), BrowsersLogsStreamInput{Identifier: "id", Source: "supervisor", JSON: true})
+	})
+	w.Close()
+	os.Stderr = oldStderr
+	stderr, _ := io.ReadAll(r)
+
+	require.Error(t, err)
+	assert.ErrorAs(t, err, new(reportedError))
+	assert.Empty(t, out)
+	assert.Contains(t, string(stderr), "stream dropped")
+}
+
+func TestBrowsersLogsStream_AllSourcesInterleaved(t *testing.T) {
+	setupStdoutCapture(t)
+	now := time.Now()
+	var mu sync.Mutex
+	got := map[string]kernel.BrowserLogStreamParams{}
+	logs := &FakeLogService{StreamFunc: func(ctx context.Context, id string, query kernel.BrowserLogStreamParams, opts ...option.RequestOption) *ssestream.Stream[shared.LogEvent] {
+		mu.Lock()
+		got[string(query.Source)] = query
+		mu.Unlock()
+		return makeStream([]shared.LogEvent{{Message: "from " + string(query.Source), Timestamp: now}})
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), logs: logs}
+	err := b.LogsStream(context.Background(), BrowsersLogsStreamInput{Identifier: "id", Source: "all", Path: "/var/log/app.log", SupervisorProcess: "chromium"})
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "/var/log/app.log", got["path"].Path.Value)
+	assert.False(t, got["path"].SupervisorProcess.Valid())
+	assert.Equal(t, "chromium", got["supervisor"].SupervisorProcess.Value)
+	assert.False(t, got["supervisor"].Path.Valid())
+
+	out := pterm.RemoveColorFromString(outBuf.String())
+	assert.Contains(t, out, "path [")
+	assert.Contains(t, out, "from path")
+	assert.Contains(t, out, "supervisor [")
+	assert.Contains(t, out, "from supervisor")
+}
+
+func TestBrowsersLogsStream_MultipleSourcesNeedTheirOptions(t *testing.T) {
+	setupStdoutCapture(t)
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), logs: &FakeLogService{}}
+	require.NoError(t, b.LogsStream(context.Background(), BrowsersLogsStreamInput{Identifier: "id", Source: "path,supervisor", SupervisorProcess: "chromium"}))
+	assert.Contains(t, outBuf.String(), "--source path requires --path")
+}
+
+func TestBrowsersLogsStream_LevelFilter(t *testing.T) {
+	setupStdoutCapture(t)
+	now := time.Now()
+	logs := &FakeLogService{StreamFunc: func(ctx context.Context, id string, query kernel.BrowserLogStreamParams, opts ...option.RequestOption) *ssestream.Stream[shared.LogEvent] {
+		return makeStream([]shared.LogEvent{
+			{Message: "INFO spawned: 'chromium'", Timestamp: now},
+			{Message: "WARN exited: neko (exit status 1)", Timestamp: now},
+			{Message: "[ERROR] chromium crashed", Timestamp: now},
+			{Message: "no marker here", Timestamp: now},
+		})
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), logs: logs}
+	require.NoError(t, b.LogsStream(context.Background(), BrowsersLogsStreamInput{Identifier: "id", Source: "supervisor", Level: "error"}))
+
+	out := outBuf.String()
+	assert.Contains(t, out, "chromium crashed")
+	assert.NotContains(t, out, "spawned")
+	assert.NotContains(t, out, "exited")
+	assert.NotContains(t, out, "no marker here")
+}
+
 // --- Tests for Replays ---
 
 func TestBrowsersReplaysList_PrintsRows(t *testing.T) {
@@ -762,6 +1618,33 @@ func TestBrowsersReplaysList_PrintsRows(t *testing.T) {
 	assert.Contains(t, out, "http://v")
 }
 
+func TestBrowsersReplaysList_ActiveAndFinishedFilters(t *testing.T) {
+	started := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	replays := []kernel.BrowserReplayListResponse{
+		{ReplayID: "recording", StartedAt: started},
+		{ReplayID: "done", StartedAt: started, FinishedAt: started.Add(time.Minute)},
+	}
+	fake := &FakeReplaysService{ListFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*[]kernel.BrowserReplayListResponse, error) {
+		return &replays, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), replays: fake}
+
+	listIDs := func(in BrowsersReplaysListInput) []string {
+		in.Identifier, in.Output = "id", "json"
+		out := captureStdout(t, func() { assert.NoError(t, b.ReplaysList(context.Background(), in)) })
+		var got []kernel.BrowserReplayListResponse
+		assert.NoError(t, json.Unmarshal([]byte(out), &got), out)
+		var ids []string
+		for _, r := range got {
+			ids = append(ids, r.ReplayID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"recording"}, listIDs(BrowsersReplaysListInput{Active: true}))
+	assert.Equal(t, []string{"done"}, listIDs(BrowsersReplaysListInput{Finished: true}))
+	assert.Equal(t, []string{"recording", "done"}, listIDs(BrowsersReplaysListInput{}))
+}
+
 func TestBrowsersReplaysStart_PrintsInfo(t *testing.T) {
 	setupStdoutCapture(t)
 	fake := &FakeReplaysService{StartFunc: func(ctx context.Context, id string, body kernel.BrowserReplayStartParams, opts ...option.RequestOption) (*kernel.BrowserReplayStartResponse, error) {
@@ -785,6 +1668,33 @@ func TestBrowsersReplaysStop_PrintsSuccess(t *testing.T) {
 	assert.Contains(t, out, "Stopped replay rid")
 }
 
+func TestBrowsersReplaysStop_AllStopsOnlyActive(t *testing.T) {
+	setupStdoutCapture(t)
+	started := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	replays := []kernel.BrowserReplayListResponse{
+		{ReplayID: "r1", StartedAt: started},
+		{ReplayID: "r2", StartedAt: started, FinishedAt: started.Add(time.Minute)},
+		{ReplayID: "r3", StartedAt: started},
+	}
+	var stopped []string
+	fake := &FakeReplaysService{
+		ListFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*[]kernel.BrowserReplayListResponse, error) {
+			return &replays, nil
+		},
+		StopFunc: func(ctx context.Context, replayID string, body kernel.BrowserReplayStopParams, opts ...option.RequestOption) error {
+			assert.Equal(t, "id", body.ID)
+			stopped = append(stopped, replayID)
+			return nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), replays: fake}
+
+	err := b.ReplaysStop(context.Background(), BrowsersReplaysStopInput{Identifier: "id", All: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"r1", "r3"}, stopped)
+	assert.NotContains(t, outBuf.String(), "r2")
+}
+
 func TestBrowsersReplaysDownload_SavesFile(t *testing.T) {
 	setupStdoutCapture(t)
 	dir := t.TempDir()
@@ -813,6 +1723,26 @@ func TestBrowsersProcessExec_PrintsSummary(t *testing.T) {
 	assert.Contains(t, out, "Duration")
 }
 
+func TestBrowsersProcessExec_OutputFile(t *testing.T) {
+	setupStdoutCapture(t)
+	enc := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	fake := &FakeProcessService{ExecFunc: func(ctx context.Context, id string, body kernel.BrowserProcessExecParams, opts ...option.RequestOption) (*kernel.BrowserProcessExecResponse, error) {
+		return &kernel.BrowserProcessExecResponse{ExitCode: 1, StdoutB64: enc("hello\n"), StderrB64: enc("oops\n")}, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: fake}
+	path := filepath.Join(t.TempDir(), "exec.log")
+
+	var err error
+	stdout := captureStdout(t, func() {
+		err = b.ProcessExec(context.Background(), BrowsersProcessExecInput{Identifier: "id", Command: "sh", OutputFile: path})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", stdout)
+	saved, _ := os.ReadFile(path)
+	assert.Equal(t, "hello\noops\n", string(saved))
+	assert.Contains(t, outBuf.String(), "Saved output to "+path)
+}
+
 func TestBrowsersProcessSpawn_PrintsInfo(t *testing.T) {
 	setupStdoutCapture(t)
 	fake := &FakeProcessService{}
@@ -824,6 +1754,84 @@ func TestBrowsersProcessSpawn_PrintsInfo(t *testing.T) {
 	assert.Contains(t, out, "PID")
 }
 
+func TestBrowsersProcessSpawn_StdinFile(t *testing.T) {
+	setupStdoutCapture(t)
+	var steps []string
+	var sent kernel.BrowserProcessStdinParams
+	fake := &FakeProcessService{
+		SpawnFunc: func(ctx context.Context, id string, body kernel.BrowserProcessSpawnParams, opts ...option.RequestOption) (*kernel.BrowserProcessSpawnResponse, error) {
+			steps = append(steps, "spawn")
+			return &kernel.BrowserProcessSpawnResponse{ProcessID: "proc"}, nil
+		},
+		StdinFunc: func(ctx context.Context, processID string, params kernel.BrowserProcessStdinParams, opts ...option.RequestOption) (*kernel.BrowserProcessStdinResponse, error) {
+			steps = append(steps, "stdin:"+processID)
+			sent = params
+			return &kernel.BrowserProcessStdinResponse{}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: fake}
+
+	err := b.ProcessSpawn(context.Background(), BrowsersProcessSpawnInput{Identifier: "id", Command: "cat", StdinFile: __writeTempFile(t, "local data")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spawn", "stdin:proc"}, steps)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("local data")), sent.DataB64)
+
+	steps = nil
+	err = b.ProcessSpawn(context.Background(), BrowsersProcessSpawnInput{Identifier: "id", Command: "cat", StdinFile: filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorContains(t, err, "failed to read stdin file")
+	assert.Empty(t, steps)
+}
+
+func TestBrowsersExport_WritesBundle(t *testing.T) {
+	setupStdoutCapture(t)
+	var logQuery kernel.BrowserLogStreamParams
+	logs := &FakeLogService{StreamFunc: func(ctx context.Context, id string, query kernel.BrowserLogStreamParams, opts ...option.RequestOption) *ssestream.Stream[shared.LogEvent] {
+		logQuery = query
+		return makeStream([]shared.LogEvent{{Message: "chromium started", Timestamp: time.Unix(0, 0)}})
+	}}
+	b := BrowsersCmd{
+		browsers: newFakeBrowsersServiceWithSimpleGet(),
+		computer: &FakeComputerService{},
+		logs:     logs,
+		fs:       &FakeFSService{},
+		replays:  &FakeReplaysService{},
+	}
+	dir := filepath.Join(t.TempDir(), "bundle")
+
+	err := b.Export(context.Background(), BrowsersExportInput{Identifier: "id", To: dir})
+	require.NoError(t, err)
+	for _, name := range []string{"browser.json", "screenshot.png", "logs.txt", "files.json", "replays.json"} {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+	assert.NoFileExists(t, filepath.Join(dir, "errors.txt"))
+	assert.Equal(t, "chromium", logQuery.SupervisorProcess.Value)
+	assert.False(t, logQuery.Follow.Value)
+	logData, err := os.ReadFile(filepath.Join(dir, "logs.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(logData), "chromium started")
+	shot, err := os.ReadFile(filepath.Join(dir, "screenshot.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "pngdata", string(shot))
+}
+
+func TestBrowsersExport_ZipAndMissingParts(t *testing.T) {
+	setupStdoutCapture(t)
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: &FakeFSService{}}
+	dir := filepath.Join(t.TempDir(), "bundle")
+
+	err := b.Export(context.Background(), BrowsersExportInput{Identifier: "id", To: dir, Zip: true})
+	require.NoError(t, err)
+	assert.NoDirExists(t, dir)
+	r, err := zip.OpenReader(dir + ".zip")
+	require.NoError(t, err)
+	defer r.Close()
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	assert.ElementsMatch(t, []string{"browser.json", "files.json", "errors.txt"}, names)
+}
+
 func TestBrowsersProcessKill_PrintsSuccess(t *testing.T) {
 	setupStdoutCapture(t)
 	fake := &FakeProcessService{}
@@ -843,7 +1851,29 @@ func TestBrowsersProcessStatus_PrintsFields(t *testing.T) {
 	out := outBuf.String()
 	assert.Contains(t, out, "State")
 	assert.Contains(t, out, "CPU %")
-	assert.Contains(t, out, "Mem Bytes")
+	assert.Contains(t, out, "Memory")
+}
+
+func TestBrowsersProcessStatus_WatchStopsOnExit(t *testing.T) {
+	setupStdoutCapture(t)
+	polls := 0
+	fake := &FakeProcessService{StatusFunc: func(ctx context.Context, processID string, query kernel.BrowserProcessStatusParams, opts ...option.RequestOption) (*kernel.BrowserProcessStatusResponse, error) {
+		polls++
+		if polls == 1 {
+			return &kernel.BrowserProcessStatusResponse{State: kernel.BrowserProcessStatusResponseStateRunning, CPUPct: 12.5}, nil
+		}
+		return &kernel.BrowserProcessStatusResponse{State: kernel.BrowserProcessStatusResponseStateExited, ExitCode: 3}, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: fake}
+
+	var err error
+	rendered := captureStdout(t, func() {
+		err = b.ProcessStatus(context.Background(), BrowsersProcessStatusInput{Identifier: "id", ProcessID: "proc", Watch: true, Interval: time.Millisecond})
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, polls)
+	assert.Contains(t, rendered, "12.50")
+	assert.Contains(t, outBuf.String(), "Process proc exited with code 3")
 }
 
 func TestBrowsersProcessStdin_PrintsSuccess(t *testing.T) {
@@ -856,6 +1886,43 @@ func TestBrowsersProcessStdin_PrintsSuccess(t *testing.T) {
 	assert.Contains(t, out, "Wrote to stdin")
 }
 
+func TestBrowsersProcessStdin_EncodesDataAndFile(t *testing.T) {
+	setupStdoutCapture(t)
+	var got []string
+	fake := &FakeProcessService{StdinFunc: func(ctx context.Context, processID string, params kernel.BrowserProcessStdinParams, opts ...option.RequestOption) (*kernel.BrowserProcessStdinResponse, error) {
+		got = append(got, params.DataB64)
+		return &kernel.BrowserProcessStdinResponse{}, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: fake}
+	file := filepath.Join(t.TempDir(), "input.txt")
+	assert.NoError(t, os.WriteFile(file, []byte("from file\n"), 0o600))
+
+	assert.NoError(t, b.ProcessStdin(context.Background(), BrowsersProcessStdinInput{Identifier: "id", ProcessID: "proc", Data: "hi"}))
+	assert.NoError(t, b.ProcessStdin(context.Background(), BrowsersProcessStdinInput{Identifier: "id", ProcessID: "proc", File: file}))
+	assert.Equal(t, []string{"aGk=", base64.StdEncoding.EncodeToString([]byte("from file\n"))}, got)
+
+	outBuf.Reset()
+	_ = b.ProcessStdin(context.Background(), BrowsersProcessStdinInput{Identifier: "id", ProcessID: "proc", Data: "hi", DataB64: "aGk="})
+	assert.Contains(t, outBuf.String(), "exactly one of")
+	assert.Len(t, got, 2)
+}
+
+func TestBrowsersProcessStdin_Newline(t *testing.T) {
+	setupStdoutCapture(t)
+	var got string
+	fake := &FakeProcessService{StdinFunc: func(ctx context.Context, processID string, params kernel.BrowserProcessStdinParams, opts ...option.RequestOption) (*kernel.BrowserProcessStdinResponse, error) {
+		got = params.DataB64
+		return &kernel.BrowserProcessStdinResponse{}, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: fake}
+
+	assert.NoError(t, b.ProcessStdin(context.Background(), BrowsersProcessStdinInput{Identifier: "id", ProcessID: "proc", Data: "cmd", Newline: true}))
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("cmd\n")), got)
+
+	assert.NoError(t, b.ProcessStdin(context.Background(), BrowsersProcessStdinInput{Identifier: "id", ProcessID: "proc", DataB64: "Y21k", Newline: true}))
+	assert.Equal(t, "Y21k", got)
+}
+
 func TestBrowsersProcessStdoutStream_PrintsExit(t *testing.T) {
 	setupStdoutCapture(t)
 	fake := &FakeProcessService{}
@@ -866,6 +1933,64 @@ func TestBrowsersProcessStdoutStream_PrintsExit(t *testing.T) {
 	assert.Contains(t, out, "process exited with code 0")
 }
 
+func TestBrowsersProcessStdoutStream_TeesToFiles(t *testing.T) {
+	setupStdoutCapture(t)
+	enc := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	fake := &FakeProcessService{StdoutStreamFunc: func(ctx context.Context, processID string, query kernel.BrowserProcessStdoutStreamParams, opts ...option.RequestOption) *ssestream.Stream[kernel.BrowserProcessStdoutStreamResponse] {
+		return makeStream([]kernel.BrowserProcessStdoutStreamResponse{
+			{Stream: kernel.BrowserProcessStdoutStreamResponseStreamStdout, DataB64: enc("out1\n")},
+			{Stream: kernel.BrowserProcessStdoutStreamResponseStreamStderr, DataB64: enc("err1\n")},
+			{Stream: kernel.BrowserProcessStdoutStreamResponseStreamStdout, DataB64: enc("out2\n")},
+			{Event: "exit", ExitCode: 3},
+		})
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: fake}
+	dir := t.TempDir()
+	outPath, errPath := filepath.Join(dir, "out.log"), filepath.Join(dir, "err.log")
+
+	var err error
+	captureStdout(t, func() {
+		err = b.ProcessStdoutStream(context.Background(), BrowsersProcessStdoutStreamInput{Identifier: "id", ProcessID: "proc", OutputFile: outPath, StderrFile: errPath})
+	})
+	require.NoError(t, err)
+	out, _ := os.ReadFile(outPath)
+	errOut, _ := os.ReadFile(errPath)
+	assert.Equal(t, "out1\nout2\n", string(out))
+	assert.Equal(t, "err1\n", string(errOut))
+	assert.Contains(t, outBuf.String(), "process exited with code 3")
+}
+
+func TestBrowsersProcessSpawn_OutputFileFollowsUntilExit(t *testing.T) {
+	setupStdoutCapture(t)
+	enc := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	var streamed string
+	fake := &FakeProcessService{
+		SpawnFunc: func(ctx context.Context, id string, body kernel.BrowserProcessSpawnParams, opts ...option.RequestOption) (*kernel.BrowserProcessSpawnResponse, error) {
+			return &kernel.BrowserProcessSpawnResponse{ProcessID: "proc"}, nil
+		},
+		StdoutStreamFunc: func(ctx context.Context, processID string, query kernel.BrowserProcessStdoutStreamParams, opts ...option.RequestOption) *ssestream.Stream[kernel.BrowserProcessStdoutStreamResponse] {
+			streamed = processID
+			return makeStream([]kernel.BrowserProcessStdoutStreamResponse{
+				{Stream: kernel.BrowserProcessStdoutStreamResponseStreamStdout, DataB64: enc("done\n")},
+				{Stream: kernel.BrowserProcessStdoutStreamResponseStreamStderr, DataB64: enc("warn\n")},
+				{Event: "exit", ExitCode: 0},
+			})
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: fake}
+	path := filepath.Join(t.TempDir(), "spawn.log")
+
+	var err error
+	captureStdout(t, func() {
+		err = b.ProcessSpawn(context.Background(), BrowsersProcessSpawnInput{Identifier: "id", Command: "sh", OutputFile: path})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "proc", streamed)
+	saved, _ := os.ReadFile(path)
+	assert.Equal(t, "done\nwarn\n", string(saved))
+	assert.Contains(t, outBuf.String(), "process exited with code 0")
+}
+
 // --- Tests for FS ---
 
 func TestBrowsersFSNewDirectory_PrintsSuccess(t *testing.T) {
@@ -910,6 +2035,202 @@ func TestBrowsersFSDownloadDirZip_SavesFile(t *testing.T) {
 	assert.Equal(t, "zip", string(data))
 }
 
+func TestBrowsersFSDownloadDirZip_ExtractStripComponents(t *testing.T) {
+	setupStdoutCapture(t)
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range map[string]string{"downloads/": "", "downloads/a.txt": "A", "downloads/sub/b.txt": "B"} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, _ = w.Write([]byte(body))
+	}
+	require.NoError(t, zw.Close())
+
+	fake := &FakeFSService{DownloadDirZipFunc: func(ctx context.Context, id string, query kernel.BrowserFDownloadDirZipParams, opts ...option.RequestOption) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(buf.Bytes()))}, nil
+	}}
+	outDir := filepath.Join(t.TempDir(), "out")
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+	require.NoError(t, b.FSDownloadDirZip(context.Background(), BrowsersFSDownloadDirZipInput{Identifier: "id", Path: "/downloads", ExtractDir: outDir, StripComponents: 1}))
+
+	data, err := os.ReadFile(filepath.Join(outDir, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "A", string(data))
+	data, err = os.ReadFile(filepath.Join(outDir, "sub", "b.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "B", string(data))
+	assert.NoDirExists(t, filepath.Join(outDir, "downloads"))
+}
+
+func TestBrowsersFSDownloadDirZip_ExtractAndKeepZip(t *testing.T) {
+	setupStdoutCapture(t)
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("a.txt")
+	require.NoError(t, err)
+	_, _ = w.Write([]byte("A"))
+	require.NoError(t, zw.Close())
+
+	fake := &FakeFSService{DownloadDirZipFunc: func(ctx context.Context, id string, query kernel.BrowserFDownloadDirZipParams, opts ...option.RequestOption) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(buf.Bytes()))}, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+	dir := t.TempDir()
+	zipPath, outDir := filepath.Join(dir, "dl.zip"), filepath.Join(dir, "out")
+	require.NoError(t, b.FSDownloadDirZip(context.Background(), BrowsersFSDownloadDirZipInput{Identifier: "id", Path: "/dl", Output: zipPath, ExtractDir: outDir}))
+
+	saved, err := os.ReadFile(zipPath)
+	require.NoError(t, err)
+	assert.Equal(t, buf.Bytes(), saved)
+	data, err := os.ReadFile(filepath.Join(outDir, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "A", string(data))
+
+	// A non-empty target is refused before anything is downloaded.
+	fake.DownloadDirZipFunc = func(ctx context.Context, id string, query kernel.BrowserFDownloadDirZipParams, opts ...option.RequestOption) (*http.Response, error) {
+		t.Fatal("download should not start when the extract directory is not empty")
+		return nil, nil
+	}
+	require.NoError(t, b.FSDownloadDirZip(context.Background(), BrowsersFSDownloadDirZipInput{Identifier: "id", Path: "/dl", ExtractDir: outDir}))
+	assert.Contains(t, outBuf.String(), "output directory must be empty")
+}
+
+func TestBrowsersSSHConfig_Snippet(t *testing.T) {
+	fake := &FakeBrowsersService{GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserGetResponse, error) {
+		return &kernel.BrowserGetResponse{SessionID: "sess123"}, nil
+	}}
+	b := BrowsersCmd{browsers: fake}
+	out := captureStdout(t, func() {
+		require.NoError(t, b.SSHConfig(context.Background(), BrowsersSSHConfigInput{Identifier: "sess123", User: "root", Port: 22, CLIPath: "/opt/my tools/kernel"}))
+	})
+	assert.Contains(t, out, "Host kernel-sess123\n")
+	assert.NotContains(t, out, "%!")
+	m := regexp.MustCompile(`(?m)^  ProxyCommand "/opt/my tools/kernel" (.+)$`).FindStringSubmatch(out)
+	require.Len(t, m, 2)
+
+	// The ProxyCommand must resolve to a real command whose flags parse, and
+	// that command must pipe stdin/stdout rather than print a summary.
+	cmd, rest, err := rootCmd.Find(strings.Fields(strings.ReplaceAll(m[1], "%p", "22")))
+	require.NoError(t, err)
+	require.Equal(t, "kernel browsers process spawn", cmd.CommandPath())
+	t.Cleanup(func() {
+		cmd.Flags().Visit(func(f *pflag.Flag) {
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		})
+	})
+	require.NoError(t, cmd.ParseFlags(rest))
+	attach, _ := cmd.Flags().GetBool("attach")
+	asRoot, _ := cmd.Flags().GetBool("as-root")
+	assert.True(t, attach)
+	assert.True(t, asRoot)
+	assert.Equal(t, []string{"sess123", "nc", "localhost", "22"}, cmd.Flags().Args())
+}
+
+func TestBrowsersProcessAttach_PipesStdinAndOutput(t *testing.T) {
+	enc := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	sent := make(chan kernel.BrowserProcessStdinParams, 1)
+	fake := &FakeProcessService{
+		StdinFunc: func(ctx context.Context, processID string, params kernel.BrowserProcessStdinParams, opts ...option.RequestOption) (*kernel.BrowserProcessStdinResponse, error) {
+			sent <- params
+			return &kernel.BrowserProcessStdinResponse{}, nil
+		},
+		StdoutStreamFunc: func(ctx context.Context, processID string, query kernel.BrowserProcessStdoutStreamParams, opts ...option.RequestOption) *ssestream.Stream[kernel.BrowserProcessStdoutStreamResponse] {
+			return makeStream([]kernel.BrowserProcessStdoutStreamResponse{
+				{Stream: kernel.BrowserProcessStdoutStreamResponseStreamStdout, DataB64: enc("SSH-2.0-OpenSSH\r\n")},
+				{Stream: kernel.BrowserProcessStdoutStreamResponseStreamStderr, DataB64: enc("warn")},
+				{Event: "exit", ExitCode: 0},
+			})
+		},
+	}
+	b := BrowsersCmd{process: fake}
+	var stdout, stderr bytes.Buffer
+
+	err := b.attachProcess(context.Background(), "sess", "proc", strings.NewReader("SSH-2.0-client\r\n"), &stdout, &stderr)
+	require.NoError(t, err)
+	assert.Equal(t, "SSH-2.0-OpenSSH\r\n", stdout.String())
+	assert.Equal(t, "warn", stderr.String())
+	select {
+	case params := <-sent:
+		assert.Equal(t, "sess", params.ID)
+		assert.Equal(t, enc("SSH-2.0-client\r\n"), params.DataB64)
+	case <-time.After(time.Second):
+		t.Fatal("stdin was not forwarded to the process")
+	}
+}
+
+func TestBrowsersProcessAttach_ReportsExitCode(t *testing.T) {
+	fake := &FakeProcessService{
+		StdoutStreamFunc: func(ctx context.Context, processID string, query kernel.BrowserProcessStdoutStreamParams, opts ...option.RequestOption) *ssestream.Stream[kernel.BrowserProcessStdoutStreamResponse] {
+			return makeStream([]kernel.BrowserProcessStdoutStreamResponse{{Event: "exit", ExitCode: 1}})
+		},
+	}
+	b := BrowsersCmd{process: fake}
+	err := b.attachProcess(context.Background(), "sess", "proc", strings.NewReader(""), io.Discard, io.Discard)
+	assert.EqualError(t, err, "process exited with code 1")
+}
+
+func TestParseCpPath(t *testing.T) {
+	tests := []struct {
+		arg        string
+		id, path   string
+		wantRemote bool
+	}{
+		{arg: "sess123:/tmp/a.txt", id: "sess123", path: "/tmp/a.txt", wantRemote: true},
+		{arg: "@last:/tmp", id: "@last", path: "/tmp", wantRemote: true},
+		{arg: "./local.txt", path: "./local.txt"},
+		{arg: "/abs/with:colon", path: "/abs/with:colon"},
+		{arg: `C:\Users\me`, path: `C:\Users\me`},
+		{arg: "C:/Users/me", path: "C:/Users/me"},
+		{arg: "sess123:relative", path: "sess123:relative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			id, path, remote := parseCpPath(tt.arg)
+			assert.Equal(t, tt.wantRemote, remote)
+			assert.Equal(t, tt.id, id)
+			assert.Equal(t, tt.path, path)
+		})
+	}
+}
+
+func TestBrowsersCp_DownloadsFile(t *testing.T) {
+	setupStdoutCapture(t)
+	dir := t.TempDir()
+	var readPath string
+	fake := &FakeFSService{ReadFileFunc: func(ctx context.Context, id string, query kernel.BrowserFReadFileParams, opts ...option.RequestOption) (*http.Response, error) {
+		readPath = query.Path
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("content"))}, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+	require.NoError(t, b.Cp(context.Background(), BrowsersCpInput{Source: "sess123:/tmp/report.txt", Dest: dir}))
+
+	assert.Equal(t, "/tmp/report.txt", readPath)
+	data, err := os.ReadFile(filepath.Join(dir, "report.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+}
+
+func TestBrowsersCp_UploadsDirectoryAsZip(t *testing.T) {
+	setupStdoutCapture(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("A"), 0o644))
+	var dest string
+	fake := &FakeFSService{
+		UploadZipFunc: func(ctx context.Context, id string, body kernel.BrowserFUploadZipParams, opts ...option.RequestOption) error {
+			dest = body.DestPath
+			return nil
+		},
+		WriteFileFunc: func(ctx context.Context, id string, contents io.Reader, body kernel.BrowserFWriteFileParams, opts ...option.RequestOption) error {
+			t.Fatal("directories should be uploaded as a zip")
+			return nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+	require.NoError(t, b.Cp(context.Background(), BrowsersCpInput{Source: dir, Dest: "sess123:/tmp/fixtures"}))
+	assert.Equal(t, "/tmp/fixtures", dest)
+}
+
 func TestBrowsersFSFileInfo_PrintsFields(t *testing.T) {
 	setupStdoutCapture(t)
 	fake := &FakeFSService{FileInfoFunc: func(ctx context.Context, id string, query kernel.BrowserFFileInfoParams, opts ...option.RequestOption) (*kernel.BrowserFFileInfoResponse, error) {
@@ -934,6 +2255,43 @@ func TestBrowsersFSListFiles_PrintsRows(t *testing.T) {
 	assert.Contains(t, out, "/f1")
 }
 
+func TestBrowsersFSListFiles_Recursive(t *testing.T) {
+	setupStdoutCapture(t)
+	dir := func(p string) kernel.BrowserFListFilesResponse {
+		return kernel.BrowserFListFilesResponse{Path: p, Name: filepath.Base(p), IsDir: true, Mode: "drwxr-xr-x"}
+	}
+	file := func(p string) kernel.BrowserFListFilesResponse {
+		return kernel.BrowserFListFilesResponse{Path: p, Name: filepath.Base(p), Mode: "-rw-r--r--", SizeBytes: 3}
+	}
+	tree := map[string][]kernel.BrowserFListFilesResponse{
+		"/":      {dir("/a"), file("/top.txt")},
+		"/a":     {dir("/a/b"), file("/a/x.txt")},
+		"/a/b":   {dir("/a/b/c")},
+		"/a/b/c": {file("/a/b/c/deep.txt")},
+	}
+	var listed []string
+	fake := &FakeFSService{ListFilesFunc: func(ctx context.Context, id string, query kernel.BrowserFListFilesParams, opts ...option.RequestOption) (*[]kernel.BrowserFListFilesResponse, error) {
+		listed = append(listed, query.Path)
+		entries := tree[query.Path]
+		return &entries, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+
+	require.NoError(t, b.FSListFiles(context.Background(), BrowsersFSListFilesInput{Identifier: "id", Path: "/", Recursive: true, MaxDepth: 2}))
+	out := outBuf.String()
+	assert.Equal(t, []string{"/", "/a"}, listed)
+	assert.Contains(t, out, "/a/x.txt")
+	assert.Contains(t, out, "/a/b/")
+	assert.NotContains(t, out, "/a/b/c")
+	assert.Contains(t, out, "Stopped at --max-depth 2")
+
+	outBuf.Reset()
+	listed = nil
+	require.NoError(t, b.FSListFiles(context.Background(), BrowsersFSListFilesInput{Identifier: "id", Path: "/", Recursive: true}))
+	assert.Contains(t, outBuf.String(), "/a/b/c/deep.txt")
+	assert.NotContains(t, outBuf.String(), "Stopped at")
+}
+
 func TestBrowsersFSMove_PrintsSuccess(t *testing.T) {
 	setupStdoutCapture(t)
 	fake := &FakeFSService{}
@@ -944,6 +2302,38 @@ func TestBrowsersFSMove_PrintsSuccess(t *testing.T) {
 	assert.Contains(t, out, "Moved /a -> /b")
 }
 
+func TestBrowsersFSMove_NoOverwrite(t *testing.T) {
+	setupStdoutCapture(t)
+	destExists := false
+	moved := 0
+	fake := &FakeFSService{
+		FileInfoFunc: func(ctx context.Context, id string, query kernel.BrowserFFileInfoParams, opts ...option.RequestOption) (*kernel.BrowserFFileInfoResponse, error) {
+			if !destExists {
+				return nil, &kernel.Error{StatusCode: http.StatusNotFound}
+			}
+			return &kernel.BrowserFFileInfoResponse{Path: query.Path}, nil
+		},
+		MoveFunc: func(ctx context.Context, id string, body kernel.BrowserFMoveParams, opts ...option.RequestOption) error {
+			moved++
+			return nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+	in := BrowsersFSMoveInput{Identifier: "id", SrcPath: "/a", DestPath: "/b", NoOverwrite: true}
+
+	require.NoError(t, b.FSMove(context.Background(), in))
+	assert.Equal(t, 1, moved)
+
+	destExists = true
+	err := b.FSMove(context.Background(), in)
+	assert.EqualError(t, err, "destination /b already exists; use --force to overwrite it")
+	assert.Equal(t, 1, moved)
+
+	in.Force = true
+	require.NoError(t, b.FSMove(context.Background(), in))
+	assert.Equal(t, 2, moved)
+}
+
 func TestBrowsersFSReadFile_SavesFile(t *testing.T) {
 	dir := t.TempDir()
 	outPath := filepath.Join(dir, "file.txt")
@@ -956,27 +2346,213 @@ func TestBrowsersFSReadFile_SavesFile(t *testing.T) {
 	assert.Equal(t, "content", string(data))
 }
 
-func TestBrowsersFSSetPermissions_PrintsSuccess(t *testing.T) {
+func TestBrowsersFSReadFile_StdoutIsUndecorated(t *testing.T) {
 	setupStdoutCapture(t)
-	fake := &FakeFSService{}
-	fakeBrowsers := newFakeBrowsersServiceWithSimpleGet()
-	b := BrowsersCmd{browsers: fakeBrowsers, fs: fake}
-	_ = b.FSSetPermissions(context.Background(), BrowsersFSSetPermsInput{Identifier: "id", Path: "/tmp/a", Mode: "644"})
-	out := outBuf.String()
-	assert.Contains(t, out, "Updated permissions for /tmp/a")
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: &FakeFSService{}}
+	out := captureStdout(t, func() {
+		require.NoError(t, b.FSReadFile(context.Background(), BrowsersFSReadFileInput{Identifier: "id", Path: "/tmp/x"}))
+	})
+	assert.Equal(t, "content", out)
+	assert.Empty(t, outBuf.String())
 }
 
-func TestBrowsersFSUpload_MappingAndDestDir_Success(t *testing.T) {
-	setupStdoutCapture(t)
-	var captured kernel.BrowserFUploadParams
-	fake := &FakeFSService{UploadFunc: func(ctx context.Context, id string, body kernel.BrowserFUploadParams, opts ...option.RequestOption) error {
-		captured = body
-		return nil
+func TestBrowsersFSChecksum_SHA256(t *testing.T) {
+	fake := &FakeFSService{ReadFileFunc: func(ctx context.Context, id string, query kernel.BrowserFReadFileParams, opts ...option.RequestOption) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("hello world"))}, nil
 	}}
-	fakeBrowsers := newFakeBrowsersServiceWithSimpleGet()
-	b := BrowsersCmd{browsers: fakeBrowsers, fs: fake}
-	in := BrowsersFSUploadInput{Identifier: "id", Mappings: []struct {
-		Local string
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+	out := captureStdout(t, func() {
+		err := b.FSChecksum(context.Background(), BrowsersFSChecksumInput{Identifier: "id", Path: "/app/bundle.zip", Algo: "sha256"})
+		assert.NoError(t, err)
+	})
+	assert.Equal(t, "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9  /app/bundle.zip\n", out)
+}
+
+func TestBrowsersFSGrep_PrintsPrefixedMatches(t *testing.T) {
+	setupStdoutCapture(t)
+	contents := map[string]string{
+		"/app/config.env":    "DEBUG=1\nAPI_KEY=abc\n",
+		"/app/readme.md":     "nothing here\n",
+		"/app/big.bin":       "API_KEY=too-big",
+		"/app/sub/extra.txt": "first\nsecond API_KEY\n",
+	}
+	fake := &FakeFSService{
+		ListFilesFunc: func(ctx context.Context, id string, query kernel.BrowserFListFilesParams, opts ...option.RequestOption) (*[]kernel.BrowserFListFilesResponse, error) {
+			var files []kernel.BrowserFListFilesResponse
+			switch query.Path {
+			case "/app":
+				files = []kernel.BrowserFListFilesResponse{
+					{Name: "config.env", Path: "/app/config.env", SizeBytes: 20},
+					{Name: "readme.md", Path: "/app/readme.md", SizeBytes: 13},
+					{Name: "big.bin", Path: "/app/big.bin", SizeBytes: 10 << 20},
+					{Name: "sub", Path: "/app/sub", IsDir: true},
+				}
+			case "/app/sub":
+				files = []kernel.BrowserFListFilesResponse{{Name: "extra.txt", Path: "/app/sub/extra.txt", SizeBytes: 20}}
+			}
+			return &files, nil
+		},
+		ReadFileFunc: func(ctx context.Context, id string, query kernel.BrowserFReadFileParams, opts ...option.RequestOption) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(contents[query.Path]))}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+
+	err := b.FSGrep(context.Background(), BrowsersFSGrepInput{Identifier: "id", Path: "/app", Pattern: "API_KEY", MaxFileSize: 1 << 20})
+	assert.NoError(t, err)
+	out := outBuf.String()
+	assert.Contains(t, out, "/app/config.env:2:API_KEY=abc")
+	assert.NotContains(t, out, "too-big")
+	assert.NotContains(t, out, "extra.txt")
+
+	outBuf.Reset()
+	err = b.FSGrep(context.Background(), BrowsersFSGrepInput{Identifier: "id", Path: "/app", Pattern: "API_KEY", Recursive: true, MaxFileSize: 1 << 20})
+	assert.NoError(t, err)
+	assert.Contains(t, outBuf.String(), "/app/sub/extra.txt:2:second API_KEY")
+}
+
+func TestBrowsersFSGrep_InvalidPattern(t *testing.T) {
+	setupStdoutCapture(t)
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: &FakeFSService{}}
+	_ = b.FSGrep(context.Background(), BrowsersFSGrepInput{Identifier: "id", Path: "/", Pattern: "("})
+	assert.Contains(t, outBuf.String(), "Invalid pattern")
+}
+
+func TestBrowsersFSStat_MixedResults(t *testing.T) {
+	fake := &FakeFSService{
+		FileInfoFunc: func(ctx context.Context, id string, query kernel.BrowserFFileInfoParams, opts ...option.RequestOption) (*kernel.BrowserFFileInfoResponse, error) {
+			switch query.Path {
+			case "/app/index.js":
+				return &kernel.BrowserFFileInfoResponse{Path: query.Path, SizeBytes: 42, Mode: "-rw-r--r--"}, nil
+			case "/app":
+				return &kernel.BrowserFFileInfoResponse{Path: query.Path, IsDir: true, Mode: "drwxr-xr-x"}, nil
+			}
+			return nil, &kernel.Error{StatusCode: http.StatusNotFound}
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+	pathsFile := filepath.Join(t.TempDir(), "paths.txt")
+	assert.NoError(t, os.WriteFile(pathsFile, []byte("# deploy check\n/app\n\n"), 0o600))
+
+	out := captureStdout(t, func() {
+		err := b.FSStat(context.Background(), BrowsersFSStatInput{Identifier: "id", Paths: []string{"/app/index.js", "/app/missing.js"}, PathsFile: pathsFile, Concurrency: 2, Output: "json"})
+		assert.NoError(t, err)
+	})
+	var got []fsStatResult
+	assert.NoError(t, json.Unmarshal([]byte(out), &got), out)
+	assert.Equal(t, []fsStatResult{
+		{Path: "/app/index.js", Exists: true, SizeBytes: 42, Mode: "-rw-r--r--"},
+		{Path: "/app/missing.js", Exists: false},
+		{Path: "/app", Exists: true, IsDir: true, Mode: "drwxr-xr-x"},
+	}, got)
+
+	setupStdoutCapture(t)
+	err := b.FSStat(context.Background(), BrowsersFSStatInput{Identifier: "id", Paths: []string{"/app/index.js", "/app/missing.js"}, Concurrency: 2})
+	assert.NoError(t, err)
+	assert.Contains(t, outBuf.String(), "/app/missing.js")
+	assert.Contains(t, outBuf.String(), "false")
+}
+
+func stubEditor(t *testing.T, edit func(path string) error) {
+	orig := openEditor
+	openEditor = edit
+	t.Cleanup(func() { openEditor = orig })
+}
+
+func newEditFakeFS(content string, written *string, writeParams *kernel.BrowserFWriteFileParams) *FakeFSService {
+	return &FakeFSService{
+		ReadFileFunc: func(ctx context.Context, id string, query kernel.BrowserFReadFileParams, opts ...option.RequestOption) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(content))}, nil
+		},
+		FileInfoFunc: func(ctx context.Context, id string, query kernel.BrowserFFileInfoParams, opts ...option.RequestOption) (*kernel.BrowserFFileInfoResponse, error) {
+			return &kernel.BrowserFFileInfoResponse{Path: query.Path, Mode: "-rwxr-x---"}, nil
+		},
+		WriteFileFunc: func(ctx context.Context, id string, contents io.Reader, body kernel.BrowserFWriteFileParams, opts ...option.RequestOption) error {
+			data, _ := io.ReadAll(contents)
+			*written = string(data)
+			*writeParams = body
+			return nil
+		},
+	}
+}
+
+func TestBrowsersFSEdit_UploadsChangedContents(t *testing.T) {
+	setupStdoutCapture(t)
+	stubEditor(t, func(path string) error {
+		return os.WriteFile(path, []byte(`{"debug": true}`), 0600)
+	})
+	var written string
+	var params kernel.BrowserFWriteFileParams
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: newEditFakeFS(`{"debug": false}`, &written, &params)}
+
+	err := b.FSEdit(context.Background(), BrowsersFSEditInput{Identifier: "id", Path: "/app/config.json"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"debug": true}`, written)
+	assert.Equal(t, "/app/config.json", params.Path)
+	assert.Equal(t, "750", params.Mode.Value)
+	assert.Contains(t, outBuf.String(), "Saved changes to /app/config.json")
+}
+
+func TestBrowsersFSEdit_NoChangesSkipsUpload(t *testing.T) {
+	setupStdoutCapture(t)
+	stubEditor(t, func(path string) error { return nil })
+	var written string
+	var params kernel.BrowserFWriteFileParams
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: newEditFakeFS("unchanged", &written, &params)}
+
+	err := b.FSEdit(context.Background(), BrowsersFSEditInput{Identifier: "id", Path: "/app/notes.txt"})
+	assert.NoError(t, err)
+	assert.Empty(t, written)
+	assert.Contains(t, outBuf.String(), "No changes made")
+}
+
+func TestBrowsersFSEdit_EditorAbortSkipsUpload(t *testing.T) {
+	setupStdoutCapture(t)
+	stubEditor(t, func(path string) error {
+		_ = os.WriteFile(path, []byte("half-edited"), 0600)
+		return errors.New("exit status 1")
+	})
+	var written string
+	var params kernel.BrowserFWriteFileParams
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: newEditFakeFS("original", &written, &params)}
+
+	err := b.FSEdit(context.Background(), BrowsersFSEditInput{Identifier: "id", Path: "/app/notes.txt"})
+	assert.NoError(t, err)
+	assert.Empty(t, written)
+	assert.Contains(t, outBuf.String(), "leaving /app/notes.txt unchanged")
+}
+
+func TestSymbolicModeToOctal(t *testing.T) {
+	for in, want := range map[string]string{"-rw-r--r--": "644", "-rwxr-xr-x": "755", "-rw-------": "600"} {
+		got, ok := symbolicModeToOctal(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, ok := symbolicModeToOctal("-rwsr-xr-x")
+	assert.False(t, ok)
+}
+
+func TestBrowsersFSSetPermissions_PrintsSuccess(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := &FakeFSService{}
+	fakeBrowsers := newFakeBrowsersServiceWithSimpleGet()
+	b := BrowsersCmd{browsers: fakeBrowsers, fs: fake}
+	_ = b.FSSetPermissions(context.Background(), BrowsersFSSetPermsInput{Identifier: "id", Path: "/tmp/a", Mode: "644"})
+	out := outBuf.String()
+	assert.Contains(t, out, "Updated permissions for /tmp/a")
+}
+
+func TestBrowsersFSUpload_MappingAndDestDir_Success(t *testing.T) {
+	setupStdoutCapture(t)
+	var captured kernel.BrowserFUploadParams
+	fake := &FakeFSService{UploadFunc: func(ctx context.Context, id string, body kernel.BrowserFUploadParams, opts ...option.RequestOption) error {
+		captured = body
+		return nil
+	}}
+	fakeBrowsers := newFakeBrowsersServiceWithSimpleGet()
+	b := BrowsersCmd{browsers: fakeBrowsers, fs: fake}
+	in := BrowsersFSUploadInput{Identifier: "id", Mappings: []struct {
+		Local string
 		Dest  string
 	}{{Local: __writeTempFile(t, "a"), Dest: "/remote/a"}}, DestDir: "/remote/dir", Paths: []string{__writeTempFile(t, "b")}}
 	_ = b.FSUpload(context.Background(), in)
@@ -985,6 +2561,188 @@ func TestBrowsersFSUpload_MappingAndDestDir_Success(t *testing.T) {
 	assert.Equal(t, 2, len(captured.Files))
 }
 
+func TestBrowsersFSUpload_Manifest(t *testing.T) {
+	setupStdoutCapture(t)
+	var captured kernel.BrowserFUploadParams
+	fake := &FakeFSService{UploadFunc: func(ctx context.Context, id string, body kernel.BrowserFUploadParams, opts ...option.RequestOption) error {
+		captured = body
+		return nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0600))
+	abs := __writeTempFile(t, "b")
+	manifest := filepath.Join(dir, "upload.json")
+	require.NoError(t, os.WriteFile(manifest, []byte(`[{"local":"a.txt","dest":"/remote/a.txt"},{"local":"`+abs+`","dest":"/remote/b.txt"}]`), 0600))
+
+	err := b.FSUpload(context.Background(), BrowsersFSUploadInput{Identifier: "id", Manifest: manifest})
+	assert.NoError(t, err)
+	require.Len(t, captured.Files, 2)
+	assert.Equal(t, "/remote/a.txt", captured.Files[0].DestPath)
+	assert.Equal(t, "/remote/b.txt", captured.Files[1].DestPath)
+}
+
+func TestBrowsersFSUpload_ManifestMissingLocalFile(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := &FakeFSService{UploadFunc: func(ctx context.Context, id string, body kernel.BrowserFUploadParams, opts ...option.RequestOption) error {
+		t.Fatal("Upload should not be called when a local file is missing")
+		return nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+
+	manifest := filepath.Join(t.TempDir(), "upload.csv")
+	require.NoError(t, os.WriteFile(manifest, []byte("local,dest\nmissing.txt,/remote/missing.txt\n"), 0600))
+
+	err := b.FSUpload(context.Background(), BrowsersFSUploadInput{Identifier: "id", Manifest: manifest})
+	assert.NoError(t, err)
+	assert.Contains(t, outBuf.String(), "Local files not found")
+	assert.Contains(t, outBuf.String(), "missing.txt")
+}
+
+func TestBrowsersFSUpload_ExplicitContentType(t *testing.T) {
+	setupStdoutCapture(t)
+	var captured kernel.BrowserFUploadParams
+	fake := &FakeFSService{UploadFunc: func(ctx context.Context, id string, body kernel.BrowserFUploadParams, opts ...option.RequestOption) error {
+		captured = body
+		return nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+
+	err := b.FSUpload(context.Background(), BrowsersFSUploadInput{Identifier: "id", DestDir: "/remote", Paths: []string{__writeTempFile(t, "a,b\n")}, ContentType: "text/csv"})
+	assert.NoError(t, err)
+	require.Len(t, captured.Files, 1)
+	typed, ok := captured.Files[0].File.(interface{ ContentType() string })
+	require.True(t, ok)
+	assert.Equal(t, "text/csv", typed.ContentType())
+}
+
+// fakeUploadStore returns an FS fake that keeps uploaded files in memory and
+// serves them back through ReadFile, after passing them through mangle.
+func fakeUploadStore(t *testing.T, mangle func(string) string) *FakeFSService {
+	stored := map[string]string{}
+	return &FakeFSService{
+		UploadFunc: func(ctx context.Context, id string, body kernel.BrowserFUploadParams, opts ...option.RequestOption) error {
+			for _, f := range body.Files {
+				data, err := io.ReadAll(f.File)
+				require.NoError(t, err)
+				stored[f.DestPath] = mangle(string(data))
+			}
+			return nil
+		},
+		ReadFileFunc: func(ctx context.Context, id string, query kernel.BrowserFReadFileParams, opts ...option.RequestOption) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(stored[query.Path]))}, nil
+		},
+	}
+}
+
+func TestBrowsersFSUpload_VerifyPasses(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := fakeUploadStore(t, func(s string) string { return s })
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+
+	err := b.FSUpload(context.Background(), BrowsersFSUploadInput{Identifier: "id", DestDir: "/remote", Paths: []string{__writeTempFile(t, "payload")}, Verify: true})
+	assert.NoError(t, err)
+	assert.Contains(t, outBuf.String(), "Verified 1 file")
+}
+
+func TestBrowsersFSUpload_VerifyDetectsMismatch(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := fakeUploadStore(t, func(s string) string { return s[:len(s)/2] })
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+
+	err := b.FSUpload(context.Background(), BrowsersFSUploadInput{Identifier: "id", DestDir: "/remote", Paths: []string{__writeTempFile(t, "payload")}, Verify: true})
+	assert.ErrorContains(t, err, "1 of 1 uploaded files failed verification")
+	assert.Contains(t, outBuf.String(), "Checksum mismatch for /remote/")
+}
+
+func TestBrowsersFSUpload_Recursive(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := fakeUploadStore(t, func(s string) string { return s })
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sub", "deep"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "top.txt"), []byte("top"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sub", "deep", "leaf.txt"), []byte("leaf"), 0o600))
+	outside := __writeTempFile(t, "linked")
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "link.txt")))
+
+	err := b.FSUpload(context.Background(), BrowsersFSUploadInput{Identifier: "id", DestDir: "/remote", Recursive: root, Verify: true})
+	require.NoError(t, err)
+	out := outBuf.String()
+	assert.Contains(t, out, "Queued 2 files for upload")
+	assert.Contains(t, out, "Verified 2 files")
+
+	pairs, err := collectUploadTree(root, "/remote", true)
+	require.NoError(t, err)
+	var dests []string
+	for _, p := range pairs {
+		dests = append(dests, p[1])
+	}
+	assert.ElementsMatch(t, []string{"/remote/top.txt", "/remote/link.txt", "/remote/sub/deep/leaf.txt"}, dests)
+}
+
+func TestBrowsersFSUpload_GlobPaths(t *testing.T) {
+	setupStdoutCapture(t)
+	var captured kernel.BrowserFUploadParams
+	fake := &FakeFSService{UploadFunc: func(ctx context.Context, id string, body kernel.BrowserFUploadParams, opts ...option.RequestOption) error {
+		captured = body
+		return nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+
+	dir := t.TempDir()
+	for _, name := range []string{"app.js", "vendor.js", "style.css", "index.html"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600))
+	}
+	// The explicit app.js duplicates a glob match and must only be sent once.
+	paths := []string{filepath.Join(dir, "*.js"), filepath.Join(dir, "app.js")}
+	err := b.FSUpload(context.Background(), BrowsersFSUploadInput{Identifier: "id", DestDir: "/remote", Paths: paths})
+	require.NoError(t, err)
+	var dests []string
+	for _, f := range captured.Files {
+		dests = append(dests, f.DestPath)
+	}
+	assert.Equal(t, []string{"/remote/app.js", "/remote/vendor.js"}, dests)
+}
+
+func TestBrowsersFSUpload_GlobMatchesNothing(t *testing.T) {
+	setupStdoutCapture(t)
+	calls := 0
+	fake := &FakeFSService{UploadFunc: func(ctx context.Context, id string, body kernel.BrowserFUploadParams, opts ...option.RequestOption) error {
+		calls++
+		return nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "a.txt")
+	require.NoError(t, os.WriteFile(existing, []byte("a"), 0o600))
+	paths := []string{filepath.Join(dir, "*.js"), existing}
+
+	require.NoError(t, b.FSUpload(context.Background(), BrowsersFSUploadInput{Identifier: "id", DestDir: "/remote", Paths: paths}))
+	assert.Contains(t, outBuf.String(), "matched no files")
+	assert.Equal(t, 0, calls)
+
+	require.NoError(t, b.FSUpload(context.Background(), BrowsersFSUploadInput{Identifier: "id", DestDir: "/remote", Paths: paths, AllowEmptyGlob: true}))
+	assert.Equal(t, 1, calls)
+}
+
+func TestSniffContentType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	contentType, r, err := sniffContentType(bytes.NewReader(png), "")
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+	rest, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, png, rest)
+
+	contentType, _, err = sniffContentType(bytes.NewReader(png), "application/x-custom")
+	require.NoError(t, err)
+	assert.Equal(t, "application/x-custom", contentType)
+}
+
 func TestBrowsersFSUploadZip_Success(t *testing.T) {
 	setupStdoutCapture(t)
 	z := __writeTempFile(t, "zipdata")
@@ -1012,6 +2770,32 @@ func TestBrowsersFSWriteFile_FromBase64_And_FromInput(t *testing.T) {
 	assert.Contains(t, out, "Wrote file to /y")
 }
 
+func TestBrowsersFSWriteFile_InlineContent(t *testing.T) {
+	setupStdoutCapture(t)
+	var written string
+	var params kernel.BrowserFWriteFileParams
+	fake := &FakeFSService{WriteFileFunc: func(ctx context.Context, id string, contents io.Reader, body kernel.BrowserFWriteFileParams, opts ...option.RequestOption) error {
+		data, err := io.ReadAll(contents)
+		require.NoError(t, err)
+		written, params = string(data), body
+		return nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), fs: fake}
+
+	err := b.FSWriteFile(context.Background(), BrowsersFSWriteFileInput{Identifier: "id", DestPath: "/etc/motd", Content: "hi there\n", HasContent: true, Mode: "600"})
+	require.NoError(t, err)
+	assert.Equal(t, "hi there\n", written)
+	assert.Equal(t, "600", params.Mode.Value)
+	assert.Contains(t, outBuf.String(), "Wrote file to /etc/motd")
+
+	outBuf.Reset()
+	written = "untouched"
+	err = b.FSWriteFile(context.Background(), BrowsersFSWriteFileInput{Identifier: "id", DestPath: "/etc/motd", Content: "x", HasContent: true, SourcePath: "local.txt"})
+	require.NoError(t, err)
+	assert.Equal(t, "untouched", written)
+	assert.Contains(t, outBuf.String(), "specify exactly one of --source or --content")
+}
+
 // helper to create temp file with contents
 func __writeTempFile(t *testing.T, data string) string {
 	t.Helper()
@@ -1060,6 +2844,156 @@ func TestBrowsersComputerScreenshot_SavesFile(t *testing.T) {
 	assert.Equal(t, "pngDATA", string(data))
 }
 
+func TestBrowsersCreate_ExtensionFromDirUploadsAndLoads(t *testing.T) {
+	setupStdoutCapture(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(`{"manifest_version":3}`), 0o644))
+
+	uploads := 0
+	exts := &FakeExtensionsService{UploadFunc: func(ctx context.Context, body kernel.ExtensionUploadParams, opts ...option.RequestOption) (*kernel.ExtensionUploadResponse, error) {
+		uploads++
+		return &kernel.ExtensionUploadResponse{ID: "ext_1", Name: "my-ext"}, nil
+	}}
+	var got kernel.BrowserNewParams
+	fakeBrowsers := &FakeBrowsersService{NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+		got = body
+		return &kernel.BrowserNewResponse{SessionID: "sess"}, nil
+	}}
+	b := BrowsersCmd{browsers: fakeBrowsers, extensions: exts}
+
+	err := b.Create(context.Background(), BrowsersCreateInput{ExtensionDirs: []string{dir}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, uploads)
+	require.Len(t, got.Extensions, 1)
+	assert.Equal(t, "my-ext", got.Extensions[0].Name.Value)
+}
+
+func TestBrowsersCreate_ExtensionFromDirCleansUpOnFailure(t *testing.T) {
+	setupStdoutCapture(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(`{}`), 0o644))
+
+	var deleted []string
+	exts := &FakeExtensionsService{
+		UploadFunc: func(ctx context.Context, body kernel.ExtensionUploadParams, opts ...option.RequestOption) (*kernel.ExtensionUploadResponse, error) {
+			return &kernel.ExtensionUploadResponse{ID: "ext_1"}, nil
+		},
+		DeleteFunc: func(ctx context.Context, idOrName string, opts ...option.RequestOption) error {
+			deleted = append(deleted, idOrName)
+			return nil
+		},
+	}
+	fakeBrowsers := &FakeBrowsersService{NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+		assert.Equal(t, "ext_1", body.Extensions[0].ID.Value)
+		return nil, errors.New("boom")
+	}}
+	b := BrowsersCmd{browsers: fakeBrowsers, extensions: exts}
+
+	err := b.Create(context.Background(), BrowsersCreateInput{ExtensionDirs: []string{dir}})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"ext_1"}, deleted)
+}
+
+func TestBrowsersCreate_ScreenshotOnCreate(t *testing.T) {
+	setupStdoutCapture(t)
+	outPath := filepath.Join(t.TempDir(), "initial.png")
+	var steps []string
+	fakeBrowsers := &FakeBrowsersService{
+		NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+			steps = append(steps, "create")
+			return &kernel.BrowserNewResponse{SessionID: "sess-new"}, nil
+		},
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserGetResponse, error) {
+			return &kernel.BrowserGetResponse{SessionID: id}, nil
+		},
+	}
+	fakePW := &FakePlaywrightService{ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+		steps = append(steps, "ready")
+		return &kernel.BrowserPlaywrightExecuteResponse{Success: true}, nil
+	}}
+	fakeComp := &FakeComputerService{CaptureScreenshotFunc: func(ctx context.Context, id string, body kernel.BrowserComputerCaptureScreenshotParams, opts ...option.RequestOption) (*http.Response, error) {
+		steps = append(steps, "screenshot:"+id)
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("pngDATA"))}, nil
+	}}
+	b := BrowsersCmd{browsers: fakeBrowsers, computer: fakeComp, playwright: fakePW}
+
+	err := b.Create(context.Background(), BrowsersCreateInput{Headless: BoolFlag{Set: true, Value: true}, ScreenshotOnCreate: outPath})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"create", "ready", "screenshot:sess-new"}, steps)
+	data, err := os.ReadFile(outPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "pngDATA", string(data))
+}
+
+func TestBrowsersComputerScreenshot_FullPageUsesPlaywright(t *testing.T) {
+	setupStdoutCapture(t)
+	outPath := filepath.Join(t.TempDir(), "page.png")
+	var code string
+	fakePW := &FakePlaywrightService{ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+		code = body.Code
+		return &kernel.BrowserPlaywrightExecuteResponse{Success: true, Result: base64.StdEncoding.EncodeToString([]byte("fullPNG"))}, nil
+	}}
+	fakeComp := &FakeComputerService{CaptureScreenshotFunc: func(ctx context.Context, id string, body kernel.BrowserComputerCaptureScreenshotParams, opts ...option.RequestOption) (*http.Response, error) {
+		t.Fatal("computer screenshot should not be used with --full-page")
+		return nil, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), computer: fakeComp, playwright: fakePW}
+
+	err := b.ComputerScreenshot(context.Background(), BrowsersComputerScreenshotInput{Identifier: "id", To: outPath, FullPage: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, "fullPage: true")
+	data, err := os.ReadFile(outPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "fullPNG", string(data))
+}
+
+func TestBrowsersComputerScreenshot_JPEGConvertsAndAddsExtension(t *testing.T) {
+	setupStdoutCapture(t)
+	var pngBuf bytes.Buffer
+	require.NoError(t, png.Encode(&pngBuf, image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	fakeComp := &FakeComputerService{CaptureScreenshotFunc: func(ctx context.Context, id string, body kernel.BrowserComputerCaptureScreenshotParams, opts ...option.RequestOption) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(pngBuf.Bytes()))}, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), computer: fakeComp}
+	base := filepath.Join(t.TempDir(), "shot")
+
+	err := b.ComputerScreenshot(context.Background(), BrowsersComputerScreenshotInput{Identifier: "id", To: base, Format: "jpeg", Quality: 50})
+	require.NoError(t, err)
+	f, err := os.Open(base + ".jpg")
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = jpeg.Decode(f)
+	assert.NoError(t, err)
+	assert.Contains(t, outBuf.String(), "Saved screenshot to "+base+".jpg")
+}
+
+func TestBrowsersComputerScreenshot_JSONOutput(t *testing.T) {
+	setupStdoutCapture(t)
+	var pngBuf bytes.Buffer
+	require.NoError(t, png.Encode(&pngBuf, image.NewRGBA(image.Rect(0, 0, 8, 6))))
+	fakeComp := &FakeComputerService{CaptureScreenshotFunc: func(ctx context.Context, id string, body kernel.BrowserComputerCaptureScreenshotParams, opts ...option.RequestOption) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(pngBuf.Bytes()))}, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), computer: fakeComp}
+	to := filepath.Join(t.TempDir(), "shot.png")
+
+	out := captureStdout(t, func() {
+		require.NoError(t, b.ComputerScreenshot(context.Background(), BrowsersComputerScreenshotInput{Identifier: "id", To: to, Output: "json"}))
+	})
+	var res screenshotResult
+	require.NoError(t, json.Unmarshal([]byte(out), &res))
+	assert.Equal(t, screenshotResult{Path: to, Bytes: int64(pngBuf.Len()), ContentType: "image/png", Width: 8, Height: 6}, res)
+	assert.NotContains(t, outBuf.String(), "Saved screenshot")
+}
+
+func TestBrowsersComputerScreenshot_InvalidFormat(t *testing.T) {
+	setupStdoutCapture(t)
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), computer: &FakeComputerService{}}
+	err := b.ComputerScreenshot(context.Background(), BrowsersComputerScreenshotInput{Identifier: "id", To: "x", Format: "gif"})
+	assert.NoError(t, err)
+	assert.Contains(t, outBuf.String(), `unsupported --format "gif"`)
+}
+
 func TestBrowsersComputerPressKey_PrintsSuccess(t *testing.T) {
 	setupStdoutCapture(t)
 	fakeBrowsers := newFakeBrowsersServiceWithSimpleGet()
@@ -1070,6 +3004,44 @@ func TestBrowsersComputerPressKey_PrintsSuccess(t *testing.T) {
 	assert.Contains(t, out, "Pressed keys: Return,Shift")
 }
 
+func TestBrowsersComputerScrollIntoView(t *testing.T) {
+	setupStdoutCapture(t)
+	var code string
+	found := true
+	fakePW := &FakePlaywrightService{ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+		code = body.Code
+		return &kernel.BrowserPlaywrightExecuteResponse{Success: true, Result: map[string]any{"found": found}}, nil
+	}}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), playwright: fakePW}
+
+	err := b.ComputerScrollIntoView(context.Background(), BrowsersComputerScrollIntoViewInput{Identifier: "id", Selector: "#footer .link"})
+	require.NoError(t, err)
+	assert.Contains(t, code, `page.$("#footer .link")`)
+	assert.Contains(t, code, "scrollIntoView()")
+	assert.Contains(t, outBuf.String(), "Scrolled element #footer .link into view")
+
+	found = false
+	err = b.ComputerScrollIntoView(context.Background(), BrowsersComputerScrollIntoViewInput{Identifier: "id", Selector: "#missing"})
+	assert.EqualError(t, err, `no element matches selector "#missing"`)
+}
+
+func TestParseKeyCombo(t *testing.T) {
+	key, holds, err := parseKeyCombo("ctrl+shift+t")
+	require.NoError(t, err)
+	assert.Equal(t, "t", key)
+	assert.Equal(t, []string{"ctrl", "shift"}, holds)
+
+	key, holds, err = parseKeyCombo("Return")
+	require.NoError(t, err)
+	assert.Equal(t, "Return", key)
+	assert.Empty(t, holds)
+
+	_, _, err = parseKeyCombo("ctrl+shift")
+	assert.ErrorContains(t, err, "non-modifier key")
+	_, _, err = parseKeyCombo("ctrl++t")
+	assert.ErrorContains(t, err, "empty key")
+}
+
 func TestBrowsersComputerScroll_PrintsSuccess(t *testing.T) {
 	setupStdoutCapture(t)
 	fakeBrowsers := newFakeBrowsersServiceWithSimpleGet()
@@ -1080,6 +3052,203 @@ func TestBrowsersComputerScroll_PrintsSuccess(t *testing.T) {
 	assert.Contains(t, out, "Scrolled at (100,200)")
 }
 
+func stubRebootPollInterval(t *testing.T) {
+	orig := rebootPollInterval
+	rebootPollInterval = time.Millisecond
+	t.Cleanup(func() { rebootPollInterval = orig })
+}
+
+func TestBrowsersReboot_RestartsAndWaitsForReadiness(t *testing.T) {
+	setupStdoutCapture(t)
+	stubRebootPollInterval(t)
+
+	var gotExec kernel.BrowserProcessExecParams
+	proc := &FakeProcessService{
+		ExecFunc: func(ctx context.Context, id string, body kernel.BrowserProcessExecParams, opts ...option.RequestOption) (*kernel.BrowserProcessExecResponse, error) {
+			gotExec = body
+			return &kernel.BrowserProcessExecResponse{ExitCode: 0}, nil
+		},
+	}
+	probes := 0
+	pw := &FakePlaywrightService{
+		ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+			probes++
+			if probes < 3 {
+				return nil, errors.New("connection refused")
+			}
+			return &kernel.BrowserPlaywrightExecuteResponse{Success: true, Result: "141.0"}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: proc, playwright: pw}
+
+	err := b.Reboot(context.Background(), BrowsersRebootInput{Identifier: "id", Timeout: 5 * time.Second})
+	assert.NoError(t, err)
+	assert.Equal(t, "supervisorctl", gotExec.Command)
+	assert.Equal(t, []string{"restart", "chromium"}, gotExec.Args)
+	assert.True(t, gotExec.AsRoot.Value)
+	assert.Equal(t, 3, probes)
+	assert.Contains(t, outBuf.String(), "Chromium restarted (downtime:")
+}
+
+func TestBrowsersReboot_TimesOutWhenUnreachable(t *testing.T) {
+	setupStdoutCapture(t)
+	stubRebootPollInterval(t)
+
+	pw := &FakePlaywrightService{
+		ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+			return &kernel.BrowserPlaywrightExecuteResponse{Success: false, Error: "browser closed"}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: &FakeProcessService{}, playwright: pw}
+
+	err := b.Reboot(context.Background(), BrowsersRebootInput{Identifier: "id", Timeout: 20 * time.Millisecond})
+	assert.ErrorContains(t, err, "not reachable")
+}
+
+func TestBrowsersComputerClickMouse_SelectorUsesPlaywright(t *testing.T) {
+	setupStdoutCapture(t)
+	fakeComp := &FakeComputerService{
+		ClickMouseFunc: func(ctx context.Context, id string, body kernel.BrowserComputerClickMouseParams, opts ...option.RequestOption) error {
+			t.Fatal("coordinate click should not be used with --selector")
+			return nil
+		},
+	}
+	var gotCode string
+	found := true
+	fakePW := &FakePlaywrightService{
+		ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+			gotCode = body.Code
+			return &kernel.BrowserPlaywrightExecuteResponse{Success: true, Result: map[string]any{"found": found}}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), computer: fakeComp, playwright: fakePW}
+	in := BrowsersComputerClickMouseInput{Identifier: "id", Selector: `button[name="submit"]`, Button: "left", NumClicks: 2, ClickType: "click"}
+
+	require.NoError(t, b.ComputerClickMouse(context.Background(), in))
+	assert.Contains(t, gotCode, `page.$("button[name=\"submit\"]")`)
+	assert.Contains(t, gotCode, "clickCount: 2")
+	assert.Contains(t, outBuf.String(), `Clicked element button[name="submit"]`)
+
+	found = false
+	err := b.ComputerClickMouse(context.Background(), in)
+	assert.EqualError(t, err, `no element matches selector "button[name=\"submit\"]"`)
+}
+
+func TestBrowsersComputerClickMouse_SelectorRejectsHoldKeys(t *testing.T) {
+	setupStdoutCapture(t)
+	fakePW := &FakePlaywrightService{
+		ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+			t.Fatal("playwright should not run when --hold-key is combined with --selector")
+			return nil, nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), playwright: fakePW}
+	err := b.ComputerClickMouse(context.Background(), BrowsersComputerClickMouseInput{Identifier: "id", Selector: "button", HoldKeys: []string{"Shift"}})
+	assert.NoError(t, err)
+	assert.Contains(t, outBuf.String(), "--hold-key is not supported with --selector")
+}
+
+func TestBrowsersAssert_Passes(t *testing.T) {
+	setupStdoutCapture(t)
+	var gotCode string
+	fakePW := &FakePlaywrightService{
+		ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+			gotCode = body.Code
+			return &kernel.BrowserPlaywrightExecuteResponse{Success: true, Result: []any{
+				map[string]any{"found": true, "text": "Welcome back"},
+				map[string]any{"found": true, "attr": "submit"},
+			}}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), playwright: fakePW}
+	err := b.Assert(context.Background(), BrowsersAssertInput{Identifier: "id", Assertions: []browserAssertion{
+		{Selector: "h1", Text: "Welcome"},
+		{Selector: "button", Attr: "type=submit"},
+	}})
+	require.NoError(t, err)
+	assert.Contains(t, gotCode, `"selector":"h1"`)
+	assert.Contains(t, gotCode, `"attr":"type"`)
+	assert.Contains(t, outBuf.String(), "pass")
+	assert.NotContains(t, outBuf.String(), "FAIL")
+}
+
+func TestBrowsersAssert_FailureReturnsErrorAndJSON(t *testing.T) {
+	setupStdoutCapture(t)
+	fakePW := &FakePlaywrightService{
+		ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+			return &kernel.BrowserPlaywrightExecuteResponse{Success: true, Result: []any{
+				map[string]any{"found": true, "text": "Sign in"},
+				map[string]any{"found": false},
+			}}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), playwright: fakePW}
+	var err error
+	out := captureStdout(t, func() {
+		err = b.Assert(context.Background(), BrowsersAssertInput{Identifier: "id", Output: "json", Assertions: []browserAssertion{
+			{Selector: "h1", Text: "Welcome"},
+			{Selector: "#missing"},
+		}})
+	})
+	assert.EqualError(t, err, "2 of 2 assertions failed")
+
+	var results []browserAssertionResult
+	require.NoError(t, json.Unmarshal([]byte(out), &results))
+	require.Len(t, results, 2)
+	assert.False(t, results[0].Passed)
+	assert.Contains(t, results[0].Message, `text "Welcome" not found`)
+	assert.Equal(t, "no element matches selector", results[1].Message)
+}
+
+func TestBrowsersComputerScroll_ToBottomUsesPlaywright(t *testing.T) {
+	setupStdoutCapture(t)
+	fakeBrowsers := newFakeBrowsersServiceWithSimpleGet()
+	fakeComp := &FakeComputerService{
+		ScrollFunc: func(ctx context.Context, id string, body kernel.BrowserComputerScrollParams, opts ...option.RequestOption) error {
+			t.Fatal("wheel scroll should not be used for --to-bottom")
+			return nil
+		},
+	}
+	var gotID, gotCode string
+	fakePW := &FakePlaywrightService{
+		ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+			gotID = id
+			gotCode = body.Code
+			return &kernel.BrowserPlaywrightExecuteResponse{Success: true, Result: map[string]any{"x": 0, "y": 4200}}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fakeBrowsers, computer: fakeComp, playwright: fakePW}
+	err := b.ComputerScroll(context.Background(), BrowsersComputerScrollInput{Identifier: "id", ToBottom: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "id", gotID)
+	assert.Equal(t, scrollToEdgeCode(true), gotCode)
+	assert.Contains(t, gotCode, "window.scrollTo(0, document.scrollingElement")
+	assert.Contains(t, gotCode, "scrollHeight")
+	out := outBuf.String()
+	assert.Contains(t, out, "Scrolled to bottom (scroll position: 0,4200)")
+}
+
+func TestBrowsersComputerScroll_ToEdgeRejectsDeltaAndHoldKeys(t *testing.T) {
+	setupStdoutCapture(t)
+	fakePW := &FakePlaywrightService{
+		ExecuteFunc: func(ctx context.Context, id string, body kernel.BrowserPlaywrightExecuteParams, opts ...option.RequestOption) (*kernel.BrowserPlaywrightExecuteResponse, error) {
+			t.Fatal("scroll should be rejected before reaching the browser")
+			return nil, nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), computer: &FakeComputerService{}, playwright: fakePW}
+
+	for _, in := range []BrowsersComputerScrollInput{
+		{Identifier: "id", ToBottom: true, DeltaY: 200, DeltaYSet: true},
+		{Identifier: "id", ToTop: true, DeltaXSet: true},
+		{Identifier: "id", ToBottom: true, HoldKeys: []string{"Shift"}},
+	} {
+		outBuf.Reset()
+		require.NoError(t, b.ComputerScroll(context.Background(), in))
+		assert.Contains(t, outBuf.String(), "cannot be used with --to-bottom or --to-top")
+	}
+}
+
 func TestBrowsersComputerDragMouse_PrintsSuccess(t *testing.T) {
 	setupStdoutCapture(t)
 	fakeBrowsers := newFakeBrowsersServiceWithSimpleGet()
@@ -1091,6 +3260,26 @@ func TestBrowsersComputerDragMouse_PrintsSuccess(t *testing.T) {
 	assert.Contains(t, out, "Dragged mouse over 3 points")
 }
 
+func TestLoadDragPath(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(p, []byte(body), 0o600))
+		return p
+	}
+
+	path, err := loadDragPath(write("ok.json", `[[0,0],[50,50],[100,100]]`))
+	require.NoError(t, err)
+	assert.Equal(t, [][]int64{{0, 0}, {50, 50}, {100, 100}}, path)
+
+	_, err = loadDragPath(write("short.json", `[[0,0]]`))
+	assert.ErrorContains(t, err, "at least two points")
+	_, err = loadDragPath(write("cols.json", `[[0,0],[1,2,3]]`))
+	assert.ErrorContains(t, err, "point 1 has 3 values")
+	_, err = loadDragPath(write("float.json", `[[0,0],[1.5,2]]`))
+	assert.ErrorContains(t, err, "integer pairs")
+}
+
 func TestParseViewport_ValidFormats(t *testing.T) {
 	tests := []struct {
 		input       string
@@ -1187,6 +3376,91 @@ func TestBrowsersCreate_WithViewportNoRefreshRate(t *testing.T) {
 	assert.False(t, captured.Viewport.RefreshRate.Valid())
 }
 
+func TestBrowsersCreate_UnsupportedViewport(t *testing.T) {
+	setupStdoutCapture(t)
+	calls := 0
+	var captured kernel.BrowserNewParams
+	fake := &FakeBrowsersService{NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+		calls++
+		captured = body
+		return &kernel.BrowserNewResponse{SessionID: "session123", CdpWsURL: "ws://example"}, nil
+	}}
+	b := BrowsersCmd{browsers: fake}
+
+	require.NoError(t, b.Create(context.Background(), BrowsersCreateInput{Viewport: "1280x720@30"}))
+	assert.Equal(t, 0, calls)
+	out := outBuf.String()
+	assert.Contains(t, out, "Unsupported viewport 1280x720@30")
+	assert.Contains(t, out, "1920x1080@25")
+
+	require.NoError(t, b.Create(context.Background(), BrowsersCreateInput{Viewport: "1920x1080@60"}))
+	assert.Equal(t, 0, calls, "a supported size with an unsupported rate is rejected")
+
+	require.NoError(t, b.Create(context.Background(), BrowsersCreateInput{Viewport: "1280x720@30", ForceViewport: true}))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, int64(1280), captured.Viewport.Width)
+}
+
+func capacityError(t *testing.T) error {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, "https://api.onkernel.com/browsers", nil)
+	require.NoError(t, err)
+	e := &kernel.Error{StatusCode: http.StatusTooManyRequests, Request: req}
+	require.NoError(t, e.UnmarshalJSON([]byte(`{"code":"too_many_requests","message":"concurrent browser limit reached"}`)))
+	return e
+}
+
+func fastCapacityRetry(t *testing.T) {
+	t.Helper()
+	orig := capacityRetryInterval
+	capacityRetryInterval = time.Millisecond
+	t.Cleanup(func() { capacityRetryInterval = orig })
+}
+
+func TestBrowsersCreate_RetryOnCapacity(t *testing.T) {
+	setupStdoutCapture(t)
+	fastCapacityRetry(t)
+	calls := 0
+	fake := &FakeBrowsersService{NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+		calls++
+		if calls == 1 {
+			return nil, capacityError(t)
+		}
+		return &kernel.BrowserNewResponse{SessionID: "session123", CdpWsURL: "ws://example"}, nil
+	}}
+	b := BrowsersCmd{browsers: fake}
+
+	err := b.Create(context.Background(), BrowsersCreateInput{RetryOnCapacity: true, CapacityTimeout: time.Minute})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	out := outBuf.String()
+	assert.Contains(t, out, "At browser capacity")
+	assert.Contains(t, out, "session123")
+}
+
+func TestBrowsersCreate_RetryOnCapacityTimesOut(t *testing.T) {
+	setupStdoutCapture(t)
+	fastCapacityRetry(t)
+	fake := &FakeBrowsersService{NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+		return nil, capacityError(t)
+	}}
+	b := BrowsersCmd{browsers: fake}
+
+	err := b.Create(context.Background(), BrowsersCreateInput{RetryOnCapacity: true, CapacityTimeout: 20 * time.Millisecond})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "concurrent browser limit reached")
+	assert.Contains(t, outBuf.String(), "No browser capacity freed up within 20ms")
+}
+
+func TestPoolFlagConflicts_IgnoresNonBrowserFlags(t *testing.T) {
+	fs := pflag.NewFlagSet("create", pflag.ContinueOnError)
+	fs.String("pool-name", "", "")
+	fs.Bool("plain", false, "")
+	fs.Bool("headless", false, "")
+	require.NoError(t, fs.Parse([]string{"--pool-name", "prod", "--plain", "--headless"}))
+	assert.Equal(t, []string{"--headless"}, poolFlagConflicts(fs))
+}
+
 func TestBrowsersCreate_WithInvalidViewport(t *testing.T) {
 	setupStdoutCapture(t)
 	fake := &FakeBrowsersService{}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/spf13/cobra"
+)
+
+// concurrencyEnvVar overrides the default worker count for batch commands.
+const concurrencyEnvVar = "KERNEL_CONCURRENCY"
+
+// maxDefaultConcurrency caps the default so batch commands stay gentle on the API.
+const maxDefaultConcurrency = 4
+
+// defaultConcurrency returns min(maxDefaultConcurrency, NumCPU).
+func defaultConcurrency() int {
+	return min(maxDefaultConcurrency, runtime.NumCPU())
+}
+
+// resolveConcurrency picks the worker count for a batch command: an explicit
+// --concurrency flag wins, then KERNEL_CONCURRENCY, then defaultConcurrency.
+// The result is always at least 1.
+func resolveConcurrency(flag int) int {
+	return resolveConcurrencyDefault(flag, defaultConcurrency())
+}
+
+// resolveConcurrencyDefault is resolveConcurrency for commands whose default
+// worker count differs from defaultConcurrency.
+func resolveConcurrencyDefault(flag, def int) int {
+	n := flag
+	if n <= 0 {
+		if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(concurrencyEnvVar))); err == nil {
+			n = v
+		}
+	}
+	if n <= 0 {
+		n = def
+	}
+	return max(n, 1)
+}
+
+// addConcurrencyFlag registers --concurrency on a batch command. Read it with
+// getConcurrency so the env override and default apply.
+func addConcurrencyFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("concurrency", 0, "Number of operations to run in parallel (default: $"+concurrencyEnvVar+" or min(4, CPUs))")
+}
+
+// getConcurrency reads the flag registered by addConcurrencyFlag.
+func getConcurrency(cmd *cobra.Command) int {
+	n, _ := cmd.Flags().GetInt("concurrency")
+	return resolveConcurrency(n)
+}
+
+// addFailurePolicyFlags registers --fail-fast and --keep-going on a batch
+// command. failFast is the command's default policy. Read it with getFailFast.
+func addFailurePolicyFlags(cmd *cobra.Command, failFast bool) {
+	cmd.Flags().Bool("fail-fast", failFast, "Stop starting new operations after the first failure")
+	cmd.Flags().Bool("keep-going", !failFast, "Attempt every operation and report all failures")
+	cmd.MarkFlagsMutuallyExclusive("fail-fast", "keep-going")
+}
+
+// getFailFast reads the flags registered by addFailurePolicyFlags.
+func getFailFast(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("keep-going") {
+		keepGoing, _ := cmd.Flags().GetBool("keep-going")
+		return !keepGoing
+	}
+	failFast, _ := cmd.Flags().GetBool("fail-fast")
+	return failFast
+}
+
+// forEachConcurrent calls fn for every index in [0, n) using at most
+// concurrency goroutines, returning once all calls have finished. Callers
+// collect results by index so output order matches input order.
+func forEachConcurrent(n, concurrency int, fn func(i int)) {
+	forEachConcurrentUntil(n, concurrency, false, func(i int) error {
+		fn(i)
+		return nil
+	})
+}
+
+// forEachConcurrentUntil is forEachConcurrent for fallible work. With
+// failFast, no new index is started once any call has returned an error;
+// calls already running still finish. Indexes that never ran are left to the
+// caller to report as skipped.
+func forEachConcurrentUntil(n, concurrency int, failFast bool, fn func(i int) error) {
+	concurrency = max(min(concurrency, n), 1)
+	idx := make(chan int)
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				if failFast && failed.Load() {
+					continue
+				}
+				if err := fn(i); err != nil {
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+	for i := range n {
+		if failFast && failed.Load() {
+			break
+		}
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+}
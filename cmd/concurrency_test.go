@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveConcurrency(t *testing.T) {
+	tests := []struct {
+		name string
+		flag int
+		env  string
+		want int
+	}{
+		{name: "flag wins over env", flag: 7, env: "3", want: 7},
+		{name: "env when flag unset", flag: 0, env: "3", want: 3},
+		{name: "env with whitespace", flag: 0, env: " 5 ", want: 5},
+		{name: "default when nothing set", flag: 0, env: "", want: defaultConcurrency()},
+		{name: "invalid env falls back to default", flag: 0, env: "lots", want: defaultConcurrency()},
+		{name: "non-positive env falls back to default", flag: 0, env: "0", want: defaultConcurrency()},
+		{name: "negative flag falls back to env", flag: -2, env: "2", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(concurrencyEnvVar, tt.env)
+			assert.Equal(t, tt.want, resolveConcurrency(tt.flag))
+		})
+	}
+}
+
+func TestResolveConcurrencyDefault_UsesCommandDefault(t *testing.T) {
+	t.Setenv(concurrencyEnvVar, "")
+	assert.Equal(t, 8, resolveConcurrencyDefault(0, 8))
+	assert.Equal(t, 2, resolveConcurrencyDefault(2, 8))
+	t.Setenv(concurrencyEnvVar, "5")
+	assert.Equal(t, 5, resolveConcurrencyDefault(0, 8))
+}
+
+func TestDefaultConcurrency_ClampedToAtLeastOne(t *testing.T) {
+	n := defaultConcurrency()
+	assert.GreaterOrEqual(t, n, 1)
+	assert.LessOrEqual(t, n, maxDefaultConcurrency)
+}
+
+func TestForEachConcurrent_VisitsEveryIndexWithinLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	seen := make([]bool, 20)
+	forEachConcurrent(len(seen), 3, func(i int) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		seen[i] = true
+		running.Add(-1)
+	})
+	for i, ok := range seen {
+		assert.True(t, ok, "index %d not visited", i)
+	}
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/onkernel/cli/pkg/create"
 	"github.com/pterm/pterm"
@@ -43,20 +44,58 @@ func (c CreateCmd) Create(ctx context.Context, ci create.CreateInput) error {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	pterm.Printfln("\nCreating a new %s %s", ci.Language, ci.Template)
+	if ci.FromGit != "" {
+		pterm.Printfln("\nCreating a new app from %s", ci.FromGit)
 
-	spinner, _ := pterm.DefaultSpinner.Start("Copying template files...")
+		spinner, _ := pterm.DefaultSpinner.Start("Fetching template repository...")
+		if err := create.CopyGitTemplate(ctx, appPath, ci.FromGit, ci.GitPath); err != nil {
+			spinner.Fail("Failed to fetch template repository")
+			return fmt.Errorf("failed to copy template files: %w", err)
+		}
+		spinner.Success()
+
+		if ci.Language == "" {
+			ci.Language = create.DetectLanguage(appPath)
+		}
+		if ci.Language == "" {
+			pterm.Warning.Println("Could not detect the template language; skipping dependency installation.")
+			pterm.Success.Println("🎉 Kernel app created successfully!")
+			return nil
+		}
+	} else {
+		pterm.Printfln("\nCreating a new %s %s", ci.Language, ci.Template)
+
+		spinner, _ := pterm.DefaultSpinner.Start("Copying template files...")
 
-	if err := create.CopyTemplateFiles(appPath, ci.Language, ci.Template); err != nil {
-		spinner.Fail("Failed to copy template files")
-		return fmt.Errorf("failed to copy template files: %w", err)
+		if err := create.CopyTemplateFilesWithVars(appPath, ci.Language, ci.Template, ci.Vars); err != nil {
+			spinner.Fail("Failed to copy template files")
+			return fmt.Errorf("failed to copy template files: %w", err)
+		}
+		spinner.Success()
+	}
+
+	if err := create.WriteAppMetadata(appPath, create.NewAppMetadata(ci.Language, ci.Template)); err != nil {
+		return err
 	}
-	spinner.Success()
 
 	nextSteps, err := create.InstallDependencies(appPath, ci)
 	if err != nil {
 		return fmt.Errorf("failed to install dependencies: %w", err)
 	}
+
+	var hooks []string
+	if ci.PostCreate {
+		hooks = append(hooks, create.GetPostCreateCommand(ci.Language, ci.Template))
+	}
+	for _, command := range append(hooks, ci.Run) {
+		if command == "" {
+			continue
+		}
+		pterm.Info.Printfln("Running: %s", command)
+		if err := create.RunPostCreate(appPath, command, os.Stdout, os.Stderr); err != nil {
+			return err
+		}
+	}
 	pterm.Success.Println("🎉 Kernel app created successfully!")
 	pterm.Println()
 	pterm.FgYellow.Println(nextSteps)
@@ -75,18 +114,61 @@ func init() {
 	createCmd.Flags().StringP("name", "n", "", "Name of the application")
 	createCmd.Flags().StringP("language", "l", "", "Language of the application")
 	createCmd.Flags().StringP("template", "t", "", "Template to use for the application")
+	createCmd.Flags().String("from-git", "", "Scaffold from a git repository instead of a built-in template (<url>[#ref])")
+	createCmd.Flags().String("path", "", "Subdirectory of the --from-git repository to use as the template")
+	createCmd.Flags().String("run", "", "Shell command to run in the new app directory after setup")
+	createCmd.Flags().Bool("post-create", false, "Run the template's own post-create command, if it defines one, before --run")
+	createCmd.Flags().StringArray("var", nil, "Extra template variable as key=value, available as {{.key}} (repeatable)")
 }
 
 func runCreateApp(cmd *cobra.Command, args []string) error {
 	appName, _ := cmd.Flags().GetString("name")
 	language, _ := cmd.Flags().GetString("language")
 	template, _ := cmd.Flags().GetString("template")
+	fromGit, _ := cmd.Flags().GetString("from-git")
+	gitPath, _ := cmd.Flags().GetString("path")
+	run, _ := cmd.Flags().GetString("run")
+	postCreate, _ := cmd.Flags().GetBool("post-create")
+	varPairs, _ := cmd.Flags().GetStringArray("var")
+	vars, err := parseTemplateVars(varPairs)
+	if err != nil {
+		return err
+	}
+
+	if gitPath != "" && fromGit == "" {
+		return fmt.Errorf("--path requires --from-git")
+	}
+	if fromGit != "" {
+		if template != "" {
+			return fmt.Errorf("--template cannot be used with --from-git")
+		}
+		if len(vars) > 0 {
+			return fmt.Errorf("--var cannot be used with --from-git; git templates are copied as-is")
+		}
+		if postCreate {
+			return fmt.Errorf("--post-create cannot be used with --from-git; use --run instead")
+		}
+		if _, _, err := create.ParseGitSource(fromGit); err != nil {
+			return err
+		}
+	}
 
-	appName, err := create.PromptForAppName(appName)
+	appName, err = create.PromptForAppName(appName)
 	if err != nil {
 		return fmt.Errorf("failed to get app name: %w", err)
 	}
 
+	if fromGit != "" {
+		c := CreateCmd{}
+		return c.Create(cmd.Context(), create.CreateInput{
+			Name:     appName,
+			Language: create.NormalizeLanguage(language),
+			FromGit:  fromGit,
+			GitPath:  gitPath,
+			Run:      run,
+		})
+	}
+
 	language, err = create.PromptForLanguage(language)
 	if err != nil {
 		return fmt.Errorf("failed to get language: %w", err)
@@ -99,8 +181,25 @@ func runCreateApp(cmd *cobra.Command, args []string) error {
 
 	c := CreateCmd{}
 	return c.Create(cmd.Context(), create.CreateInput{
-		Name:     appName,
-		Language: language,
-		Template: template,
+		Name:       appName,
+		Language:   language,
+		Template:   template,
+		Run:        run,
+		PostCreate: postCreate,
+		Vars:       vars,
 	})
 }
+
+// parseTemplateVars converts repeated --var key=value flags into a map.
+func parseTemplateVars(pairs []string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected key=value", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
@@ -275,6 +276,172 @@ func TestCreateCommand_DependencyInstallationFails(t *testing.T) {
 	assert.Contains(t, output, "pnpm install", "should print pnpm install command")
 }
 
+// TestCreateCommand_RunExecutesInAppDir tests that --run executes after setup
+// inside the new app directory
+func TestCreateCommand_RunExecutesInAppDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	orgDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		os.Chdir(orgDir)
+	})
+
+	originalInstallCommands := create.InstallCommands
+	create.InstallCommands = map[string]string{
+		create.LanguageTypeScript: "true",
+	}
+	t.Cleanup(func() {
+		create.InstallCommands = originalInstallCommands
+	})
+
+	c := CreateCmd{}
+	err = c.Create(context.Background(), create.CreateInput{
+		Name:     "run-app",
+		Language: create.LanguageTypeScript,
+		Template: create.TemplateSampleApp,
+		Run:      "touch created.marker",
+	})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(tmpDir, "run-app", "created.marker"))
+}
+
+// TestCreateCommand_TemplatePostCreateIsOptIn tests that a template's
+// post-create command only runs with --post-create, and before --run
+func TestCreateCommand_TemplatePostCreateIsOptIn(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	orgDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		os.Chdir(orgDir)
+	})
+
+	originalInstallCommands := create.InstallCommands
+	create.InstallCommands = map[string]string{
+		create.LanguageTypeScript: "true",
+	}
+	originalConfig := create.Commands[create.LanguageTypeScript][create.TemplateSampleApp]
+	hooked := originalConfig
+	hooked.PostCreate = "touch template.marker"
+	create.Commands[create.LanguageTypeScript][create.TemplateSampleApp] = hooked
+	t.Cleanup(func() {
+		create.InstallCommands = originalInstallCommands
+		create.Commands[create.LanguageTypeScript][create.TemplateSampleApp] = originalConfig
+	})
+
+	c := CreateCmd{}
+	err = c.Create(context.Background(), create.CreateInput{
+		Name:     "no-hook-app",
+		Language: create.LanguageTypeScript,
+		Template: create.TemplateSampleApp,
+	})
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(tmpDir, "no-hook-app", "template.marker"))
+
+	err = c.Create(context.Background(), create.CreateInput{
+		Name:       "hook-app",
+		Language:   create.LanguageTypeScript,
+		Template:   create.TemplateSampleApp,
+		PostCreate: true,
+		Run:        "test -f template.marker && touch run.marker",
+	})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(tmpDir, "hook-app", "template.marker"))
+	assert.FileExists(t, filepath.Join(tmpDir, "hook-app", "run.marker"))
+}
+
+// TestCreateCommand_RunFailureReturnsError tests that a failing --run command is reported
+func TestCreateCommand_RunFailureReturnsError(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	orgDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		os.Chdir(orgDir)
+	})
+
+	originalInstallCommands := create.InstallCommands
+	create.InstallCommands = map[string]string{
+		create.LanguageTypeScript: "true",
+	}
+	t.Cleanup(func() {
+		create.InstallCommands = originalInstallCommands
+	})
+
+	c := CreateCmd{}
+	err = c.Create(context.Background(), create.CreateInput{
+		Name:     "run-app",
+		Language: create.LanguageTypeScript,
+		Template: create.TemplateSampleApp,
+		Run:      "exit 3",
+	})
+	assert.ErrorContains(t, err, `command "exit 3" failed`)
+}
+
+// TestCreateCommand_ManifestUsesAppName tests that the generated manifest is named after the app
+func TestCreateCommand_ManifestUsesAppName(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	orgDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		os.Chdir(orgDir)
+	})
+
+	originalInstallCommands := create.InstallCommands
+	create.InstallCommands = map[string]string{
+		create.LanguageTypeScript: "true",
+		create.LanguagePython:     "true",
+	}
+	t.Cleanup(func() {
+		create.InstallCommands = originalInstallCommands
+	})
+
+	c := CreateCmd{}
+	require.NoError(t, c.Create(context.Background(), create.CreateInput{
+		Name:     "ts-named-app",
+		Language: create.LanguageTypeScript,
+		Template: create.TemplateSampleApp,
+	}))
+	content, err := os.ReadFile(filepath.Join(tmpDir, "ts-named-app", "package.json"))
+	require.NoError(t, err)
+	var pkg struct {
+		Name string `json:"name"`
+	}
+	require.NoError(t, json.Unmarshal(content, &pkg))
+	assert.Equal(t, "ts-named-app", pkg.Name)
+
+	require.NoError(t, c.Create(context.Background(), create.CreateInput{
+		Name:     "py-named-app",
+		Language: create.LanguagePython,
+		Template: create.TemplateSampleApp,
+	}))
+	content, err = os.ReadFile(filepath.Join(tmpDir, "py-named-app", "pyproject.toml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `name = "py-named-app"`)
+
+	meta, err := create.ReadAppMetadata(filepath.Join(tmpDir, "py-named-app"))
+	require.NoError(t, err)
+	assert.Equal(t, create.GetDeployCommand(create.LanguagePython, create.TemplateSampleApp), meta.DeployCommand)
+}
+
+func TestParseTemplateVars(t *testing.T) {
+	vars, err := parseTemplateVars([]string{"author=Jane", "desc=a=b", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"author": "Jane", "desc": "a=b", "empty": ""}, vars)
+
+	_, err = parseTemplateVars([]string{"novalue"})
+	assert.ErrorContains(t, err, "expected key=value")
+
+	_, err = parseTemplateVars([]string{"=value"})
+	assert.Error(t, err)
+}
+
 // TestCreateCommand_RequiredToolMissing tests that the app is created
 func TestCreateCommand_RequiredToolMissing(t *testing.T) {
 	tests := []struct {
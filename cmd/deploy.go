@@ -10,14 +10,19 @@ import (
 	"net/http"
 	"net/textproto"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/onkernel/cli/pkg/create"
 	"github.com/onkernel/cli/pkg/util"
 	kernel "github.com/onkernel/kernel-go-sdk"
 	"github.com/onkernel/kernel-go-sdk/option"
+	"github.com/onkernel/kernel-go-sdk/shared"
 	"github.com/pterm/pterm"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
@@ -32,15 +37,16 @@ var deployLogsCmd = &cobra.Command{
 
 var deployHistoryCmd = &cobra.Command{
 	Use:   "history [app_name]",
-	Short: "Show deployment history",
+	Short: "Show deployment history (use @current for the app in this directory)",
 	Args:  cobra.RangeArgs(0, 1),
 	RunE:  runDeployHistory,
 }
 
 var deployCmd = &cobra.Command{
-	Use:   "deploy <entrypoint>",
+	Use:   "deploy [entrypoint]",
 	Short: "Deploy a Kernel application",
-	Args:  cobra.ExactArgs(1),
+	Long:  "Deploy a Kernel application. When the entrypoint is omitted it is detected from .kernel/app.json (written by `kernel create`) or a lone index.ts/main.py in the current directory.",
+	Args:  cobra.MaximumNArgs(1),
 	RunE:  runDeploy,
 }
 
@@ -53,15 +59,18 @@ var deployGithubCmd = &cobra.Command{
 }
 
 func init() {
-	deployCmd.Flags().String("version", "latest", "Specify a version for the app (default: latest)")
+	deployCmd.Flags().String("version", "latest", "Specify a version for the app (default: latest); 'auto' derives a unique one from the git commit or current time")
 	deployCmd.Flags().Bool("force", false, "Allow overwrite of an existing version with the same name")
 	deployCmd.Flags().StringArrayP("env", "e", []string{}, "Set environment variables (e.g., KEY=value). May be specified multiple times")
 	deployCmd.Flags().StringArray("env-file", []string{}, "Read environment variables from a file (.env format). May be specified multiple times")
+	deployCmd.Flags().Bool("list-files", false, "Print each archived file and its size (largest first) before uploading")
 
 	// Subcommands under deploy
 	deployLogsCmd.Flags().BoolP("follow", "f", false, "Follow logs in real-time (stream continuously)")
 	deployLogsCmd.Flags().StringP("since", "s", "", "How far back to retrieve logs. Supports duration formats: ns, us, ms, s, m, h (e.g., 5m, 2h, 1h30m). Note: 'd' not supported; use hours instead. Can also specify timestamps: 2006-01-02, 2006-01-02T15:04, 2006-01-02T15:04:05, 2006-01-02T15:04:05.000. Max lookback ~167h.")
 	deployLogsCmd.Flags().BoolP("with-timestamps", "t", false, "Include timestamps in each log line")
+	deployLogsCmd.Flags().StringP("output", "o", "", "Write logs to this file instead of stdout")
+	deployLogsCmd.Flags().Bool("append", false, "Append to the --output file instead of overwriting it")
 	deployCmd.AddCommand(deployLogsCmd)
 
 	deployHistoryCmd.Flags().Int("limit", 20, "Max deployments to return (default 20)")
@@ -92,6 +101,11 @@ func runDeployGithub(cmd *cobra.Command, args []string) error {
 
 	version, _ := cmd.Flags().GetString("version")
 	force, _ := cmd.Flags().GetBool("force")
+	if version == autoVersion {
+		// The local checkout says nothing about the GitHub ref being deployed.
+		version = resolveDeployVersion(version, "", time.Now())
+		pterm.Info.Printfln("Using version: %s", version)
+	}
 
 	// Collect env vars similar to runDeploy
 	envPairs, _ := cmd.Flags().GetStringArray("env")
@@ -200,9 +214,19 @@ func runDeployGithub(cmd *cobra.Command, args []string) error {
 func runDeploy(cmd *cobra.Command, args []string) (err error) {
 	startTime := time.Now()
 	client := getKernelClient(cmd)
-	entrypoint := args[0]
+	var entrypoint string
+	if len(args) > 0 {
+		entrypoint = args[0]
+	} else {
+		entrypoint, err = detectEntrypoint(".")
+		if err != nil {
+			return err
+		}
+		pterm.Info.Printfln("Using detected entrypoint: %s", entrypoint)
+	}
 	version, _ := cmd.Flags().GetString("version")
 	force, _ := cmd.Flags().GetBool("force")
+	listFiles, _ := cmd.Flags().GetBool("list-files")
 	if version == "" {
 		version = "latest"
 	}
@@ -215,15 +239,23 @@ func runDeploy(cmd *cobra.Command, args []string) (err error) {
 	}
 
 	sourceDir := filepath.Dir(resolvedEntrypoint)
+	if version == autoVersion {
+		version = resolveDeployVersion(version, sourceDir, time.Now())
+		pterm.Info.Printfln("Using version: %s", version)
+	}
 	spinner, _ := pterm.DefaultSpinner.Start("Compressing files...")
 	tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("kernel_%d.zip", time.Now().UnixNano()))
 	logger.Debug("compressing files", logger.Args("sourceDir", sourceDir, "tmpFile", tmpFile))
-	if err := util.ZipDirectory(sourceDir, tmpFile); err != nil {
+	entries, err := util.ZipDirectoryWithEntries(sourceDir, tmpFile)
+	if err != nil {
 		spinner.Fail("Failed to compress files")
 		return err
 	}
 	spinner.Success("Compressed files")
 	defer os.Remove(tmpFile)
+	if listFiles {
+		printZipEntries(entries)
+	}
 
 	// make io.Reader from tmpFile
 	file, err := os.Open(tmpFile)
@@ -275,6 +307,76 @@ func runDeploy(cmd *cobra.Command, args []string) (err error) {
 	return followDeployment(cmd.Context(), client, resp.ID, startTime, option.WithMaxRetries(0))
 }
 
+// printZipEntries lists archived files largest first, followed by the total size.
+func printZipEntries(entries []util.ZipEntry) {
+	sorted := slices.Clone(entries)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Size > sorted[j].Size })
+
+	var total int64
+	rows := pterm.TableData{{"Size", "Path"}}
+	for _, e := range sorted {
+		total += e.Size
+		rows = append(rows, []string{util.HumanBytes(e.Size), e.Path})
+	}
+	PrintTableNoPad(rows, true)
+	pterm.Info.Printfln("%d files, %s uncompressed", len(sorted), util.HumanBytes(total))
+}
+
+// autoVersion asks deploy to pick a unique version instead of reusing a slot
+// like "latest".
+const autoVersion = "auto"
+
+// resolveDeployVersion expands --version auto into "<timestamp>-<short sha>"
+// when dir is inside a git repository, or just the UTC timestamp otherwise.
+// Any other version is returned unchanged.
+func resolveDeployVersion(version, dir string, now time.Time) string {
+	if version != autoVersion {
+		return version
+	}
+	stamp := now.UTC().Format("20060102-150405")
+	if dir == "" {
+		return stamp
+	}
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return stamp
+	}
+	sha := strings.TrimSpace(string(out))
+	if sha == "" {
+		return stamp
+	}
+	return stamp + "-" + sha
+}
+
+// defaultEntrypoints are the entrypoint file names used by the built-in templates.
+var defaultEntrypoints = []string{"index.ts", "main.py"}
+
+// detectEntrypoint picks the entrypoint in dir for a bare `kernel deploy`. The
+// entrypoint recorded in .kernel/app.json wins; otherwise exactly one of the
+// default entrypoints must be present.
+func detectEntrypoint(dir string) (string, error) {
+	if meta, err := create.ReadAppMetadata(dir); err == nil && meta.Entrypoint != "" {
+		if _, err := os.Stat(filepath.Join(dir, meta.Entrypoint)); err == nil {
+			return meta.Entrypoint, nil
+		}
+	}
+
+	var found []string
+	for _, name := range defaultEntrypoints {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			found = append(found, name)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("no entrypoint found in the current directory; run `kernel deploy <entrypoint>`")
+	case 1:
+		return found[0], nil
+	default:
+		return "", fmt.Errorf("multiple entrypoints found (%s); run `kernel deploy <entrypoint>`", strings.Join(found, ", "))
+	}
+}
+
 func quoteIfNeeded(s string) string {
 	if strings.ContainsRune(s, ' ') {
 		return fmt.Sprintf("\"%s\"", s)
@@ -291,6 +393,17 @@ func runDeployLogs(cmd *cobra.Command, args []string) error {
 	since, _ := cmd.Flags().GetString("since")
 	follow, _ := cmd.Flags().GetBool("follow")
 	ts, _ := cmd.Flags().GetBool("with-timestamps")
+	outputPath, _ := cmd.Flags().GetString("output")
+	appendOutput, _ := cmd.Flags().GetBool("append")
+	if appendOutput && outputPath == "" {
+		return fmt.Errorf("--append requires --output")
+	}
+
+	out, err := openLogOutput(outputPath, appendOutput)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
 
 	stream := client.Deployments.FollowStreaming(cmd.Context(), deploymentID, kernel.DeploymentFollowParams{Since: kernel.Opt(since)}, option.WithMaxRetries(0))
 	defer func() { _ = stream.Close() }()
@@ -303,12 +416,7 @@ func runDeployLogs(cmd *cobra.Command, args []string) error {
 			data := stream.Current()
 			switch data.Event {
 			case "log":
-				logEntry := data.AsLog()
-				if ts {
-					fmt.Printf("%s %s\n", logEntry.Timestamp.Format(time.RFC3339Nano), strings.TrimSuffix(logEntry.Message, "\n"))
-				} else {
-					fmt.Println(strings.TrimSuffix(logEntry.Message, "\n"))
-				}
+				printDeployLogLine(out, data.AsLog(), ts)
 			case "error":
 				errEvt := data.AsErrorEvent()
 				return fmt.Errorf("%s: %s", errEvt.Error.Code, errEvt.Error.Message)
@@ -329,12 +437,7 @@ func runDeployLogs(cmd *cobra.Command, args []string) error {
 				data := stream.Current()
 				switch data.Event {
 				case "log":
-					logEntry := data.AsLog()
-					if ts {
-						fmt.Printf("%s %s\n", logEntry.Timestamp.Format(time.RFC3339Nano), strings.TrimSuffix(logEntry.Message, "\n"))
-					} else {
-						fmt.Println(strings.TrimSuffix(logEntry.Message, "\n"))
-					}
+					printDeployLogLine(out, data.AsLog(), ts)
 				case "error":
 					errEvt := data.AsErrorEvent()
 					return fmt.Errorf("%s: %s", errEvt.Error.Code, errEvt.Error.Message)
@@ -353,6 +456,16 @@ func runDeployLogs(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printDeployLogLine writes a single deployment log entry to out.
+func printDeployLogLine(out io.Writer, entry shared.LogEvent, withTimestamp bool) {
+	msg := strings.TrimSuffix(entry.Message, "\n")
+	if withTimestamp {
+		fmt.Fprintf(out, "%s %s\n", entry.Timestamp.Format(time.RFC3339Nano), msg)
+		return
+	}
+	fmt.Fprintln(out, msg)
+}
+
 func runDeployHistory(cmd *cobra.Command, args []string) error {
 	client := getKernelClient(cmd)
 
@@ -379,7 +492,11 @@ func runDeployHistory(cmd *cobra.Command, args []string) error {
 	// Build server-side paginated request
 	var appNameFilter string
 	if len(args) == 1 {
-		appNameFilter = strings.TrimSpace(args[0])
+		name, err := resolveAppName(strings.TrimSpace(args[0]))
+		if err != nil {
+			return err
+		}
+		appNameFilter = name
 	}
 
 	params := kernel.DeploymentListParams{}
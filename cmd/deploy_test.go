@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onkernel/cli/pkg/create"
+	"github.com/onkernel/cli/pkg/util"
+	kernel "github.com/onkernel/kernel-go-sdk"
+	"github.com/onkernel/kernel-go-sdk/option"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("// entry"), 0644))
+	}
+}
+
+func TestDetectEntrypoint(t *testing.T) {
+	t.Run("single default entrypoint", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, "index.ts", "helper.ts")
+		got, err := detectEntrypoint(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "index.ts", got)
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, "index.ts", "main.py")
+		_, err := detectEntrypoint(dir)
+		assert.ErrorContains(t, err, "multiple entrypoints found")
+	})
+
+	t.Run("none", func(t *testing.T) {
+		_, err := detectEntrypoint(t.TempDir())
+		assert.ErrorContains(t, err, "no entrypoint found")
+	})
+
+	t.Run("app metadata wins", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, "index.ts", "main.py")
+		require.NoError(t, create.WriteAppMetadata(dir, create.AppMetadata{Language: create.LanguagePython, Entrypoint: "main.py"}))
+		got, err := detectEntrypoint(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "main.py", got)
+	})
+}
+
+func TestRunDeploy_AutoDetectsEntrypoint(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "index.ts")
+	orgDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(orgDir) })
+
+	origLogger := logger
+	logger = pterm.DefaultLogger.WithLevel(pterm.LogLevelDisabled)
+	t.Cleanup(func() { logger = origLogger })
+
+	// Stand in for the upload endpoint: capture the entrypoint and reject the deploy
+	var gotEntrypoint string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err == nil {
+			gotEntrypoint = r.FormValue("entrypoint_rel_path")
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"bad_request","message":"stubbed"}`))
+	}))
+	defer srv.Close()
+
+	client := kernel.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("test"))
+	cmd := &cobra.Command{}
+	cmd.Flags().String("version", "latest", "")
+	cmd.Flags().Bool("force", false, "")
+	cmd.Flags().StringArray("env", nil, "")
+	cmd.Flags().StringArray("env-file", nil, "")
+	cmd.SetContext(context.WithValue(context.Background(), util.KernelClientKey, client))
+
+	err = runDeploy(cmd, nil)
+	require.Error(t, err)
+	assert.Equal(t, "index.ts", gotEntrypoint)
+}
+
+func TestRunDeployHistory_CurrentAppFromMetadata(t *testing.T) {
+	setupStdoutCapture(t)
+	dir := t.TempDir()
+	require.NoError(t, create.WriteAppMetadata(dir, create.NewAppMetadata(create.LanguageTypeScript, create.TemplateSampleApp)))
+	orgDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(orgDir) })
+
+	var gotAppName string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAppName = r.URL.Query().Get("app_name")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	client := kernel.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("test"))
+	cmd := &cobra.Command{}
+	cmd.Flags().Int("limit", 0, "")
+	cmd.Flags().Int("per-page", 20, "")
+	cmd.Flags().Int("page", 1, "")
+	cmd.SetContext(context.WithValue(context.Background(), util.KernelClientKey, client))
+
+	require.NoError(t, runDeployHistory(cmd, []string{"@current"}))
+	assert.Equal(t, "ts-basic", gotAppName)
+}
+
+func TestResolveAppName_NoMetadata(t *testing.T) {
+	orgDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(orgDir) })
+
+	_, err = resolveAppName("@current")
+	assert.ErrorContains(t, err, ".kernel/app.json")
+
+	name, err := resolveAppName("my-app")
+	assert.NoError(t, err)
+	assert.Equal(t, "my-app", name)
+}
+
+func TestPrintZipEntries_LargestFirst(t *testing.T) {
+	setupStdoutCapture(t)
+
+	printZipEntries([]util.ZipEntry{
+		{Path: "index.ts", Size: 10},
+		{Path: "node_modules/big.js", Size: 5 << 20},
+		{Path: "lib/util.ts", Size: 2048},
+	})
+
+	out := outBuf.String()
+	big := strings.Index(out, "node_modules/big.js")
+	mid := strings.Index(out, "lib/util.ts")
+	small := strings.Index(out, "index.ts")
+	require.True(t, big >= 0 && mid >= 0 && small >= 0, out)
+	assert.Less(t, big, mid)
+	assert.Less(t, mid, small)
+	assert.Contains(t, out, "5.0 MiB")
+	assert.Contains(t, out, "3 files")
+}
+
+func TestResolveDeployVersion(t *testing.T) {
+	now := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	assert.Equal(t, "v1", resolveDeployVersion("v1", t.TempDir(), now))
+
+	t.Run("outside a git repo", func(t *testing.T) {
+		assert.Equal(t, "20250304-050607", resolveDeployVersion("auto", t.TempDir(), now))
+	})
+
+	t.Run("inside a git repo", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not available")
+		}
+		repo := t.TempDir()
+		writeFiles(t, repo, "index.ts")
+		for _, args := range [][]string{
+			{"init", "-q"},
+			{"add", "."},
+			{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+		} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repo
+			out, err := cmd.CombinedOutput()
+			require.NoError(t, err, string(out))
+		}
+		out, err := exec.Command("git", "-C", repo, "rev-parse", "--short", "HEAD").Output()
+		require.NoError(t, err)
+		sha := strings.TrimSpace(string(out))
+
+		assert.Equal(t, "20250304-050607-"+sha, resolveDeployVersion("auto", repo, now))
+	})
+}
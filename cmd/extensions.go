@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -26,7 +28,35 @@ type ExtensionsService interface {
 	Upload(ctx context.Context, body kernel.ExtensionUploadParams, opts ...option.RequestOption) (res *kernel.ExtensionUploadResponse, err error)
 }
 
-type ExtensionsListInput struct{}
+type ExtensionsListInput struct {
+	Output string
+	Count  bool
+	// Unused keeps extensions that have never been used.
+	Unused bool
+	// UsedBefore keeps extensions last used longer ago than this. Combined
+	// with Unused, either condition selects an extension.
+	UsedBefore time.Duration
+}
+
+// filterStaleExtensions applies the --unused and --used-before filters
+// relative to now. With neither set, items is returned unchanged.
+func filterStaleExtensions(items []kernel.ExtensionListResponse, unused bool, usedBefore time.Duration, now time.Time) []kernel.ExtensionListResponse {
+	if !unused && usedBefore <= 0 {
+		return items
+	}
+	cutoff := now.Add(-usedBefore)
+	var out []kernel.ExtensionListResponse
+	for _, it := range items {
+		neverUsed := it.LastUsedAt.IsZero()
+		switch {
+		case unused && neverUsed:
+			out = append(out, it)
+		case usedBefore > 0 && !neverUsed && it.LastUsedAt.Before(cutoff):
+			out = append(out, it)
+		}
+	}
+	return out
+}
 
 type ExtensionsDeleteInput struct {
 	Identifier  string
@@ -36,6 +66,7 @@ type ExtensionsDeleteInput struct {
 type ExtensionsDownloadInput struct {
 	Identifier string
 	Output     string
+	Retry      RetryOptions
 }
 
 type ExtensionsDownloadWebStoreInput struct {
@@ -54,18 +85,43 @@ type ExtensionsCmd struct {
 	extensions ExtensionsService
 }
 
-func (e ExtensionsCmd) List(ctx context.Context, _ ExtensionsListInput) error {
-	pterm.Info.Println("Fetching extensions...")
-	items, err := e.extensions.List(ctx)
+func (e ExtensionsCmd) List(ctx context.Context, in ExtensionsListInput) error {
+	if in.Output != "" && in.Output != "json" {
+		pterm.Error.Println("unsupported --output value: use 'json'")
+		return nil
+	}
+	if in.Output == "" && !in.Count {
+		pterm.Info.Println("Fetching extensions...")
+	}
+	res, err := e.extensions.List(ctx)
 	if err != nil {
 		return util.CleanedUpSdkError{Err: err}
 	}
-	if items == nil || len(*items) == 0 {
+	var items []kernel.ExtensionListResponse
+	if res != nil {
+		items = filterStaleExtensions(*res, in.Unused, in.UsedBefore, time.Now())
+	}
+	if in.Count {
+		return printCount(len(items), in.Output)
+	}
+	if in.Output == "json" {
+		if len(items) == 0 {
+			fmt.Println("[]")
+			return nil
+		}
+		bs, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bs))
+		return nil
+	}
+	if len(items) == 0 {
 		pterm.Info.Println("No extensions found")
 		return nil
 	}
-	rows := pterm.TableData{{"Extension ID", "Name", "Created At", "Size (bytes)", "Last Used At"}}
-	for _, it := range *items {
+	rows := pterm.TableData{{"Extension ID", "Name", "Created At", "Size", "Last Used At"}}
+	for _, it := range items {
 		name := it.Name
 		if name == "" {
 			name = "-"
@@ -74,8 +130,8 @@ func (e ExtensionsCmd) List(ctx context.Context, _ ExtensionsListInput) error {
 			it.ID,
 			name,
 			util.FormatLocal(it.CreatedAt),
-			fmt.Sprintf("%d", it.SizeBytes),
-			util.FormatLocal(it.LastUsedAt),
+			util.HumanBytes(it.SizeBytes),
+			util.FormatRelative(it.LastUsedAt),
 		})
 	}
 	PrintTableNoPad(rows, true)
@@ -90,9 +146,7 @@ func (e ExtensionsCmd) Delete(ctx context.Context, in ExtensionsDeleteInput) err
 
 	if !in.SkipConfirm {
 		msg := fmt.Sprintf("Are you sure you want to delete extension '%s'?", in.Identifier)
-		pterm.DefaultInteractiveConfirm.DefaultText = msg
-		ok, _ := pterm.DefaultInteractiveConfirm.Show()
-		if !ok {
+		if !confirmPrompt(msg) {
 			pterm.Info.Println("Deletion cancelled")
 			return nil
 		}
@@ -114,59 +168,43 @@ func (e ExtensionsCmd) Download(ctx context.Context, in ExtensionsDownloadInput)
 		pterm.Error.Println("Missing identifier")
 		return nil
 	}
-	res, err := e.extensions.Download(ctx, in.Identifier)
-	if err != nil {
-		return util.CleanedUpSdkError{Err: err}
-	}
-	defer res.Body.Close()
 	if in.Output == "" {
 		pterm.Error.Println("Missing --to output directory")
-		_, _ = io.Copy(io.Discard, res.Body)
 		return nil
 	}
 
 	outDir, err := filepath.Abs(in.Output)
 	if err != nil {
 		pterm.Error.Printf("Failed to resolve output path: %v\n", err)
-		_, _ = io.Copy(io.Discard, res.Body)
 		return nil
 	}
-	// Create directory if not exists; if exists, ensure empty
-	if st, err := os.Stat(outDir); err == nil {
-		if !st.IsDir() {
-			pterm.Error.Printf("Output path exists and is not a directory: %s\n", outDir)
-			_, _ = io.Copy(io.Discard, res.Body)
-			return nil
-		}
-		entries, _ := os.ReadDir(outDir)
-		if len(entries) > 0 {
-			pterm.Error.Printf("Output directory must be empty: %s\n", outDir)
-			_, _ = io.Copy(io.Discard, res.Body)
-			return nil
-		}
-	} else {
-		if err := os.MkdirAll(outDir, 0o755); err != nil {
-			pterm.Error.Printf("Failed to create output directory: %v\n", err)
-			_, _ = io.Copy(io.Discard, res.Body)
-			return nil
-		}
+	if err := prepareExtractDir(outDir); err != nil {
+		pterm.Error.Println(err.Error())
+		return nil
 	}
 
 	// Write response to a temp zip, then extract
 	tmpZip, err := os.CreateTemp("", "kernel-ext-*.zip")
 	if err != nil {
 		pterm.Error.Printf("Failed to create temp zip: %v\n", err)
-		_, _ = io.Copy(io.Discard, res.Body)
 		return nil
 	}
 	tmpName := tmpZip.Name()
+	_ = tmpZip.Close()
 	defer func() { _ = os.Remove(tmpName) }()
-	if _, err := io.Copy(tmpZip, res.Body); err != nil {
-		_ = tmpZip.Close()
-		pterm.Error.Printf("Failed to read response: %v\n", err)
-		return nil
+	// A connection dropped mid-body surfaces from io.Copy, so the whole
+	// request is retried rather than just the initial call.
+	err = withRetry(ctx, in.Retry, func() error {
+		return e.downloadTo(ctx, in.Identifier, tmpName)
+	})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
 	}
-	_ = tmpZip.Close()
+	zr, err := zip.OpenReader(tmpName)
+	if err != nil {
+		return fmt.Errorf("corrupt download: extension archive is not a valid zip: %w", err)
+	}
+	_ = zr.Close()
 	if err := util.Unzip(tmpName, outDir); err != nil {
 		pterm.Error.Printf("Failed to extract zip: %v\n", err)
 		return nil
@@ -175,6 +213,44 @@ func (e ExtensionsCmd) Download(ctx context.Context, in ExtensionsDownloadInput)
 	return nil
 }
 
+// prepareExtractDir makes sure dir can receive an extracted archive: it is
+// created when missing and must be empty when it already exists.
+func prepareExtractDir(dir string) error {
+	st, err := os.Stat(dir)
+	if err != nil {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		return nil
+	}
+	if !st.IsDir() {
+		return fmt.Errorf("output path exists and is not a directory: %s", dir)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) > 0 {
+		return fmt.Errorf("output directory must be empty: %s", dir)
+	}
+	return nil
+}
+
+// downloadTo fetches the extension archive into path, truncating whatever a
+// previous attempt left behind.
+func (e ExtensionsCmd) downloadTo(ctx context.Context, identifier, path string) error {
+	res, err := e.extensions.Download(ctx, identifier)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, res.Body); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	return f.Close()
+}
+
 func (e ExtensionsCmd) DownloadWebStore(ctx context.Context, in ExtensionsDownloadWebStoreInput) error {
 	if in.URL == "" {
 		pterm.Error.Println("Missing URL argument")
@@ -211,24 +287,10 @@ func (e ExtensionsCmd) DownloadWebStore(ctx context.Context, in ExtensionsDownlo
 		_, _ = io.Copy(io.Discard, res.Body)
 		return nil
 	}
-	if st, err := os.Stat(outDir); err == nil {
-		if !st.IsDir() {
-			pterm.Error.Printf("Output path exists and is not a directory: %s\n", outDir)
-			_, _ = io.Copy(io.Discard, res.Body)
-			return nil
-		}
-		entries, _ := os.ReadDir(outDir)
-		if len(entries) > 0 {
-			pterm.Error.Printf("Output directory must be empty: %s\n", outDir)
-			_, _ = io.Copy(io.Discard, res.Body)
-			return nil
-		}
-	} else {
-		if err := os.MkdirAll(outDir, 0o755); err != nil {
-			pterm.Error.Printf("Failed to create output directory: %v\n", err)
-			_, _ = io.Copy(io.Discard, res.Body)
-			return nil
-		}
+	if err := prepareExtractDir(outDir); err != nil {
+		pterm.Error.Println(err.Error())
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil
 	}
 
 	// Save to temp zip then extract
@@ -262,49 +324,59 @@ func (e ExtensionsCmd) Upload(ctx context.Context, in ExtensionsUploadInput) err
 	if in.Dir == "" {
 		return fmt.Errorf("missing directory argument")
 	}
-	absDir, err := filepath.Abs(in.Dir)
+	item, err := uploadExtensionDir(ctx, e.extensions, in.Dir, in.Name, "")
+	if err != nil {
+		return err
+	}
+
+	name := item.Name
+	if name == "" {
+		name = "-"
+	}
+	rows := pterm.TableData{{"Property", "Value"}}
+	rows = append(rows, []string{"ID", item.ID})
+	rows = append(rows, []string{"Name", name})
+	rows = append(rows, []string{"Created At", util.FormatLocal(item.CreatedAt)})
+	rows = append(rows, []string{"Size", util.HumanBytes(item.SizeBytes)})
+	PrintTableNoPad(rows, true)
+	return nil
+}
+
+// uploadExtensionDir zips an unpacked extension directory and uploads it,
+// optionally under name. Progress goes to stderr when output is set.
+func uploadExtensionDir(ctx context.Context, svc ExtensionsService, dir, name, output string) (*kernel.ExtensionUploadResponse, error) {
+	absDir, err := filepath.Abs(dir)
 	if err != nil {
-		return fmt.Errorf("failed to resolve directory: %w", err)
+		return nil, fmt.Errorf("failed to resolve directory: %w", err)
 	}
 	stat, err := os.Stat(absDir)
 	if err != nil || !stat.IsDir() {
-		return fmt.Errorf("directory %s does not exist", absDir)
+		return nil, fmt.Errorf("directory %s does not exist", absDir)
 	}
 
 	tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("kernel_ext_%d.zip", time.Now().UnixNano()))
-	pterm.Info.Println("Zipping extension directory...")
+	statusPrinter(pterm.Info, output).Println("Zipping extension directory...")
 	if err := util.ZipDirectory(absDir, tmpFile); err != nil {
-		pterm.Error.Println("Failed to zip directory")
-		return err
+		statusPrinter(pterm.Error, output).Println("Failed to zip directory")
+		return nil, err
 	}
 	defer os.Remove(tmpFile)
 
 	f, err := os.Open(tmpFile)
 	if err != nil {
-		return fmt.Errorf("failed to open temp zip: %w", err)
+		return nil, fmt.Errorf("failed to open temp zip: %w", err)
 	}
 	defer f.Close()
 
 	params := kernel.ExtensionUploadParams{File: f}
-	if in.Name != "" {
-		params.Name = kernel.Opt(in.Name)
+	if name != "" {
+		params.Name = kernel.Opt(name)
 	}
-	item, err := e.extensions.Upload(ctx, params)
+	item, err := svc.Upload(ctx, params)
 	if err != nil {
-		return util.CleanedUpSdkError{Err: err}
+		return nil, util.CleanedUpSdkError{Err: err}
 	}
-
-	name := item.Name
-	if name == "" {
-		name = "-"
-	}
-	rows := pterm.TableData{{"Property", "Value"}}
-	rows = append(rows, []string{"ID", item.ID})
-	rows = append(rows, []string{"Name", name})
-	rows = append(rows, []string{"Created At", util.FormatLocal(item.CreatedAt)})
-	rows = append(rows, []string{"Size (bytes)", fmt.Sprintf("%d", item.SizeBytes)})
-	PrintTableNoPad(rows, true)
-	return nil
+	return item, nil
 }
 
 // --- Cobra wiring ---
@@ -323,8 +395,12 @@ var extensionsListCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getKernelClient(cmd)
 		svc := client.Extensions
+		out, _ := cmd.Flags().GetString("output")
+		count, _ := cmd.Flags().GetBool("count")
+		unused, _ := cmd.Flags().GetBool("unused")
+		usedBefore, _ := cmd.Flags().GetDuration("used-before")
 		e := ExtensionsCmd{extensions: &svc}
-		return e.List(cmd.Context(), ExtensionsListInput{})
+		return e.List(cmd.Context(), ExtensionsListInput{Output: out, Count: count, Unused: unused, UsedBefore: usedBefore})
 	},
 }
 
@@ -348,9 +424,13 @@ var extensionsDownloadCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getKernelClient(cmd)
 		out, _ := cmd.Flags().GetString("to")
+		retry, err := getRetryOptions(cmd)
+		if err != nil {
+			return err
+		}
 		svc := client.Extensions
 		e := ExtensionsCmd{extensions: &svc}
-		return e.Download(cmd.Context(), ExtensionsDownloadInput{Identifier: args[0], Output: out})
+		return e.Download(cmd.Context(), ExtensionsDownloadInput{Identifier: args[0], Output: out, Retry: retry})
 	},
 }
 
@@ -388,8 +468,13 @@ func init() {
 	extensionsCmd.AddCommand(extensionsDownloadWebStoreCmd)
 	extensionsCmd.AddCommand(extensionsUploadCmd)
 
-	extensionsDeleteCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	extensionsListCmd.Flags().StringP("output", "o", "", "Output format: json for raw API response")
+	extensionsListCmd.Flags().Bool("count", false, "Print only the number of extensions")
+	extensionsListCmd.Flags().Bool("unused", false, "Only show extensions that have never been used")
+	extensionsListCmd.Flags().Duration("used-before", 0, "Only show extensions last used longer ago than this (e.g. 720h); combined with --unused, either matches")
+	addYesFlag(extensionsDeleteCmd)
 	extensionsDownloadCmd.Flags().String("to", "", "Output zip file path")
+	addRetryFlagsWithDefault(extensionsDownloadCmd, 3)
 	extensionsDownloadWebStoreCmd.Flags().String("to", "", "Output zip file path for the downloaded archive")
 	extensionsDownloadWebStoreCmd.Flags().String("os", "", "Target OS: mac, win, or linux (default linux)")
 	extensionsUploadCmd.Flags().String("name", "", "Optional unique extension name")
@@ -4,14 +4,18 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"github.com/onkernel/cli/pkg/table"
+	"github.com/onkernel/cli/pkg/util"
 	"github.com/onkernel/kernel-go-sdk"
 	"github.com/onkernel/kernel-go-sdk/option"
 	"github.com/pterm/pterm"
@@ -106,6 +110,71 @@ func TestExtensionsList_WithRows(t *testing.T) {
 	assert.Contains(t, out, "e2")
 }
 
+func TestExtensionsList_RawOutput(t *testing.T) {
+	created := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	rows := []kernel.ExtensionListResponse{{ID: "e1", Name: "alpha", CreatedAt: created, SizeBytes: 2048, LastUsedAt: created.Add(time.Hour)}}
+	fake := &FakeExtensionsService{ListFunc: func(ctx context.Context, opts ...option.RequestOption) (*[]kernel.ExtensionListResponse, error) {
+		return &rows, nil
+	}}
+	e := ExtensionsCmd{extensions: fake}
+
+	buf := captureExtensionsOutput(t)
+	_ = e.List(context.Background(), ExtensionsListInput{})
+	assert.Contains(t, buf.String(), "2.0 KiB")
+	assert.NotContains(t, buf.String(), "2025-03-04T05:06:07Z")
+	assert.Contains(t, buf.String(), " ago")
+
+	// Scripts pair --raw with --plain, which also keeps cells from being
+	// truncated to the terminal width.
+	util.RawOutput, table.Plain = true, true
+	t.Cleanup(func() { util.RawOutput, table.Plain = false, false })
+	buf.Reset()
+	_ = e.List(context.Background(), ExtensionsListInput{})
+	out := buf.String()
+	assert.Contains(t, out, "2048")
+	assert.NotContains(t, out, "KiB")
+	assert.Contains(t, out, "2025-03-04T05:06:07Z")
+	assert.Contains(t, out, "2025-03-04T06:06:07Z")
+}
+
+func TestFilterStaleExtensions(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	items := []kernel.ExtensionListResponse{
+		{ID: "never"},
+		{ID: "recent", LastUsedAt: now.Add(-time.Hour)},
+		{ID: "stale", LastUsedAt: now.Add(-60 * 24 * time.Hour)},
+	}
+	ids := func(items []kernel.ExtensionListResponse) []string {
+		var out []string
+		for _, it := range items {
+			out = append(out, it.ID)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"never", "recent", "stale"}, ids(filterStaleExtensions(items, false, 0, now)))
+	assert.Equal(t, []string{"never"}, ids(filterStaleExtensions(items, true, 0, now)))
+	assert.Equal(t, []string{"stale"}, ids(filterStaleExtensions(items, false, 30*24*time.Hour, now)))
+	assert.Equal(t, []string{"never", "stale"}, ids(filterStaleExtensions(items, true, 30*24*time.Hour, now)))
+	assert.Empty(t, filterStaleExtensions(items, false, 90*24*time.Hour, now))
+}
+
+func TestExtensionsList_UnusedJSON(t *testing.T) {
+	rows := []kernel.ExtensionListResponse{{ID: "e1", Name: "alpha"}, {ID: "e2", Name: "beta", LastUsedAt: time.Now()}}
+	fake := &FakeExtensionsService{ListFunc: func(ctx context.Context, opts ...option.RequestOption) (*[]kernel.ExtensionListResponse, error) {
+		return &rows, nil
+	}}
+	e := ExtensionsCmd{extensions: fake}
+	out := captureStdout(t, func() {
+		assert.NoError(t, e.List(context.Background(), ExtensionsListInput{Unused: true, Output: "json"}))
+	})
+	var got []map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(out), &got), out)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "e1", got[0]["id"])
+	}
+}
+
 func TestExtensionsDelete_SkipConfirm(t *testing.T) {
 	buf := captureExtensionsOutput(t)
 	fake := &FakeExtensionsService{}
@@ -159,6 +228,48 @@ func TestExtensionsDownload_ExtractsToDir(t *testing.T) {
 	_ = os.RemoveAll(outDir)
 }
 
+func TestExtensionsDownload_CorruptZip(t *testing.T) {
+	captureExtensionsOutput(t)
+	fake := &FakeExtensionsService{DownloadFunc: func(ctx context.Context, idOrName string, opts ...option.RequestOption) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("<html>not a zip</html>")), Header: http.Header{}}, nil
+	}}
+	e := ExtensionsCmd{extensions: fake}
+	outDir := filepath.Join(t.TempDir(), "out")
+	err := e.Download(context.Background(), ExtensionsDownloadInput{Identifier: "e1", Output: outDir})
+	assert.ErrorContains(t, err, "corrupt download")
+	entries, _ := os.ReadDir(outDir)
+	assert.Empty(t, entries)
+}
+
+func TestExtensionsDownload_RetriesTransientFailure(t *testing.T) {
+	buf := captureExtensionsOutput(t)
+	var zbuf bytes.Buffer
+	zw := zip.NewWriter(&zbuf)
+	w, _ := zw.Create("manifest.json")
+	_, _ = w.Write([]byte("{}"))
+	_ = zw.Close()
+
+	calls := 0
+	fake := &FakeExtensionsService{DownloadFunc: func(ctx context.Context, idOrName string, opts ...option.RequestOption) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			// Connection dropped halfway through the body.
+			half := io.LimitReader(bytes.NewReader(zbuf.Bytes()), int64(zbuf.Len()/2))
+			body := io.MultiReader(half, iotest.ErrReader(io.ErrUnexpectedEOF))
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(body), Header: http.Header{}}, nil
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(zbuf.Bytes())), Header: http.Header{}}, nil
+	}}
+	e := ExtensionsCmd{extensions: fake}
+	outDir := filepath.Join(t.TempDir(), "out")
+	err := e.Download(context.Background(), ExtensionsDownloadInput{Identifier: "e1", Output: outDir, Retry: RetryOptions{Retries: 2, Backoff: time.Millisecond}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	_, statErr := os.Stat(filepath.Join(outDir, "manifest.json"))
+	assert.NoError(t, statErr)
+	assert.Contains(t, buf.String(), "Extracted extension to "+outDir)
+}
+
 func TestExtensionsDownloadWebStore_ExtractsToDir(t *testing.T) {
 	buf := captureExtensionsOutput(t)
 	var zbuf bytes.Buffer
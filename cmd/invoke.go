@@ -33,15 +33,57 @@ var invocationHistoryCmd = &cobra.Command{
 	RunE:  runInvocationHistory,
 }
 
+var invocationCancelCmd = &cobra.Command{
+	Use:   "cancel <invocation-id>",
+	Short: "Cancel a queued or running invocation",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runInvocationCancel,
+}
+
+// InvocationsService defines the subset of the Kernel SDK invocation client that we use.
+type InvocationsService interface {
+	Get(ctx context.Context, id string, opts ...option.RequestOption) (res *kernel.InvocationGetResponse, err error)
+	Update(ctx context.Context, id string, body kernel.InvocationUpdateParams, opts ...option.RequestOption) (res *kernel.InvocationUpdateResponse, err error)
+	DeleteBrowsers(ctx context.Context, id string, opts ...option.RequestOption) (err error)
+}
+
+// InvocationsCmd handles invocation operations independent of cobra.
+type InvocationsCmd struct {
+	invocations InvocationsService
+}
+
+type InvocationsCancelInput struct {
+	ID          string
+	SkipConfirm bool
+	Output      string
+}
+
+// invocationCancelResult is printed by `invoke cancel --output json`.
+type invocationCancelResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	// Cancelled is false when the invocation had already finished or no
+	// longer exists, in which case nothing was changed.
+	Cancelled bool `json:"cancelled"`
+}
+
+// cancelledInvocationOutput is recorded as the output of invocations
+// cancelled from the CLI.
+const cancelledInvocationOutput = `{"error":"Invocation cancelled by user"}`
+
 func init() {
 	invokeCmd.Flags().StringP("version", "v", "latest", "Specify a version of the app to invoke (optional, defaults to 'latest')")
 	invokeCmd.Flags().StringP("payload", "p", "", "JSON payload for the invocation (optional)")
 	invokeCmd.Flags().BoolP("sync", "s", false, "Invoke synchronously (default false). A synchronous invocation will open a long-lived HTTP POST to the Kernel API to wait for the invocation to complete. This will time out after 60 seconds, so only use this option if you expect your invocation to complete in less than 60 seconds. The default is to invoke asynchronously, in which case the CLI will open an SSE connection to the Kernel API after submitting the invocation and wait for the invocation to complete.")
 
 	invocationHistoryCmd.Flags().Int("limit", 100, "Max invocations to return (default 100)")
-	invocationHistoryCmd.Flags().StringP("app", "a", "", "Filter by app name")
+	invocationHistoryCmd.Flags().StringP("app", "a", "", "Filter by app name (@current for the app in this directory)")
 	invocationHistoryCmd.Flags().String("version", "", "Filter by invocation version")
 	invokeCmd.AddCommand(invocationHistoryCmd)
+
+	addYesFlag(invocationCancelCmd)
+	invocationCancelCmd.Flags().StringP("output", "o", "", "Output format: json for {id, status, cancelled}")
+	invokeCmd.AddCommand(invocationCancelCmd)
 }
 
 func runInvoke(cmd *cobra.Command, args []string) error {
@@ -50,7 +92,10 @@ func runInvoke(cmd *cobra.Command, args []string) error {
 	}
 	startTime := time.Now()
 	client := getKernelClient(cmd)
-	appName := args[0]
+	appName, err := resolveAppName(args[0])
+	if err != nil {
+		return err
+	}
 	actionName := args[1]
 	version, _ := cmd.Flags().GetString("version")
 	if version == "" {
@@ -122,7 +167,7 @@ func runInvoke(cmd *cobra.Command, args []string) error {
 				resp.ID,
 				kernel.InvocationUpdateParams{
 					Status: kernel.InvocationUpdateParamsStatusFailed,
-					Output: kernel.Opt(`{"error":"Invocation cancelled by user"}`),
+					Output: kernel.Opt(cancelledInvocationOutput),
 				},
 				option.WithRequestTimeout(30*time.Second),
 			); err != nil {
@@ -219,6 +264,10 @@ func runInvocationHistory(cmd *cobra.Command, args []string) error {
 	lim, _ := cmd.Flags().GetInt("limit")
 	appFilter, _ := cmd.Flags().GetString("app")
 	versionFilter, _ := cmd.Flags().GetString("version")
+	appFilter, err := resolveAppName(appFilter)
+	if err != nil {
+		return err
+	}
 
 	// Build parameters for the API call
 	params := kernel.InvocationListParams{
@@ -299,3 +348,69 @@ func runInvocationHistory(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// Cancel marks a queued or running invocation as failed and releases its
+// browsers, the same cleanup `invoke` performs on Ctrl-C. Invocations that
+// already finished or no longer exist are reported and left alone.
+func (c InvocationsCmd) Cancel(ctx context.Context, in InvocationsCancelInput) error {
+	if !checkOutput(in.Output, outputJSON) {
+		return nil
+	}
+	inv, err := c.invocations.Get(ctx, in.ID)
+	if err != nil {
+		if util.IsNotFound(err) {
+			return c.printCancelResult(invocationCancelResult{ID: in.ID, Status: "not_found"}, in.Output)
+		}
+		return util.CleanedUpSdkError{Err: err}
+	}
+	if inv.Status == kernel.InvocationGetResponseStatusSucceeded || inv.Status == kernel.InvocationGetResponseStatusFailed {
+		return c.printCancelResult(invocationCancelResult{ID: in.ID, Status: string(inv.Status)}, in.Output)
+	}
+
+	if !in.SkipConfirm {
+		msg := fmt.Sprintf("Are you sure you want to cancel %s invocation %s (%s/%s)?", inv.Status, in.ID, inv.AppName, inv.ActionName)
+		if !confirmPrompt(msg) {
+			pterm.Info.Println("Cancel aborted")
+			return nil
+		}
+	}
+
+	res, err := c.invocations.Update(ctx, in.ID, kernel.InvocationUpdateParams{
+		Status: kernel.InvocationUpdateParamsStatusFailed,
+		Output: kernel.Opt(cancelledInvocationOutput),
+	})
+	if err != nil {
+		return util.CleanedUpSdkError{Err: err}
+	}
+	if err := c.invocations.DeleteBrowsers(ctx, in.ID); err != nil && !util.IsNotFound(err) {
+		warn := pterm.Warning
+		if in.Output == outputJSON {
+			warn = *warn.WithWriter(os.Stderr)
+		}
+		warn.Printf("Invocation cancelled, but failed to delete its browsers: %v\n", util.CleanedUpSdkError{Err: err})
+	}
+	return c.printCancelResult(invocationCancelResult{ID: in.ID, Status: string(res.Status), Cancelled: true}, in.Output)
+}
+
+func (c InvocationsCmd) printCancelResult(res invocationCancelResult, output string) error {
+	if output == outputJSON {
+		return printStructured(res, outputJSON)
+	}
+	switch {
+	case res.Cancelled:
+		pterm.Success.Printf("Cancelled invocation %s (status: %s)\n", res.ID, res.Status)
+	case res.Status == "not_found":
+		pterm.Info.Printf("Invocation %s not found; nothing to cancel\n", res.ID)
+	default:
+		pterm.Info.Printf("Invocation %s already %s; nothing to cancel\n", res.ID, res.Status)
+	}
+	return nil
+}
+
+func runInvocationCancel(cmd *cobra.Command, args []string) error {
+	client := getKernelClient(cmd)
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	output, _ := cmd.Flags().GetString("output")
+	c := InvocationsCmd{invocations: &client.Invocations}
+	return c.Cancel(cmd.Context(), InvocationsCancelInput{ID: args[0], SkipConfirm: skipConfirm, Output: output})
+}
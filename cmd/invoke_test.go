@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/onkernel/kernel-go-sdk"
+	"github.com/onkernel/kernel-go-sdk/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeInvocationsService implements InvocationsService
+type FakeInvocationsService struct {
+	GetFunc            func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.InvocationGetResponse, error)
+	UpdateFunc         func(ctx context.Context, id string, body kernel.InvocationUpdateParams, opts ...option.RequestOption) (*kernel.InvocationUpdateResponse, error)
+	DeleteBrowsersFunc func(ctx context.Context, id string, opts ...option.RequestOption) error
+}
+
+func (f *FakeInvocationsService) Get(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.InvocationGetResponse, error) {
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, id, opts...)
+	}
+	return &kernel.InvocationGetResponse{ID: id, Status: kernel.InvocationGetResponseStatusRunning}, nil
+}
+
+func (f *FakeInvocationsService) Update(ctx context.Context, id string, body kernel.InvocationUpdateParams, opts ...option.RequestOption) (*kernel.InvocationUpdateResponse, error) {
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, id, body, opts...)
+	}
+	return &kernel.InvocationUpdateResponse{ID: id, Status: kernel.InvocationUpdateResponseStatus(body.Status)}, nil
+}
+
+func (f *FakeInvocationsService) DeleteBrowsers(ctx context.Context, id string, opts ...option.RequestOption) error {
+	if f.DeleteBrowsersFunc != nil {
+		return f.DeleteBrowsersFunc(ctx, id, opts...)
+	}
+	return nil
+}
+
+func TestInvocationsCancel_Running(t *testing.T) {
+	setupStdoutCapture(t)
+	stubConfirm(t, true)
+	var updated kernel.InvocationUpdateParams
+	deleted := false
+	fake := &FakeInvocationsService{
+		UpdateFunc: func(ctx context.Context, id string, body kernel.InvocationUpdateParams, opts ...option.RequestOption) (*kernel.InvocationUpdateResponse, error) {
+			updated = body
+			return &kernel.InvocationUpdateResponse{ID: id, Status: kernel.InvocationUpdateResponseStatusFailed}, nil
+		},
+		DeleteBrowsersFunc: func(ctx context.Context, id string, opts ...option.RequestOption) error {
+			deleted = true
+			return nil
+		},
+	}
+	c := InvocationsCmd{invocations: fake}
+
+	require.NoError(t, c.Cancel(context.Background(), InvocationsCancelInput{ID: "inv_1"}))
+	assert.Equal(t, kernel.InvocationUpdateParamsStatusFailed, updated.Status)
+	assert.True(t, deleted)
+	assert.Contains(t, outBuf.String(), "Cancelled invocation inv_1 (status: failed)")
+}
+
+func TestInvocationsCancel_AlreadyTerminal(t *testing.T) {
+	setupStdoutCapture(t)
+	fake := &FakeInvocationsService{
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.InvocationGetResponse, error) {
+			return &kernel.InvocationGetResponse{ID: id, Status: kernel.InvocationGetResponseStatusSucceeded}, nil
+		},
+		UpdateFunc: func(ctx context.Context, id string, body kernel.InvocationUpdateParams, opts ...option.RequestOption) (*kernel.InvocationUpdateResponse, error) {
+			t.Fatal("Update should not be called for a finished invocation")
+			return nil, nil
+		},
+	}
+	c := InvocationsCmd{invocations: fake}
+
+	out := captureStdout(t, func() {
+		require.NoError(t, c.Cancel(context.Background(), InvocationsCancelInput{ID: "inv_1", SkipConfirm: true, Output: "json"}))
+	})
+	var res invocationCancelResult
+	require.NoError(t, json.Unmarshal([]byte(out), &res))
+	assert.Equal(t, invocationCancelResult{ID: "inv_1", Status: "succeeded"}, res)
+
+	fake.GetFunc = func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.InvocationGetResponse, error) {
+		return nil, &kernel.Error{StatusCode: http.StatusNotFound}
+	}
+	require.NoError(t, c.Cancel(context.Background(), InvocationsCancelInput{ID: "inv_2", SkipConfirm: true}))
+	assert.Contains(t, outBuf.String(), "Invocation inv_2 not found; nothing to cancel")
+}
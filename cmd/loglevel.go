@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/onkernel/kernel-go-sdk/shared"
+)
+
+// Log severities, lowest first, used by --level filtering.
+const (
+	logLevelDebug = iota
+	logLevelInfo
+	logLevelWarn
+	logLevelError
+)
+
+// logLevelNames maps the names accepted by --level, and the markers found in
+// log lines, to a severity.
+var logLevelNames = map[string]int{
+	"trace":    logLevelDebug,
+	"debug":    logLevelDebug,
+	"verbose":  logLevelDebug,
+	"info":     logLevelInfo,
+	"warn":     logLevelWarn,
+	"warning":  logLevelWarn,
+	"err":      logLevelError,
+	"error":    logLevelError,
+	"crit":     logLevelError,
+	"critical": logLevelError,
+	"fatal":    logLevelError,
+	"panic":    logLevelError,
+}
+
+// logfmtLevelRegex matches level=... in logfmt-style lines.
+var logfmtLevelRegex = regexp.MustCompile(`\blevel=["']?([A-Za-z]+)`)
+
+// logMarkerRegex matches upper-case severity markers such as [ERROR], WARN
+// or Chromium's :ERROR:. Lower-case words are ignored so messages that merely
+// mention "error" are not misclassified.
+var logMarkerRegex = regexp.MustCompile(`\b(TRACE|DEBUG|VERBOSE|INFO|WARNING|WARN|ERROR|ERR|CRITICAL|CRIT|FATAL|PANIC)\b`)
+
+// parseLogLevel resolves a --level value.
+func parseLogLevel(name string) (int, bool) {
+	lvl, ok := logLevelNames[strings.ToLower(strings.TrimSpace(name))]
+	return lvl, ok
+}
+
+// logEventLevel returns the severity of ev: a structured "level" field when
+// the API sends one, otherwise the first marker in the message. Lines with no
+// marker count as info.
+func logEventLevel(ev shared.LogEvent) int {
+	if f, ok := ev.JSON.ExtraFields["level"]; ok {
+		raw := f.Raw()
+		if s, err := strconv.Unquote(raw); err == nil {
+			raw = s
+		}
+		if lvl, ok := parseLogLevel(raw); ok {
+			return lvl
+		}
+	}
+	if m := logfmtLevelRegex.FindStringSubmatch(ev.Message); m != nil {
+		if lvl, ok := parseLogLevel(m[1]); ok {
+			return lvl
+		}
+	}
+	if m := logMarkerRegex.FindString(ev.Message); m != "" {
+		return logLevelNames[strings.ToLower(m)]
+	}
+	return logLevelInfo
+}
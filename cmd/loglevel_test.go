@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onkernel/kernel-go-sdk/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogEventLevel(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want int
+	}{
+		{msg: "2025-01-01 12:00:00,000 INFO spawned: 'chromium' with pid 42", want: logLevelInfo},
+		{msg: "2025-01-01 12:00:00,000 WARN received SIGTERM indicating exit request", want: logLevelWarn},
+		{msg: "2025-01-01 12:00:00,000 CRIT Server 'unix_http_server' running without any HTTP authentication checking", want: logLevelError},
+		{msg: "[ERROR] failed to bind port", want: logLevelError},
+		{msg: "[1234:5678:0101/120000.000000:ERROR:gpu_init.cc(523)] Passthrough is not supported", want: logLevelError},
+		{msg: `time=2025-01-01T12:00:00Z level=warn msg="slow request"`, want: logLevelWarn},
+		{msg: "retrying after error: connection reset", want: logLevelInfo},
+		{msg: "plain line", want: logLevelInfo},
+	}
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			assert.Equal(t, tt.want, logEventLevel(shared.LogEvent{Message: tt.msg}))
+		})
+	}
+}
+
+func TestLogEventLevel_StructuredField(t *testing.T) {
+	var ev shared.LogEvent
+	require.NoError(t, json.Unmarshal([]byte(`{"event":"log","message":"INFO looks fine","timestamp":"2025-01-01T00:00:00Z","level":"error"}`), &ev))
+	assert.Equal(t, logLevelError, logEventLevel(ev))
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onkernel/kernel-go-sdk/shared"
+	"github.com/pterm/pterm"
+)
+
+// Orderings accepted by --order-by on log commands.
+const (
+	logOrderArrival   = "arrival"
+	logOrderTimestamp = "timestamp"
+)
+
+// defaultLogReorderWindow is how long an event is held back when ordering by
+// timestamp, giving slower sources a chance to deliver earlier events.
+const defaultLogReorderWindow = 500 * time.Millisecond
+
+// maxLogReorderBuffer bounds how many events are held back at once; when it
+// is exceeded the oldest event is emitted early.
+const maxLogReorderBuffer = 1000
+
+// logEventStream is the subset of *ssestream.Stream[shared.LogEvent] used when
+// merging log streams.
+type logEventStream interface {
+	Next() bool
+	Current() shared.LogEvent
+	Err() error
+	Close() error
+}
+
+// logSource is one named stream feeding mergeLogStreams.
+type logSource struct {
+	Name   string
+	Stream logEventStream
+}
+
+// mergeOptions controls how mergeLogStreams orders events.
+type mergeOptions struct {
+	// OrderBy is logOrderArrival (the default) or logOrderTimestamp.
+	OrderBy string
+	// Window is the look-behind used with logOrderTimestamp.
+	Window time.Duration
+}
+
+type mergedLogEvent struct {
+	source  string
+	event   shared.LogEvent
+	arrived time.Time
+}
+
+// logEventHeap orders buffered events by timestamp, then arrival.
+type logEventHeap []mergedLogEvent
+
+func (h logEventHeap) Len() int { return len(h) }
+func (h logEventHeap) Less(i, j int) bool {
+	if !h[i].event.Timestamp.Equal(h[j].event.Timestamp) {
+		return h[i].event.Timestamp.Before(h[j].event.Timestamp)
+	}
+	return h[i].arrived.Before(h[j].arrived)
+}
+func (h logEventHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *logEventHeap) Push(x any)   { *h = append(*h, x.(mergedLogEvent)) }
+func (h *logEventHeap) Pop() any {
+	old := *h
+	ev := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return ev
+}
+
+// mergeLogStreams reads all sources concurrently and calls emit for every
+// event. With logOrderTimestamp, events are held for up to opts.Window and
+// released in timestamp order; events arriving later than the window can
+// still appear out of order. It returns when every stream has ended, or with
+// the first stream error, after which the remaining streams are closed.
+func mergeLogStreams(ctx context.Context, sources []logSource, opts mergeOptions, emit func(source string, ev shared.LogEvent)) error {
+	ordered := opts.OrderBy == logOrderTimestamp
+	window := opts.Window
+	if window <= 0 {
+		window = defaultLogReorderWindow
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events := make(chan mergedLogEvent)
+	errs := make(chan error, len(sources))
+	var wg sync.WaitGroup
+	for _, src := range sources {
+		wg.Add(1)
+		go func(src logSource) {
+			defer wg.Done()
+			for src.Stream.Next() {
+				select {
+				case events <- mergedLogEvent{source: src.Name, event: src.Stream.Current(), arrived: time.Now()}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err := src.Stream.Err(); err != nil {
+				errs <- fmt.Errorf("%s: %w", src.Name, err)
+			}
+		}(src)
+	}
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+	// Closing the streams unblocks readers still waiting on Next once the
+	// merge stops early.
+	defer func() {
+		for _, src := range sources {
+			_ = src.Stream.Close()
+		}
+	}()
+
+	var buf logEventHeap
+	flush := func(all bool) {
+		for buf.Len() > 0 && (all || buf.Len() > maxLogReorderBuffer || time.Since(buf[0].arrived) >= window) {
+			ev := heap.Pop(&buf).(mergedLogEvent)
+			emit(ev.source, ev.event)
+		}
+	}
+	ticker := time.NewTicker(window / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				flush(true)
+				select {
+				case err := <-errs:
+					return err
+				default:
+					return nil
+				}
+			}
+			if !ordered {
+				emit(ev.source, ev.event)
+				continue
+			}
+			heap.Push(&buf, ev)
+			flush(false)
+		case <-ticker.C:
+			flush(false)
+		case err := <-errs:
+			flush(true)
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// logSourceColors is cycled through to color-code source names.
+var logSourceColors = []pterm.Color{pterm.FgCyan, pterm.FgMagenta, pterm.FgYellow, pterm.FgGreen, pterm.FgBlue}
+
+// logSourceColor returns the color used for the i-th source.
+func logSourceColor(i int) pterm.Color {
+	return logSourceColors[i%len(logSourceColors)]
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onkernel/kernel-go-sdk/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLogStream yields events after an optional per-event delay.
+type fakeLogStream struct {
+	events []shared.LogEvent
+	delay  time.Duration
+	err    error
+	i      int
+}
+
+func (f *fakeLogStream) Next() bool {
+	if f.i >= len(f.events) {
+		return false
+	}
+	time.Sleep(f.delay)
+	f.i++
+	return true
+}
+
+func (f *fakeLogStream) Current() shared.LogEvent { return f.events[f.i-1] }
+func (f *fakeLogStream) Err() error               { return f.err }
+func (f *fakeLogStream) Close() error             { return nil }
+
+func logEventsAt(base time.Time, msgs map[int]string, seconds ...int) []shared.LogEvent {
+	var evs []shared.LogEvent
+	for _, s := range seconds {
+		evs = append(evs, shared.LogEvent{Timestamp: base.Add(time.Duration(s) * time.Second), Message: msgs[s]})
+	}
+	return evs
+}
+
+func TestMergeLogStreams_OrderByTimestamp(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	msgs := map[int]string{1: "one", 2: "two", 3: "three", 4: "four"}
+	// The slow source delivers its earlier timestamps after the fast source.
+	fast := &fakeLogStream{events: logEventsAt(base, msgs, 1, 3)}
+	slow := &fakeLogStream{events: logEventsAt(base, msgs, 2, 4), delay: 20 * time.Millisecond}
+
+	var got []string
+	err := mergeLogStreams(context.Background(), []logSource{{Name: "path", Stream: fast}, {Name: "supervisor", Stream: slow}},
+		mergeOptions{OrderBy: logOrderTimestamp, Window: time.Second},
+		func(source string, ev shared.LogEvent) { got = append(got, source+":"+ev.Message) })
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"path:one", "supervisor:two", "path:three", "supervisor:four"}, got)
+}
+
+func TestMergeLogStreams_ReturnsStreamError(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	failing := &fakeLogStream{events: logEventsAt(base, map[int]string{1: "one"}, 1), err: errors.New("stream reset")}
+
+	var got []string
+	err := mergeLogStreams(context.Background(), []logSource{{Name: "path", Stream: failing}}, mergeOptions{},
+		func(source string, ev shared.LogEvent) { got = append(got, ev.Message) })
+
+	assert.EqualError(t, err, "path: stream reset")
+	assert.Equal(t, []string{"one"}, got)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// defaultRotateKeep is how many rotated log files are kept when
+// --rotate-keep is not given.
+const defaultRotateKeep = 5
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// openLogOutput returns where log lines should be written: stdout when path
+// is empty, otherwise the file at path, truncated unless appendMode is set so
+// repeated runs can accumulate into one file. Lines are written to the file
+// unbuffered, so a `tail -f` on it sees each line as soon as it arrives.
+func openLogOutput(path string, appendMode bool) (io.WriteCloser, error) {
+	if path == "" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	flags := os.O_CREATE | os.O_WRONLY
+	if appendMode {
+		flags |= os.O_APPEND
+	} else {
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log output file: %w", err)
+	}
+	return f, nil
+}
+
+// rotatingWriter writes to path until the next write would take it past
+// maxSize, then shifts path to path.1 (path.1 to path.2, and so on) and
+// starts a new file. Only the newest keep rotated files are retained.
+type rotatingWriter struct {
+	path    string
+	maxSize int64
+	keep    int
+	f       *os.File
+	size    int64
+}
+
+// openRotatingLogOutput is openLogOutput for a file that rotates once it
+// reaches maxSize bytes. An existing file at path is truncated.
+func openRotatingLogOutput(path string, maxSize int64, keep int) (io.WriteCloser, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log output file: %w", err)
+	}
+	return &rotatingWriter{path: path, maxSize: maxSize, keep: keep, f: f}, nil
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	if w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.f.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingWriter) rotate() error {
+	if err := w.f.Close(); err != nil {
+		return err
+	}
+	// Renaming onto path.keep replaces the oldest file, so it drops out.
+	_ = os.Remove(fmt.Sprintf("%s.%d", w.path, w.keep))
+	for i := w.keep - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", w.path, i)
+		if _, err := os.Stat(from); err == nil {
+			if err := os.Rename(from, fmt.Sprintf("%s.%d", w.path, i+1)); err != nil {
+				return fmt.Errorf("failed to rotate log output file: %w", err)
+			}
+		}
+	}
+	if w.keep > 0 {
+		if err := os.Rename(w.path, w.path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate log output file: %w", err)
+		}
+	}
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log output file: %w", err)
+	}
+	w.f, w.size = f, 0
+	return nil
+}
+
+func (w *rotatingWriter) Close() error {
+	return w.f.Close()
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onkernel/kernel-go-sdk/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLogRun(t *testing.T, path string, appendMode bool, lines ...string) {
+	t.Helper()
+	out, err := openLogOutput(path, appendMode)
+	require.NoError(t, err)
+	for _, line := range lines {
+		printDeployLogLine(out, shared.LogEvent{Message: line + "\n", Timestamp: time.Now()}, false)
+	}
+	require.NoError(t, out.Close())
+}
+
+func TestOpenLogOutput_AppendAccumulates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deploy.log")
+
+	writeLogRun(t, path, true, "first run")
+	writeLogRun(t, path, true, "second run")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "first run\nsecond run\n", string(data))
+}
+
+func TestOpenLogOutput_TruncatesWithoutAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deploy.log")
+
+	writeLogRun(t, path, false, "first run")
+	writeLogRun(t, path, false, "second run")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "second run\n", string(data))
+}
+
+func TestRotatingLogOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.log")
+	out, err := openRotatingLogOutput(path, 10, 2)
+	require.NoError(t, err)
+	for _, line := range []string{"line-1\n", "line-2\n", "line-3\n", "line-4\n"} {
+		_, err := out.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, out.Close())
+
+	// Each 7-byte line overflows the 10-byte limit, so every write after the
+	// first rotates and only the two newest rotated files survive.
+	for name, want := range map[string]string{path: "line-4\n", path + ".1": "line-3\n", path + ".2": "line-2\n"} {
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(data), name)
+	}
+	assert.NoFileExists(t, path+".3")
+}
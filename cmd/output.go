@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"gopkg.in/yaml.v3"
+)
+
+// Values accepted by --output on commands that print API objects.
+const (
+	outputJSON   = "json"
+	outputNDJSON = "ndjson"
+	outputYAML   = "yaml"
+	outputCSV    = "csv"
+)
+
+// checkOutput reports whether output is empty or one of allowed. Otherwise
+// it prints the usual "unsupported --output value" error and returns false.
+func checkOutput(output string, allowed ...string) bool {
+	if output == "" {
+		return true
+	}
+	for _, a := range allowed {
+		if output == a {
+			return true
+		}
+	}
+	quoted := make([]string, len(allowed))
+	for i, a := range allowed {
+		quoted[i] = "'" + a + "'"
+	}
+	choices := quoted[0]
+	if n := len(quoted); n > 1 {
+		choices = strings.Join(quoted[:n-1], ", ") + " or " + quoted[n-1]
+	}
+	pterm.Error.Printf("unsupported --output value: use %s\n", choices)
+	return false
+}
+
+// statusPrinter returns p, or a copy that writes to stderr when output is
+// set, so progress and warning messages don't mix with machine-readable
+// stdout.
+func statusPrinter(p pterm.PrefixPrinter, output string) *pterm.PrefixPrinter {
+	if output != "" {
+		return p.WithWriter(os.Stderr)
+	}
+	return &p
+}
+
+// printStructured prints v as indented JSON, or as YAML with the same keys
+// as the JSON form.
+func printStructured(v any, output string) error {
+	bs, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if output != outputYAML {
+		fmt.Println(string(bs))
+		return nil
+	}
+	// Round-trip through JSON so the API's field names are used as keys.
+	var generic any
+	if err := json.Unmarshal(bs, &generic); err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(generic)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(out))
+	return nil
+}
+
+// printCSV writes rows, header first, as CSV to stdout.
+func printCSV(rows [][]string) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// ProcessTracker remembers the processes spawned in each browser session so
+// they can be cleaned up later; the API has no endpoint to list them.
+type ProcessTracker interface {
+	Add(sessionID, processID string) error
+	List(sessionID string) ([]string, error)
+	Remove(sessionID string, processIDs ...string) error
+}
+
+// fileProcessTracker stores one JSON array of process IDs per session in dir.
+type fileProcessTracker struct {
+	dir string
+}
+
+// newFileProcessTracker returns a tracker rooted in the CLI config directory.
+func newFileProcessTracker() (*fileProcessTracker, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return &fileProcessTracker{dir: filepath.Join(home, ".config", "kernel", "processes")}, nil
+}
+
+func (t *fileProcessTracker) path(sessionID string) string {
+	return filepath.Join(t.dir, sessionID+".json")
+}
+
+func (t *fileProcessTracker) List(sessionID string) ([]string, error) {
+	data, err := os.ReadFile(t.path(sessionID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, fmt.Errorf("invalid process tracking file %s: %w", t.path(sessionID), err)
+	}
+	return ids, nil
+}
+
+func (t *fileProcessTracker) Add(sessionID, processID string) error {
+	ids, err := t.List(sessionID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(ids, processID) {
+		return nil
+	}
+	return t.write(sessionID, append(ids, processID))
+}
+
+func (t *fileProcessTracker) Remove(sessionID string, processIDs ...string) error {
+	ids, err := t.List(sessionID)
+	if err != nil {
+		return err
+	}
+	ids = slices.DeleteFunc(ids, func(id string) bool { return slices.Contains(processIDs, id) })
+	if len(ids) == 0 {
+		if err := os.Remove(t.path(sessionID)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return t.write(sessionID, ids)
+}
+
+func (t *fileProcessTracker) write(sessionID string, ids []string) error {
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.path(sessionID), data, 0600)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/onkernel/kernel-go-sdk"
+	"github.com/onkernel/kernel-go-sdk/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileProcessTracker_AddListRemove(t *testing.T) {
+	tracker := &fileProcessTracker{dir: t.TempDir()}
+
+	require.NoError(t, tracker.Add("sess", "p1"))
+	require.NoError(t, tracker.Add("sess", "p2"))
+	require.NoError(t, tracker.Add("sess", "p1"))
+	ids, err := tracker.List("sess")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p1", "p2"}, ids)
+
+	require.NoError(t, tracker.Remove("sess", "p1", "p2"))
+	ids, err = tracker.List("sess")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestBrowsersProcessSpawn_TracksProcess(t *testing.T) {
+	setupStdoutCapture(t)
+	tracker := &fileProcessTracker{dir: t.TempDir()}
+	proc := &FakeProcessService{
+		SpawnFunc: func(ctx context.Context, id string, body kernel.BrowserProcessSpawnParams, opts ...option.RequestOption) (*kernel.BrowserProcessSpawnResponse, error) {
+			return &kernel.BrowserProcessSpawnResponse{ProcessID: "proc-1"}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: proc, tracker: tracker}
+
+	require.NoError(t, b.ProcessSpawn(context.Background(), BrowsersProcessSpawnInput{Identifier: "id", Command: "sleep"}))
+	ids, err := tracker.List("id")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"proc-1"}, ids)
+}
+
+func TestBrowsersProcessKillAll_SignalsTrackedProcesses(t *testing.T) {
+	setupStdoutCapture(t)
+	tracker := &fileProcessTracker{dir: t.TempDir()}
+	require.NoError(t, tracker.Add("id", "proc-1"))
+	require.NoError(t, tracker.Add("id", "proc-2"))
+
+	signalled := map[string]kernel.BrowserProcessKillParamsSignal{}
+	proc := &FakeProcessService{
+		KillFunc: func(ctx context.Context, processID string, params kernel.BrowserProcessKillParams, opts ...option.RequestOption) (*kernel.BrowserProcessKillResponse, error) {
+			signalled[processID] = params.Signal
+			return &kernel.BrowserProcessKillResponse{}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: proc, tracker: tracker}
+
+	err := b.ProcessKillAll(context.Background(), BrowsersProcessKillAllInput{Identifier: "id", Signal: "KILL"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]kernel.BrowserProcessKillParamsSignal{"proc-1": "KILL", "proc-2": "KILL"}, signalled)
+
+	ids, err := tracker.List("id")
+	require.NoError(t, err)
+	assert.Empty(t, ids, "signalled processes are no longer tracked")
+}
+
+func TestBrowsersProcessKillAll_ReportsFailures(t *testing.T) {
+	setupStdoutCapture(t)
+	tracker := &fileProcessTracker{dir: t.TempDir()}
+	require.NoError(t, tracker.Add("id", "gone"))
+	require.NoError(t, tracker.Add("id", "stuck"))
+
+	proc := &FakeProcessService{
+		KillFunc: func(ctx context.Context, processID string, params kernel.BrowserProcessKillParams, opts ...option.RequestOption) (*kernel.BrowserProcessKillResponse, error) {
+			if processID == "gone" {
+				return nil, &kernel.Error{StatusCode: http.StatusNotFound}
+			}
+			return nil, errors.New("connection reset")
+		},
+	}
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: proc, tracker: tracker}
+
+	err := b.ProcessKillAll(context.Background(), BrowsersProcessKillAllInput{Identifier: "id", Signal: "TERM"})
+	assert.ErrorContains(t, err, "failed to signal 1 of 2 processes")
+	assert.Contains(t, outBuf.String(), "not running")
+
+	ids, err := tracker.List("id")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stuck"}, ids)
+}
+
+func TestBrowsersProcessKillAll_ReportsMissingTracker(t *testing.T) {
+	setupStdoutCapture(t)
+	b := BrowsersCmd{browsers: newFakeBrowsersServiceWithSimpleGet(), process: &FakeProcessService{}}
+
+	require.NoError(t, b.ProcessKillAll(context.Background(), BrowsersProcessKillAllInput{Identifier: "id", Signal: "TERM"}))
+	assert.Contains(t, outBuf.String(), "process tracker not available")
+	assert.NotContains(t, outBuf.String(), "process service not available")
+}
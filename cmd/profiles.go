@@ -70,7 +70,7 @@ func (p ProfilesCmd) List(ctx context.Context) error {
 			name,
 			util.FormatLocal(prof.CreatedAt),
 			util.FormatLocal(prof.UpdatedAt),
-			util.FormatLocal(prof.LastUsedAt),
+			util.FormatRelative(prof.LastUsedAt),
 		})
 	}
 	PrintTableNoPad(rows, true)
@@ -139,9 +139,7 @@ func (p ProfilesCmd) Delete(ctx context.Context, in ProfilesDeleteInput) error {
 
 	if !in.SkipConfirm {
 		msg := fmt.Sprintf("Are you sure you want to delete profile '%s'?", in.Identifier)
-		pterm.DefaultInteractiveConfirm.DefaultText = msg
-		ok, _ := pterm.DefaultInteractiveConfirm.Show()
-		if !ok {
+		if !confirmPrompt(msg) {
 			pterm.Info.Println("Deletion cancelled")
 			return nil
 		}
@@ -256,7 +254,7 @@ func init() {
 	profilesCmd.AddCommand(profilesDownloadCmd)
 
 	profilesCreateCmd.Flags().String("name", "", "Optional unique profile name")
-	profilesDeleteCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	addYesFlag(profilesDeleteCmd)
 	profilesDownloadCmd.Flags().String("to", "", "Output zip file path")
 	profilesDownloadCmd.Flags().Bool("pretty", false, "Pretty-print JSON to file")
 }
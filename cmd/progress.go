@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/onkernel/cli/pkg/util"
+	"github.com/pterm/pterm"
+)
+
+// progressOutput is where upload progress is drawn. It is stderr so stdout
+// stays free for command output.
+var progressOutput io.Writer = os.Stderr
+
+// stderrIsTerminal reports whether stderr is attached to a terminal, where
+// redrawing a progress bar makes sense.
+func stderrIsTerminal() bool {
+	st, err := os.Stderr.Stat()
+	return err == nil && st.Mode()&os.ModeCharDevice != 0
+}
+
+// uploadProgress counts bytes read through the readers it wraps. Without a
+// bar it only prints a single "Uploading…" line when started.
+type uploadProgress struct {
+	bar *pterm.ProgressbarPrinter
+}
+
+// startUploadProgress starts reporting an upload of total bytes, drawing a
+// bar when show is set and a single line otherwise.
+func startUploadProgress(total int64, show bool) *uploadProgress {
+	title := "Uploading " + util.FormatBytes(total)
+	if show && total > 0 {
+		bar, err := pterm.DefaultProgressbar.WithTotal(int(total)).WithTitle(title).WithWriter(progressOutput).WithRemoveWhenDone().Start()
+		if err == nil {
+			return &uploadProgress{bar: bar}
+		}
+	}
+	pterm.Info.WithWriter(progressOutput).Println(title + "…")
+	return &uploadProgress{}
+}
+
+// wrap returns r counting toward the progress bar.
+func (p *uploadProgress) wrap(r io.Reader) io.Reader {
+	if p.bar == nil {
+		return r
+	}
+	return &progressReader{r: r, bar: p.bar}
+}
+
+// stop removes the bar, even if the upload failed before reaching the total.
+func (p *uploadProgress) stop() {
+	if p.bar != nil {
+		_, _ = p.bar.Stop()
+	}
+}
+
+type progressReader struct {
+	r   io.Reader
+	bar *pterm.ProgressbarPrinter
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.bar.Add(n)
+	}
+	return n, err
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadProgress(t *testing.T) {
+	var buf bytes.Buffer
+	orig := progressOutput
+	progressOutput = &buf
+	t.Cleanup(func() { progressOutput = orig })
+
+	p := startUploadProgress(10, true)
+	require.NotNil(t, p.bar)
+	data, err := io.ReadAll(p.wrap(strings.NewReader("0123456789")))
+	p.stop()
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(data))
+	assert.Equal(t, 10, p.bar.Current)
+	assert.Contains(t, buf.String(), "Uploading 10 B")
+
+	buf.Reset()
+	p = startUploadProgress(2048, false)
+	assert.Nil(t, p.bar)
+	assert.Contains(t, buf.String(), "Uploading 2.0 KiB…")
+}
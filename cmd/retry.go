@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/onkernel/kernel-go-sdk"
+	"github.com/spf13/cobra"
+)
+
+// defaultRetryOn lists the HTTP status codes retried when --retry is set
+// without an explicit --retry-on.
+var defaultRetryOn = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// readOnlyCommands lists the commands, by path below the root, that may
+// register --retry. Re-sending a mutating request could apply it twice, so
+// getRetryOptions refuses any command not listed here.
+var readOnlyCommands = map[string]bool{
+	"browsers list":                true,
+	"browsers get":                 true,
+	"browsers fs read-file":        true,
+	"browsers fs cat":              true,
+	"browsers computer screenshot": true,
+	"extensions download":          true,
+}
+
+// RetryOptions controls how withRetry re-attempts an operation.
+type RetryOptions struct {
+	// Retries is the number of additional attempts after the first one.
+	Retries int
+	// On is the set of HTTP status codes that are considered retryable.
+	On []int
+	// Backoff is the delay before the first retry; it doubles on each attempt.
+	Backoff time.Duration
+}
+
+// withRetry runs fn, re-attempting it on retryable API status codes or
+// transient network errors. It must only be used for idempotent operations.
+func withRetry(ctx context.Context, opts RetryOptions, fn func() error) error {
+	backoff := opts.Backoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	on := opts.On
+	if len(on) == 0 {
+		on = defaultRetryOn
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if err == nil || attempt >= opts.Retries || !isRetryable(err, on) {
+			return err
+		}
+		if logger != nil {
+			logger.Debug("retrying request", logger.Args("attempt", attempt+2, "of", opts.Retries+1, "backoff", backoff.String(), "error", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func isRetryable(err error, on []int) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apierr *kernel.Error
+	if errors.As(err, &apierr) {
+		return slices.Contains(on, apierr.StatusCode)
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// addRetryFlags registers --retry and --retry-on. The command must be listed
+// in readOnlyCommands.
+func addRetryFlags(cmd *cobra.Command) {
+	addRetryFlagsWithDefault(cmd, 0)
+}
+
+// addRetryFlagsWithDefault is addRetryFlags for commands that retry by default,
+// such as downloads where a dropped connection is common.
+func addRetryFlagsWithDefault(cmd *cobra.Command, retries int) {
+	cmd.Flags().Int("retry", retries, "Retry the request up to N times on transient failures (read-only commands only)")
+	cmd.Flags().IntSlice("retry-on", nil, "HTTP status codes to retry on (default 429,502,503,504)")
+}
+
+// getRetryOptions reads the flags registered by addRetryFlags.
+func getRetryOptions(cmd *cobra.Command) (RetryOptions, error) {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if !readOnlyCommands[path] {
+		return RetryOptions{}, fmt.Errorf("--retry is not supported by %q: only read-only commands can be retried", path)
+	}
+	retries, _ := cmd.Flags().GetInt("retry")
+	on, _ := cmd.Flags().GetIntSlice("retry-on")
+	if retries < 0 {
+		return RetryOptions{}, fmt.Errorf("--retry must be >= 0")
+	}
+	for _, code := range on {
+		if code < 100 || code > 599 {
+			return RetryOptions{}, fmt.Errorf("invalid --retry-on status code: %d", code)
+		}
+	}
+	return RetryOptions{Retries: retries, On: on}, nil
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onkernel/kernel-go-sdk"
+	"github.com/onkernel/kernel-go-sdk/option"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRetry_RetriesOnRetryableStatus(t *testing.T) {
+	attempts := 0
+	err := withRetry(context.Background(), RetryOptions{Retries: 2, Backoff: time.Millisecond}, func() error {
+		attempts++
+		if attempts == 1 {
+			return &kernel.Error{StatusCode: 503}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestWithRetry_DoesNotRetryUnlistedStatus(t *testing.T) {
+	attempts := 0
+	err := withRetry(context.Background(), RetryOptions{Retries: 3, On: []int{429}, Backoff: time.Millisecond}, func() error {
+		attempts++
+		return &kernel.Error{StatusCode: 500}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithRetry_StopsAfterRetries(t *testing.T) {
+	attempts := 0
+	err := withRetry(context.Background(), RetryOptions{Retries: 2, Backoff: time.Millisecond}, func() error {
+		attempts++
+		return &kernel.Error{StatusCode: 429}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWithRetry_ZeroRetriesRunsOnce(t *testing.T) {
+	attempts := 0
+	err := withRetry(context.Background(), RetryOptions{}, func() error {
+		attempts++
+		return errors.New("boom")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestBrowsersGet_RetrySucceedsOnSecondAttempt(t *testing.T) {
+	setupStdoutCapture(t)
+	attempts := 0
+	fake := &FakeBrowsersService{
+		GetFunc: func(ctx context.Context, id string, opts ...option.RequestOption) (*kernel.BrowserGetResponse, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, &kernel.Error{StatusCode: 502}
+			}
+			return &kernel.BrowserGetResponse{SessionID: "sess-1"}, nil
+		},
+	}
+	b := BrowsersCmd{browsers: fake}
+	err := b.Get(context.Background(), BrowsersGetInput{Identifier: "sess-1", Retry: RetryOptions{Retries: 1, Backoff: time.Millisecond}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Contains(t, outBuf.String(), "sess-1")
+}
+
+func TestGetRetryOptions_RefusesMutatingCommand(t *testing.T) {
+	root := &cobra.Command{Use: "kernel"}
+	browsers := &cobra.Command{Use: "browsers"}
+	get := &cobra.Command{Use: "get <id>"}
+	del := &cobra.Command{Use: "delete <id>"}
+	root.AddCommand(browsers)
+	browsers.AddCommand(get, del)
+	addRetryFlags(get)
+	addRetryFlags(del)
+
+	require.NoError(t, del.Flags().Parse([]string{"--retry", "2"}))
+	_, err := getRetryOptions(del)
+	assert.ErrorContains(t, err, `--retry is not supported by "browsers delete"`)
+
+	require.NoError(t, get.Flags().Parse([]string{"--retry", "2"}))
+	opts, err := getRetryOptions(get)
+	require.NoError(t, err)
+	assert.Equal(t, 2, opts.Retries)
+}
+
+func TestRetryFlagsOnlyOnReadOnlyCommands(t *testing.T) {
+	registered := map[string]bool{}
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		if c.Flags().Lookup("retry") != nil {
+			path := strings.TrimPrefix(c.CommandPath(), "kernel ")
+			assert.True(t, readOnlyCommands[path], "%s registers --retry but is not in readOnlyCommands", path)
+			registered[path] = true
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(rootCmd)
+	for path := range readOnlyCommands {
+		assert.True(t, registered[path], "readOnlyCommands lists %s, which has no --retry flag", path)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"github.com/onkernel/cli/cmd/mcp"
 	"github.com/onkernel/cli/cmd/proxies"
 	"github.com/onkernel/cli/pkg/auth"
+	"github.com/onkernel/cli/pkg/table"
 	"github.com/onkernel/cli/pkg/update"
 	"github.com/onkernel/cli/pkg/util"
 	"github.com/onkernel/kernel-go-sdk"
@@ -101,6 +103,8 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("version", "v", false, "Print the CLI version")
 	rootCmd.PersistentFlags().BoolP("no-color", "", false, "Disable color output")
 	rootCmd.PersistentFlags().String("log-level", "warn", "Set the log level (trace, debug, info, warn, error, fatal, print)")
+	rootCmd.PersistentFlags().Bool("raw", false, "Show raw byte counts and RFC 3339 timestamps instead of human-friendly values")
+	rootCmd.PersistentFlags().Bool("plain", false, "Print tables as unstyled tab-separated columns for copy-paste and scripts")
 	rootCmd.SilenceUsage = true
 	rootCmd.SilenceErrors = true
 	cobra.OnInitialize(initConfig)
@@ -113,6 +117,8 @@ func init() {
 		if noColor, _ := cmd.Flags().GetBool("no-color"); noColor {
 			pterm.DisableStyling()
 		}
+		util.RawOutput, _ = cmd.Flags().GetBool("raw")
+		table.Plain, _ = cmd.Flags().GetBool("plain")
 
 		// Skip auth check for commands that don't need it (including children, e.g., "completion zsh")
 		if isAuthExempt(cmd) {
@@ -176,6 +182,9 @@ func Execute(m Metadata) {
 		fang.WithVersion(metadata.Version),
 		fang.WithCommit(metadata.Commit),
 		fang.WithErrorHandler(func(w io.Writer, styles fang.Styles, err error) {
+			if errors.As(err, new(reportedError)) {
+				return
+			}
 			err = util.CleanedUpSdkError{Err: err}
 			// remove margins so that it matches other pterm.error "style"
 			// we should add them back later as it looks cleaner
@@ -197,6 +206,13 @@ func Execute(m Metadata) {
 	}
 }
 
+// reportedError wraps an error the command has already printed, e.g. to
+// stderr to keep machine-readable stdout clean. Execute still exits non-zero
+// but does not print it again.
+type reportedError struct{ error }
+
+func (e reportedError) Unwrap() error { return e.error }
+
 // isUsageError is a hack to detect usage errors.
 // See: https://github.com/spf13/cobra/pull/2266
 // from github.com/charmbracelet/fang/help.go
@@ -225,3 +241,17 @@ func onCancel(ctx context.Context, fn func()) {
 		}
 	}()
 }
+
+// confirmPrompt shows an interactive yes/no prompt and reports the answer.
+// It is a variable so tests can answer the prompt without a terminal.
+var confirmPrompt = func(msg string) bool {
+	pterm.DefaultInteractiveConfirm.DefaultText = msg
+	ok, _ := pterm.DefaultInteractiveConfirm.Show()
+	return ok
+}
+
+// addYesFlag registers the --yes/-y flag shared by every command that asks
+// for confirmation before proceeding.
+func addYesFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// maxBrowserTimeout is the longest session timeout the API accepts.
+const maxBrowserTimeout = 72 * time.Hour
+
+// secondsFlag is a pflag.Value holding a whole number of seconds. It accepts
+// a bare integer (seconds) or a Go duration such as 90m or 1h30m.
+type secondsFlag struct {
+	seconds int64
+	// max, when non-zero, is the largest accepted value.
+	max time.Duration
+}
+
+func (f *secondsFlag) String() string { return strconv.FormatInt(f.seconds, 10) }
+func (f *secondsFlag) Type() string   { return "seconds|duration" }
+
+func (f *secondsFlag) Set(s string) error {
+	n, err := parseSeconds(s, f.max)
+	if err != nil {
+		return err
+	}
+	f.seconds = n
+	return nil
+}
+
+// parseSeconds parses s as whole seconds or a Go duration, rejecting
+// negative values, fractional seconds and anything above max (if set).
+func parseSeconds(s string, max time.Duration) (int64, error) {
+	s = strings.TrimSpace(s)
+	var d time.Duration
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		d = time.Duration(n) * time.Second
+	} else {
+		d, err = time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("use seconds (e.g. 600) or a duration like 90m, 2h or 1h30m")
+		}
+		if d%time.Second != 0 {
+			return 0, fmt.Errorf("%s is not a whole number of seconds", d)
+		}
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	if max > 0 && d > max {
+		return 0, fmt.Errorf("%s exceeds the maximum of %s", d, max)
+	}
+	return int64(d / time.Second), nil
+}
+
+// addSecondsFlag registers a flag parsed by secondsFlag. Read it with
+// getSeconds.
+func addSecondsFlag(cmd *cobra.Command, name, shorthand string, def int64, max time.Duration, usage string) {
+	cmd.Flags().VarP(&secondsFlag{seconds: def, max: max}, name, shorthand, usage)
+}
+
+// getSeconds reads a flag registered by addSecondsFlag.
+func getSeconds(cmd *cobra.Command, name string) int64 {
+	if f, ok := cmd.Flags().Lookup(name).Value.(*secondsFlag); ok {
+		return f.seconds
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeconds(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr string
+	}{
+		{in: "600", want: 600},
+		{in: "90m", want: 5400},
+		{in: "1h30m", want: 5400},
+		{in: "72h", want: 259200},
+		{in: "1.5s", wantErr: "not a whole number of seconds"},
+		{in: "73h", wantErr: "exceeds the maximum of 72h0m0s"},
+		{in: "259201", wantErr: "exceeds the maximum"},
+		{in: "-5", wantErr: "must not be negative"},
+		{in: "soon", wantErr: "use seconds"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseSeconds(tt.in, maxBrowserTimeout)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSecondsFlag_DefaultAndParse(t *testing.T) {
+	cmd := &cobra.Command{Use: "create"}
+	addSecondsFlag(cmd, "timeout", "t", 60, maxBrowserTimeout, "Timeout")
+	assert.Equal(t, int64(60), getSeconds(cmd, "timeout"))
+
+	require.NoError(t, cmd.Flags().Parse([]string{"-t", "2h"}))
+	assert.Equal(t, int64(7200), getSeconds(cmd, "timeout"))
+	assert.Error(t, cmd.Flags().Parse([]string{"--timeout", "100h"}))
+}
+
+func TestBrowserPoolsTimeoutFlagsAcceptDurations(t *testing.T) {
+	for _, cmd := range []*cobra.Command{browserPoolsCreateCmd, browserPoolsUpdateCmd} {
+		flag := cmd.Flags().Lookup("timeout")
+		require.NoError(t, flag.Value.Set("90m"), cmd.Name())
+		assert.Equal(t, int64(5400), getSeconds(cmd, "timeout"), cmd.Name())
+		require.NoError(t, flag.Value.Set("0"))
+	}
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// lastSessionRef can be passed in place of a browser session ID to refer to
+// the most recently created or acquired session.
+const lastSessionRef = "@last"
+
+// acceptsPersistentIDAnnotation marks "<id>" commands that also accept a
+// persistent ID, which is user-chosen and exempt from session ID validation.
+const acceptsPersistentIDAnnotation = "accepts-persistent-id"
+
+// lastSessionPath returns the file that records the most recent session ID.
+func lastSessionPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "kernel", "last-session"), nil
+}
+
+// saveSessionID writes id to path, creating parent directories as needed.
+func saveSessionID(path, id string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, []byte(id+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to save session ID: %w", err)
+	}
+	return nil
+}
+
+// readSessionID reads a session ID previously written by saveSessionID.
+func readSessionID(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read session file: %w", err)
+	}
+	id := strings.TrimSpace(string(data))
+	if id == "" {
+		return "", fmt.Errorf("session file %s is empty", path)
+	}
+	return id, nil
+}
+
+// recordSession writes id to saveTo (when set) and, when remember is true,
+// records it as the session @last refers to. Failing to update the
+// last-session file is not fatal.
+func recordSession(id, saveTo string, remember bool) error {
+	if remember {
+		if path, err := lastSessionPath(); err == nil {
+			if err := saveSessionID(path, id); err != nil && logger != nil {
+				logger.Debug("failed to record last session", logger.Args("error", err.Error()))
+			}
+		}
+	}
+	if saveTo == "" {
+		return nil
+	}
+	return saveSessionID(saveTo, id)
+}
+
+// resolveSessionRef expands @last into the recorded session ID. Any other
+// value is returned unchanged.
+func resolveSessionRef(id string) (string, error) {
+	if id != lastSessionRef {
+		return id, nil
+	}
+	path, err := lastSessionPath()
+	if err != nil {
+		return "", err
+	}
+	resolved, err := readSessionID(path)
+	if err != nil {
+		return "", fmt.Errorf("no session recorded for %s; create one with `kernel browsers create` first", lastSessionRef)
+	}
+	return resolved, nil
+}
+
+// validateSessionID reports whether id is shaped like a session ID.
+func validateSessionID(id string) error {
+	if !cuidRegex.MatchString(id) {
+		return fmt.Errorf("%q is not a valid session ID (expected 24 lowercase letters and digits)", id)
+	}
+	return nil
+}
+
+// checkSessionID rejects a malformed id when --strict-id is set and otherwise
+// only logs a warning, since the API remains the final judge.
+func checkSessionID(cmd *cobra.Command, id string) error {
+	err := validateSessionID(id)
+	if err == nil {
+		return nil
+	}
+	if strict, _ := cmd.Flags().GetBool("strict-id"); strict {
+		return err
+	}
+	if logger != nil {
+		logger.Warn(err.Error())
+	}
+	return nil
+}
+
+// resolveSessionArgs applies --session-from and @last to the positional
+// arguments of a command whose first argument is a session ID.
+func resolveSessionArgs(cmd *cobra.Command, args []string) ([]string, error) {
+	if from, _ := cmd.Flags().GetString("session-from"); from != "" {
+		id, err := readSessionID(from)
+		if err != nil {
+			return nil, err
+		}
+		args = append([]string{id}, args...)
+	}
+	if len(args) > 0 {
+		id, err := resolveSessionRef(args[0])
+		if err != nil {
+			return nil, err
+		}
+		args = append([]string{id}, args[1:]...)
+	}
+	return args, nil
+}
+
+// enableSessionRefs lets every command under root that takes a session "<id>"
+// as its first argument accept @last, or omit the ID when --session-from is set.
+// --session-from and --strict-id are only registered on those commands, so
+// passing them anywhere else is an unknown-flag error rather than a no-op.
+func enableSessionRefs(root *cobra.Command) {
+	for _, c := range root.Commands() {
+		enableSessionRefs(c)
+	}
+	if root.RunE == nil || !strings.HasPrefix(strings.TrimPrefix(root.Use, root.Name()), " <id>") {
+		return
+	}
+	root.Flags().String("session-from", "", "Read the browser session ID from this file instead of the <id> argument")
+	root.Flags().Bool("strict-id", false, "Reject malformed session IDs instead of sending them to the API")
+	validate, run := root.Args, root.RunE
+	root.Args = func(cmd *cobra.Command, args []string) error {
+		if from, _ := cmd.Flags().GetString("session-from"); from != "" {
+			args = append([]string{from}, args...)
+		}
+		if validate == nil {
+			return nil
+		}
+		return validate(cmd, args)
+	}
+	root.RunE = func(cmd *cobra.Command, args []string) error {
+		args, err := resolveSessionArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+		if len(args) > 0 && cmd.Annotations[acceptsPersistentIDAnnotation] == "" {
+			if err := checkSessionID(cmd, args[0]); err != nil {
+				return err
+			}
+		}
+		return run(cmd, args)
+	}
+}
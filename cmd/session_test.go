@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/onkernel/kernel-go-sdk"
+	"github.com/onkernel/kernel-go-sdk/option"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSessionRefTestCmd builds a parent with a "get <id>" child that records the args it ran with.
+func newSessionRefTestCmd(got *[]string) *cobra.Command {
+	root := &cobra.Command{Use: "browsers"}
+	root.AddCommand(&cobra.Command{Use: "list", RunE: func(cmd *cobra.Command, args []string) error { return nil }})
+	get := &cobra.Command{
+		Use:  "get <id>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			*got = args
+			return nil
+		},
+	}
+	root.AddCommand(get)
+	enableSessionRefs(root)
+	return root
+}
+
+func TestSessionFile_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "session")
+	require.NoError(t, saveSessionID(path, "sess-123"))
+
+	id, err := readSessionID(path)
+	require.NoError(t, err)
+	assert.Equal(t, "sess-123", id)
+
+	_, err = readSessionID(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestEnableSessionRefs_ResolvesLast(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	require.NoError(t, recordSession("sess-last", "", true))
+
+	var got []string
+	root := newSessionRefTestCmd(&got)
+	root.SetArgs([]string{"get", "@last"})
+	require.NoError(t, root.Execute())
+	assert.Equal(t, []string{"sess-last"}, got)
+}
+
+func TestEnableSessionRefs_SessionFromReplacesID(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	file := filepath.Join(t.TempDir(), "session")
+	require.NoError(t, recordSession("sess-file", file, false))
+
+	var got []string
+	root := newSessionRefTestCmd(&got)
+	root.SetArgs([]string{"get", "--session-from", file})
+	require.NoError(t, root.Execute())
+	assert.Equal(t, []string{"sess-file"}, got)
+}
+
+func TestEnableSessionRefs_LastWithoutRecordedSession(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var got []string
+	root := newSessionRefTestCmd(&got)
+	root.SilenceErrors, root.SilenceUsage = true, true
+	root.SetArgs([]string{"get", "@last"})
+	assert.ErrorContains(t, root.Execute(), "no session recorded")
+	assert.Nil(t, got)
+}
+
+func TestValidateSessionID(t *testing.T) {
+	assert.NoError(t, validateSessionID("htzv5orfit78e1m2biiifpbv"))
+	for _, id := range []string{"", "htzv5orfit78e1m2biiifpb", "HTZV5ORFIT78E1M2BIIIFPBV", "htzv5orfit78e1m2biiifpbv1", "sess-123"} {
+		assert.Error(t, validateSessionID(id), id)
+	}
+}
+
+func TestEnableSessionRefs_StrictIDRejectsMalformed(t *testing.T) {
+	var got []string
+	root := newSessionRefTestCmd(&got)
+	root.SilenceErrors, root.SilenceUsage = true, true
+	root.SetArgs([]string{"get", "--strict-id", "not-an-id"})
+	assert.ErrorContains(t, root.Execute(), "not a valid session ID")
+	assert.Nil(t, got)
+
+	root = newSessionRefTestCmd(&got)
+	root.SetArgs([]string{"get", "not-an-id"})
+	require.NoError(t, root.Execute())
+	assert.Equal(t, []string{"not-an-id"}, got)
+}
+
+func TestEnableSessionRefs_FlagsOnlyOnIDCommands(t *testing.T) {
+	var got []string
+	root := newSessionRefTestCmd(&got)
+	root.SilenceErrors, root.SilenceUsage = true, true
+	root.SetArgs([]string{"list", "--session-from", "session"})
+	assert.ErrorContains(t, root.Execute(), "unknown flag: --session-from")
+
+	root.SetArgs([]string{"list", "--strict-id"})
+	assert.ErrorContains(t, root.Execute(), "unknown flag: --strict-id")
+}
+
+func TestBrowsersCreate_SaveSessionWritesFile(t *testing.T) {
+	setupStdoutCapture(t)
+	t.Setenv("HOME", t.TempDir())
+	fakeBrowsers := &FakeBrowsersService{
+		NewFunc: func(ctx context.Context, body kernel.BrowserNewParams, opts ...option.RequestOption) (*kernel.BrowserNewResponse, error) {
+			return &kernel.BrowserNewResponse{SessionID: "sess-created"}, nil
+		},
+	}
+	file := filepath.Join(t.TempDir(), "session")
+	b := BrowsersCmd{browsers: fakeBrowsers}
+	require.NoError(t, b.Create(context.Background(), BrowsersCreateInput{SaveSession: file, RememberSession: true}))
+
+	id, err := readSessionID(file)
+	require.NoError(t, err)
+	assert.Equal(t, "sess-created", id)
+
+	last, err := resolveSessionRef(lastSessionRef)
+	require.NoError(t, err)
+	assert.Equal(t, "sess-created", last)
+}
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/onkernel/cli/pkg/table"
 	"github.com/pterm/pterm"
 )
@@ -9,3 +12,18 @@ import (
 func PrintTableNoPad(data pterm.TableData, hasHeader bool) {
 	table.PrintTableNoPad(data, hasHeader)
 }
+
+// printCount prints the result of a list command's --count mode: the bare
+// integer, or {"count": N} with --output json.
+func printCount(n int, output string) error {
+	if output == "json" {
+		bs, err := json.Marshal(map[string]int{"count": n})
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bs))
+		return nil
+	}
+	fmt.Println(n)
+	return nil
+}
@@ -1,19 +1,68 @@
 package create
 
 import (
+	"bytes"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
 
 	"github.com/onkernel/cli/pkg/templates"
 )
 
 const (
-	DIR_PERM  = 0755 // rwxr-xr-x
-	FILE_PERM = 0644 // rw-r--r--
+	DIR_PERM       = 0755 // rwxr-xr-x
+	FILE_PERM      = 0644 // rw-r--r--
+	EXEC_FILE_PERM = 0755 // rwxr-xr-x
 )
 
+// TemplatedFiles lists the file names rendered with text/template while
+// copying the embedded templates, so values like {{.AppName}} are replaced
+// with the project's own.
+var TemplatedFiles = []string{"package.json", "pyproject.toml", "uv.lock"}
+
+// TemplateVars builds the substitution data for templated files. AppName,
+// PythonPackageName, Language and Template are always set; extra adds (or
+// overrides) keys.
+func TemplateVars(appName, language, template string, extra map[string]string) map[string]string {
+	vars := map[string]string{
+		"AppName":           appName,
+		"PythonPackageName": pythonPackageName(appName),
+		"Language":          language,
+		"Template":          template,
+	}
+	for k, v := range extra {
+		vars[k] = v
+	}
+	return vars
+}
+
+// pythonPackageName normalizes name the way uv records it in uv.lock
+// (lowercase, with runs of "-", "_" and "." collapsed to "-"), so the lock
+// matches the pyproject.toml name without being rewritten on first sync.
+func pythonPackageName(name string) string {
+	return strings.ToLower(packageNameSeparators.ReplaceAllString(name, "-"))
+}
+
+var packageNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// renderTemplatedFile substitutes vars into content using text/template.
+func renderTemplatedFile(name string, content []byte, vars map[string]string) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template file %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return nil, fmt.Errorf("failed to render template file %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
 // CopyTemplateFiles copies all files and directories from the specified embedded template
 // into the target application path. It uses the given language and template names
 // to locate the template inside the embedded filesystem.
@@ -26,8 +75,17 @@ const (
 // replicate all files and folders in appPath. If a file named "_gitignore" is encountered,
 // it is renamed to ".gitignore" in the output, to work around file embedding limitations.
 //
+// Files named in TemplatedFiles are rendered with TemplateVars, using the base name of
+// appPath as the app name.
+//
 // Returns an error if the template path is invalid, empty, or if any file operations fail.
 func CopyTemplateFiles(appPath, language, template string) error {
+	return CopyTemplateFilesWithVars(appPath, language, template, nil)
+}
+
+// CopyTemplateFilesWithVars is CopyTemplateFiles with extra substitution
+// variables (e.g. from `kernel create --var key=value`).
+func CopyTemplateFilesWithVars(appPath, language, template string, extra map[string]string) error {
 	// Build the template path within the embedded FS (e.g., "typescript/sample-app")
 	templatePath := filepath.Join(language, template)
 
@@ -40,14 +98,21 @@ func CopyTemplateFiles(appPath, language, template string) error {
 		return fmt.Errorf("template directory is empty: %s/%s", language, template)
 	}
 
-	// Walk through the embedded template directory and copy contents
-	return fs.WalkDir(templates.FS, templatePath, func(path string, d fs.DirEntry, err error) error {
+	vars := TemplateVars(filepath.Base(appPath), language, template, extra)
+	return copyFS(templates.FS, templatePath, appPath, vars)
+}
+
+// copyFS replicates the tree rooted at root in fsys into appPath, renaming
+// "_gitignore" files to ".gitignore" and, when vars is non-nil, rendering
+// TemplatedFiles with vars. Any ".git" directory is skipped.
+func copyFS(fsys fs.FS, root, appPath string, vars map[string]string) error {
+	return fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
 		// Determine the path relative to the root of the template
-		relPath, err := filepath.Rel(templatePath, path)
+		relPath, err := filepath.Rel(root, path)
 		if err != nil {
 			return err
 		}
@@ -57,25 +122,42 @@ func CopyTemplateFiles(appPath, language, template string) error {
 			return nil
 		}
 
+		if d.IsDir() && d.Name() == ".git" {
+			return fs.SkipDir
+		}
+
 		destPath := filepath.Join(appPath, relPath)
 
 		if d.IsDir() {
 			return os.MkdirAll(destPath, DIR_PERM)
 		}
 
-		// Read the file content from the embedded filesystem
-		content, err := fs.ReadFile(templates.FS, path)
+		// Read the file content from the source filesystem
+		content, err := fs.ReadFile(fsys, path)
 		if err != nil {
 			return fmt.Errorf("failed to read template file %s: %w", path, err)
 		}
 
+		if vars != nil && slices.Contains(TemplatedFiles, d.Name()) {
+			content, err = renderTemplatedFile(path, content, vars)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Rename _gitignore to .gitignore in the destination
 		if filepath.Base(destPath) == "_gitignore" {
 			destPath = filepath.Join(filepath.Dir(destPath), ".gitignore")
 		}
 
+		// Keep scripts runnable when the source marks them executable
+		perm := os.FileMode(FILE_PERM)
+		if info, err := d.Info(); err == nil && info.Mode()&0111 != 0 {
+			perm = EXEC_FILE_PERM
+		}
+
 		// Write the file to disk in the target project directory
-		if err := os.WriteFile(destPath, content, FILE_PERM); err != nil {
+		if err := os.WriteFile(destPath, content, perm); err != nil {
 			return fmt.Errorf("failed to write file %s: %w", destPath, err)
 		}
 
@@ -1,9 +1,11 @@
 package create
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -171,6 +173,23 @@ func TestCopyTemplateFiles_DirectoryPermissions(t *testing.T) {
 	assert.Equal(t, os.FileMode(DIR_PERM), mode.Perm(), "Directory should have 0755 permissions")
 }
 
+func TestCopyFS_ExecutableFilePermissions(t *testing.T) {
+	appPath := t.TempDir()
+	fsys := fstest.MapFS{
+		"tpl/run.sh":    {Data: []byte("#!/bin/sh\n"), Mode: 0755},
+		"tpl/README.md": {Data: []byte("readme\n"), Mode: 0644},
+	}
+
+	require.NoError(t, copyFS(fsys, "tpl", appPath, nil))
+
+	info, err := os.Stat(filepath.Join(appPath, "run.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(EXEC_FILE_PERM), info.Mode().Perm(), "Executable should keep 0755 permissions")
+	info, err = os.Stat(filepath.Join(appPath, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(FILE_PERM), info.Mode().Perm())
+}
+
 func TestCopyTemplateFiles_PreservesDirectoryStructure(t *testing.T) {
 	tmpDir := t.TempDir()
 	appPath := filepath.Join(tmpDir, "test-app")
@@ -255,3 +274,74 @@ func TestCopyTemplateFiles_AllTemplatesForAllLanguages(t *testing.T) {
 		}
 	}
 }
+
+func TestCopyTemplateFiles_SubstitutesAppName(t *testing.T) {
+	// Every template's manifest should carry the chosen app name
+	for templateKey, templateInfo := range Templates {
+		for _, lang := range templateInfo.Languages {
+			t.Run(lang+"/"+templateKey, func(t *testing.T) {
+				appPath := filepath.Join(t.TempDir(), "my-cool-app")
+				require.NoError(t, os.MkdirAll(appPath, testDirPerm))
+
+				require.NoError(t, CopyTemplateFiles(appPath, lang, templateKey))
+
+				switch lang {
+				case LanguageTypeScript:
+					content, err := os.ReadFile(filepath.Join(appPath, "package.json"))
+					require.NoError(t, err)
+					var pkg struct {
+						Name string `json:"name"`
+					}
+					require.NoError(t, json.Unmarshal(content, &pkg))
+					assert.Equal(t, "my-cool-app", pkg.Name)
+				case LanguagePython:
+					content, err := os.ReadFile(filepath.Join(appPath, "pyproject.toml"))
+					require.NoError(t, err)
+					assert.Contains(t, string(content), `name = "my-cool-app"`)
+					assert.NotContains(t, string(content), "{{")
+					lock, err := os.ReadFile(filepath.Join(appPath, "uv.lock"))
+					require.NoError(t, err)
+					assert.Contains(t, string(lock), "name = \"my-cool-app\"\nversion = \"0.1.0\"\nsource = { virtual = \".\" }")
+					assert.NotContains(t, string(lock), "{{")
+				}
+			})
+		}
+	}
+}
+
+func TestPythonPackageName(t *testing.T) {
+	assert.Equal(t, "my-cool-app", pythonPackageName("my-cool-app"))
+	assert.Equal(t, "my-cool-app", pythonPackageName("My__Cool_App"))
+}
+
+func TestCopyTemplateFilesWithVars_ExtraVars(t *testing.T) {
+	src := fstest.MapFS{
+		"tmpl/package.json": {Data: []byte(`{"name": "{{.AppName}}", "description": "{{.description}}"}`)},
+		"tmpl/README.md":    {Data: []byte("{{.AppName}} stays literal")},
+	}
+	appPath := filepath.Join(t.TempDir(), "app")
+	require.NoError(t, os.MkdirAll(appPath, testDirPerm))
+	vars := TemplateVars("app", LanguageTypeScript, TemplateSampleApp, map[string]string{"description": "hello"})
+
+	require.NoError(t, copyFS(src, "tmpl", appPath, vars))
+
+	content, err := os.ReadFile(filepath.Join(appPath, "package.json"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"name": "app", "description": "hello"}`, string(content))
+
+	readme, err := os.ReadFile(filepath.Join(appPath, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "{{.AppName}} stays literal", string(readme), "only designated files are rendered")
+}
+
+func TestCopyTemplateFilesWithVars_MissingVar(t *testing.T) {
+	src := fstest.MapFS{
+		"tmpl/pyproject.toml": {Data: []byte(`name = "{{.AppName}}"\nauthor = "{{.author}}"`)},
+	}
+	appPath := filepath.Join(t.TempDir(), "app")
+	require.NoError(t, os.MkdirAll(appPath, testDirPerm))
+
+	err := copyFS(src, "tmpl", appPath, TemplateVars("app", LanguagePython, "", nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "author")
+}
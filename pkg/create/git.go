@@ -0,0 +1,113 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// scpLikeGitURL matches the "user@host:path" form accepted by git (e.g. git@github.com:org/repo.git).
+var scpLikeGitURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/].*$`)
+
+// ParseGitSource splits a "<url>[#ref]" template source into its repository
+// URL and optional ref (branch or tag), validating the URL.
+func ParseGitSource(source string) (repoURL, ref string, err error) {
+	repoURL, ref, _ = strings.Cut(strings.TrimSpace(source), "#")
+	if repoURL == "" {
+		return "", "", fmt.Errorf("git URL cannot be empty")
+	}
+	if scpLikeGitURL.MatchString(repoURL) {
+		return repoURL, ref, nil
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid git URL %q: %w", repoURL, err)
+	}
+	switch u.Scheme {
+	case "https", "http", "ssh", "git":
+		if u.Host == "" {
+			return "", "", fmt.Errorf("invalid git URL %q: missing host", repoURL)
+		}
+	case "file":
+	default:
+		return "", "", fmt.Errorf("invalid git URL %q: use https://, ssh://, git://, file:// or user@host:path", repoURL)
+	}
+	return repoURL, ref, nil
+}
+
+// FetchGitRepo shallow-clones repoURL (at ref, when set) into dest.
+// It is a variable so tests can substitute a fetcher that does not need network access.
+var FetchGitRepo = func(ctx context.Context, repoURL, ref, dest string) error {
+	args := []string{"clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, repoURL, dest)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git clone failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// CopyGitTemplate fetches a template repository described by source
+// ("<url>[#ref]") and copies it into appPath. When subPath is set, only that
+// directory of the repository is copied. As with the embedded templates,
+// "_gitignore" files are renamed to ".gitignore". Files are copied verbatim:
+// third-party templates are not rendered, since they may contain "{{" of
+// their own.
+func CopyGitTemplate(ctx context.Context, appPath, source, subPath string) error {
+	repoURL, ref, err := ParseGitSource(source)
+	if err != nil {
+		return err
+	}
+	root := "."
+	if subPath != "" {
+		root = filepath.ToSlash(filepath.Clean(subPath))
+		if !fs.ValidPath(root) {
+			return fmt.Errorf("invalid --path %q: must be a relative path inside the repository", subPath)
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "kernel-template-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := FetchGitRepo(ctx, repoURL, ref, tmpDir); err != nil {
+		return err
+	}
+
+	fsys := os.DirFS(tmpDir)
+	entries, err := fs.ReadDir(fsys, root)
+	if err != nil {
+		return fmt.Errorf("template path not found in repository: %s", root)
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("template directory is empty: %s", root)
+	}
+	return copyFS(fsys, root, appPath, nil)
+}
+
+// DetectLanguage infers the template language from the project files in
+// appPath. It returns an empty string when the language cannot be determined.
+func DetectLanguage(appPath string) string {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(appPath, name))
+		return err == nil
+	}
+	switch {
+	case exists("package.json"):
+		return LanguageTypeScript
+	case exists("pyproject.toml"), exists("requirements.txt"):
+		return LanguagePython
+	}
+	return ""
+}
@@ -0,0 +1,140 @@
+package create
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGitFetcher replaces FetchGitRepo with one that writes files into the
+// clone destination, recording the requested URL and ref.
+func fakeGitFetcher(t *testing.T, files map[string]string) (gotURL, gotRef *string) {
+	t.Helper()
+	var u, r string
+	orig := FetchGitRepo
+	FetchGitRepo = func(ctx context.Context, repoURL, ref, dest string) error {
+		u, r = repoURL, ref
+		for name, content := range files {
+			p := filepath.Join(dest, name)
+			if err := os.MkdirAll(filepath.Dir(p), testDirPerm); err != nil {
+				return err
+			}
+			if err := os.WriteFile(p, []byte(content), testFilePerm); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	t.Cleanup(func() { FetchGitRepo = orig })
+	return &u, &r
+}
+
+func TestParseGitSource(t *testing.T) {
+	tests := []struct {
+		source  string
+		url     string
+		ref     string
+		wantErr bool
+	}{
+		{source: "https://github.com/acme/starter.git", url: "https://github.com/acme/starter.git"},
+		{source: "https://github.com/acme/starter#v1.2.0", url: "https://github.com/acme/starter", ref: "v1.2.0"},
+		{source: "git@github.com:acme/starter.git#main", url: "git@github.com:acme/starter.git", ref: "main"},
+		{source: "file:///tmp/starter", url: "file:///tmp/starter"},
+		{source: "", wantErr: true},
+		{source: "ftp://example.com/repo", wantErr: true},
+		{source: "https:///no-host", wantErr: true},
+		{source: "not a url", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			u, ref, err := ParseGitSource(tt.source)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.url, u)
+			assert.Equal(t, tt.ref, ref)
+		})
+	}
+}
+
+func TestCopyGitTemplate_CopiesRepoFiles(t *testing.T) {
+	gotURL, gotRef := fakeGitFetcher(t, map[string]string{
+		"package.json":   `{"name":"{{ project_name }}"}`,
+		"src/index.ts":   "export {}",
+		"_gitignore":     "node_modules",
+		".git/HEAD":      "ref: refs/heads/main",
+		"docs/README.md": "docs",
+	})
+	appPath := t.TempDir()
+
+	err := CopyGitTemplate(context.Background(), appPath, "https://github.com/acme/starter#main", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://github.com/acme/starter", *gotURL)
+	assert.Equal(t, "main", *gotRef)
+	manifest, err := os.ReadFile(filepath.Join(appPath, "package.json"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"{{ project_name }}"}`, string(manifest), "git templates are copied verbatim")
+	assert.FileExists(t, filepath.Join(appPath, "src", "index.ts"))
+	assert.FileExists(t, filepath.Join(appPath, ".gitignore"))
+	assert.NoFileExists(t, filepath.Join(appPath, "_gitignore"))
+	assert.NoDirExists(t, filepath.Join(appPath, ".git"))
+	assert.Equal(t, LanguageTypeScript, DetectLanguage(appPath))
+}
+
+func TestCopyGitTemplate_SubPath(t *testing.T) {
+	fakeGitFetcher(t, map[string]string{
+		"README.md":                   "root",
+		"templates/py/pyproject.toml": "[project]",
+		"templates/py/main.py":        "print()",
+	})
+	appPath := t.TempDir()
+
+	err := CopyGitTemplate(context.Background(), appPath, "https://github.com/acme/starters", "templates/py")
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(appPath, "main.py"))
+	assert.FileExists(t, filepath.Join(appPath, "pyproject.toml"))
+	assert.NoFileExists(t, filepath.Join(appPath, "README.md"))
+	assert.Equal(t, LanguagePython, DetectLanguage(appPath))
+}
+
+func TestCopyGitTemplate_InvalidSubPath(t *testing.T) {
+	fakeGitFetcher(t, map[string]string{"main.py": ""})
+	err := CopyGitTemplate(context.Background(), t.TempDir(), "https://github.com/acme/starters", "../outside")
+	assert.ErrorContains(t, err, "invalid --path")
+
+	err = CopyGitTemplate(context.Background(), t.TempDir(), "https://github.com/acme/starters", "missing")
+	assert.ErrorContains(t, err, "template path not found")
+}
+
+func TestCopyGitTemplate_LocalRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "main.py"), []byte("print('hi')"), testFilePerm))
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	appPath := t.TempDir()
+	err := CopyGitTemplate(context.Background(), appPath, "file://"+repo, "")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(appPath, "main.py"))
+	assert.NoDirExists(t, filepath.Join(appPath, ".git"))
+}
@@ -0,0 +1,84 @@
+package create
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AppMetadataPath is where create records how an app was scaffolded, relative to the app directory.
+var AppMetadataPath = filepath.Join(".kernel", "app.json")
+
+// AppMetadata describes a scaffolded app so later commands (e.g. deploy) can suggest the right invocation.
+type AppMetadata struct {
+	// AppName is the name the app registers with kernel.App, i.e. what deploy creates.
+	AppName       string `json:"app_name,omitempty"`
+	Language      string `json:"language"`
+	Template      string `json:"template,omitempty"`
+	Entrypoint    string `json:"entrypoint,omitempty"`
+	DeployCommand string `json:"deploy_command,omitempty"`
+	InvokeSample  string `json:"invoke_sample,omitempty"`
+}
+
+// NewAppMetadata builds the metadata for a language/template pair from the deploy and invoke samples.
+func NewAppMetadata(language, template string) AppMetadata {
+	invokeSample := GetInvokeSample(language, template)
+	return AppMetadata{
+		AppName:       appNameFromInvokeSample(invokeSample),
+		Language:      language,
+		Template:      template,
+		Entrypoint:    Commands[language][template].EntryPoint,
+		DeployCommand: GetDeployCommand(language, template),
+		InvokeSample:  invokeSample,
+	}
+}
+
+// appNameFromInvokeSample extracts <app_name> from a "kernel invoke <app_name> <action> ..." sample.
+func appNameFromInvokeSample(sample string) string {
+	fields := strings.Fields(sample)
+	if len(fields) < 3 || fields[0] != "kernel" || fields[1] != "invoke" {
+		return ""
+	}
+	return fields[2]
+}
+
+// DeployedAppName returns the app name, falling back to the invoke sample for
+// metadata written before app_name was recorded.
+func (m AppMetadata) DeployedAppName() string {
+	if m.AppName != "" {
+		return m.AppName
+	}
+	return appNameFromInvokeSample(m.InvokeSample)
+}
+
+// WriteAppMetadata writes meta to .kernel/app.json inside appPath.
+func WriteAppMetadata(appPath string, meta AppMetadata) error {
+	path := filepath.Join(appPath, AppMetadataPath)
+	if err := os.MkdirAll(filepath.Dir(path), DIR_PERM); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode app metadata: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), FILE_PERM); err != nil {
+		return fmt.Errorf("failed to write app metadata: %w", err)
+	}
+	return nil
+}
+
+// ReadAppMetadata loads .kernel/app.json from dir. The returned error wraps
+// fs.ErrNotExist when the directory was not scaffolded by create.
+func ReadAppMetadata(dir string) (*AppMetadata, error) {
+	data, err := os.ReadFile(filepath.Join(dir, AppMetadataPath))
+	if err != nil {
+		return nil, err
+	}
+	var meta AppMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", AppMetadataPath, err)
+	}
+	return &meta, nil
+}
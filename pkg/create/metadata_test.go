@@ -0,0 +1,45 @@
+package create
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAppMetadata(t *testing.T) {
+	appPath := t.TempDir()
+
+	require.NoError(t, WriteAppMetadata(appPath, NewAppMetadata(LanguagePython, TemplateBrowserUse)))
+
+	data, err := os.ReadFile(filepath.Join(appPath, ".kernel", "app.json"))
+	require.NoError(t, err)
+	var got map[string]string
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, "python-bu", got["app_name"])
+	assert.Equal(t, LanguagePython, got["language"])
+	assert.Equal(t, TemplateBrowserUse, got["template"])
+	assert.Equal(t, "main.py", got["entrypoint"])
+	assert.Equal(t, "kernel deploy main.py --env-file .env", got["deploy_command"])
+	assert.Equal(t, GetInvokeSample(LanguagePython, TemplateBrowserUse), got["invoke_sample"])
+
+	meta, err := ReadAppMetadata(appPath)
+	require.NoError(t, err)
+	assert.Equal(t, NewAppMetadata(LanguagePython, TemplateBrowserUse), *meta)
+}
+
+func TestReadAppMetadata_Missing(t *testing.T) {
+	_, err := ReadAppMetadata(t.TempDir())
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+func TestAppMetadata_DeployedAppNameFallsBackToInvokeSample(t *testing.T) {
+	meta := AppMetadata{InvokeSample: "kernel invoke ts-basic get-page-title --payload '{}'"}
+	assert.Equal(t, "ts-basic", meta.DeployedAppName())
+	assert.Equal(t, "", AppMetadata{}.DeployedAppName())
+}
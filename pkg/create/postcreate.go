@@ -0,0 +1,20 @@
+package create
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// RunPostCreate executes command with the shell in appPath, streaming its
+// output to stdout and stderr.
+func RunPostCreate(appPath, command string, stdout, stderr io.Writer) error {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Dir = appPath
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("command %q failed: %w", command, err)
+	}
+	return nil
+}
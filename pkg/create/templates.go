@@ -150,6 +150,10 @@ type DeployConfig struct {
 	EntryPoint    string
 	NeedsEnvFile  bool
 	InvokeCommand string
+	// PostCreate is an optional shell command run in the new app directory
+	// after dependencies are installed, letting a template bootstrap itself.
+	// It only runs when the user opts in with --post-create.
+	PostCreate string
 }
 
 var Commands = map[string]map[string]DeployConfig{
@@ -258,3 +262,12 @@ func GetInvokeSample(language, template string) string {
 
 	return config.InvokeCommand
 }
+
+// GetPostCreateCommand returns the template's post-create command, if any
+func GetPostCreateCommand(language, template string) string {
+	langCommands, ok := Commands[language]
+	if !ok {
+		return ""
+	}
+	return langCommands[template].PostCreate
+}
@@ -6,6 +6,16 @@ type CreateInput struct {
 	Name     string
 	Language string
 	Template string
+	// FromGit scaffolds from a remote repository ("<url>[#ref]") instead of an embedded template.
+	FromGit string
+	// GitPath selects a subdirectory of the FromGit repository.
+	GitPath string
+	// Run is an optional shell command executed in the app directory after install.
+	Run string
+	// PostCreate runs the template's own post-create command, if it has one.
+	PostCreate bool
+	// Vars holds extra template substitutions from --var key=value.
+	Vars map[string]string
 }
 
 const (
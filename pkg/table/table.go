@@ -7,15 +7,48 @@ import (
 	"github.com/pterm/pterm"
 )
 
+// Plain switches PrintTableNoPad to SprintTablePlain. It is set by the
+// global --plain flag.
+var Plain bool
+
 // PrintTableNoPad renders a table similar to pterm.DefaultTable, but it avoids
 // adding trailing padding spaces after the last column and does not add blank
 // padded lines to match multi-line cells in other columns. The last column may
 // contain multi-line content which will be printed as-is on following lines.
 // It also intelligently truncates columns to prevent line wrapping.
 func PrintTableNoPad(data pterm.TableData, hasHeader bool) {
-	if len(data) == 0 {
+	if Plain {
+		pterm.Print(SprintTablePlain(data))
 		return
 	}
+	pterm.Print(SprintTableNoPad(data, hasHeader))
+}
+
+// SprintTablePlain renders the table as tab-separated rows with color codes
+// removed and no truncation, for pasting into plain text or piping to awk.
+// Tabs and newlines inside a cell are replaced with spaces so every row stays
+// on one line with the same number of columns.
+func SprintTablePlain(data pterm.TableData) string {
+	var b strings.Builder
+	cleaner := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ")
+	for _, row := range data {
+		for colIdx, cell := range row {
+			if colIdx > 0 {
+				b.WriteByte('\t')
+			}
+			b.WriteString(cleaner.Replace(pterm.RemoveColorFromString(cell)))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// SprintTableNoPad renders the table PrintTableNoPad would print and returns
+// it as a string.
+func SprintTableNoPad(data pterm.TableData, hasHeader bool) string {
+	if len(data) == 0 {
+		return ""
+	}
 
 	// Get terminal width and truncate data to fit
 	termWidth := pterm.GetTerminalWidth()
@@ -27,7 +60,7 @@ func PrintTableNoPad(data pterm.TableData, hasHeader bool) {
 	// Determine number of columns from the first row
 	numCols := len(data[0])
 	if numCols == 0 {
-		return
+		return ""
 	}
 
 	// Pre-compute max width per column (including last column for proper alignment)
@@ -87,7 +120,7 @@ func PrintTableNoPad(data pterm.TableData, hasHeader bool) {
 		renderRow(row, hasHeader && idx == 0)
 	}
 
-	pterm.Print(b.String())
+	return b.String()
 }
 
 // truncateTableData intelligently truncates table cells to fit within terminal width
@@ -0,0 +1,35 @@
+package table
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintTableNoPad_Plain(t *testing.T) {
+	var buf bytes.Buffer
+	pterm.SetDefaultOutput(&buf)
+	Plain = true
+	t.Cleanup(func() {
+		pterm.SetDefaultOutput(os.Stdout)
+		Plain = false
+	})
+
+	PrintTableNoPad(pterm.TableData{
+		{"ID", "Status", "Note"},
+		{"abc", pterm.Green("running"), "two\nlines"},
+		{"defghi", pterm.Red("stopped"), ""},
+	}, true)
+
+	out := buf.String()
+	assert.NotContains(t, out, "\x1b[")
+	assert.Equal(t, []string{
+		"ID\tStatus\tNote",
+		"abc\trunning\ttwo lines",
+		"defghi\tstopped\t",
+	}, strings.Split(strings.TrimSuffix(out, "\n"), "\n"))
+}
@@ -5,10 +5,60 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"strings"
 
 	"github.com/onkernel/kernel-go-sdk"
 )
 
+// errorHint is a suggested next step for API errors with a given HTTP status
+// on a given resource (the first segment of the request path, e.g.
+// "browsers"). An empty resource matches any request.
+type errorHint struct {
+	status   int
+	resource string
+	hint     string
+}
+
+// errorHints are checked in order; the first match is appended to the
+// cleaned-up message.
+var errorHints = []errorHint{
+	{http.StatusUnauthorized, "", "Run `kernel login` or check that KERNEL_API_KEY is valid"},
+	{http.StatusTooManyRequests, "browsers", "Run `kernel browsers list` and delete sessions you no longer need with `kernel browsers delete`"},
+	{http.StatusNotFound, "browsers", "Check `kernel browsers list` for the active sessions"},
+	{http.StatusNotFound, "profiles", "Check `kernel profiles list` for the available profiles"},
+	{http.StatusNotFound, "proxies", "Check `kernel proxies list` for the available proxies"},
+}
+
+// ErrorHint returns the suggestion for an API error with the given status on
+// the given request path, or "" if there is none.
+func ErrorHint(status int, path string) string {
+	resource := requestResource(path)
+	for _, h := range errorHints {
+		if h.status == status && (h.resource == "" || h.resource == resource) {
+			return h.hint
+		}
+	}
+	return ""
+}
+
+// IsCapacityError reports whether err is an API error saying the org is at
+// its browser limit: a 429 on the browsers resource, the same case that gets
+// the capacity hint.
+func IsCapacityError(err error) bool {
+	var kerror *kernel.Error
+	if !errors.As(err, &kerror) || kerror.Request == nil {
+		return false
+	}
+	return kerror.StatusCode == http.StatusTooManyRequests && requestResource(kerror.Request.URL.Path) == "browsers"
+}
+
+// requestResource returns the first segment of an API request path.
+func requestResource(path string) string {
+	resource, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return resource
+}
+
 // CleanedUpSdkError extracts a message field from the raw JSON resposne.
 // This is the convention we use in the API for error response bodies (400s and 500s)
 type CleanedUpSdkError struct {
@@ -24,7 +74,15 @@ func (e CleanedUpSdkError) Error() string {
 		if err := json.Unmarshal([]byte(kerror.RawJSON()), &m); err == nil {
 			message, _ := m["message"].(string)
 			code, _ := m["code"].(string)
-			return fmt.Sprintf("%s: %s", code, message)
+			msg := fmt.Sprintf("%s: %s", code, message)
+			var path string
+			if kerror.Request != nil {
+				path = kerror.Request.URL.Path
+			}
+			if hint := ErrorHint(kerror.StatusCode, path); hint != "" {
+				msg += "\nHint: " + hint
+			}
+			return msg
 		} else if kerror.Response != nil && kerror.Response.Body != nil {
 			// try response body as text
 			body, err := io.ReadAll(kerror.Response.Body)
@@ -0,0 +1,60 @@
+package util
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/onkernel/kernel-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func apiError(t *testing.T, status int, path, body string) *kernel.Error {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, "https://api.onkernel.com"+path, nil)
+	require.NoError(t, err)
+	e := &kernel.Error{StatusCode: status, Request: req}
+	require.NoError(t, e.UnmarshalJSON([]byte(body)))
+	return e
+}
+
+func TestCleanedUpSdkError_CapacityHint(t *testing.T) {
+	err := CleanedUpSdkError{Err: apiError(t, http.StatusTooManyRequests, "/browsers", `{"code":"quota_exceeded","message":"concurrent browser limit reached"}`)}
+	assert.Equal(t, "quota_exceeded: concurrent browser limit reached\nHint: Run `kernel browsers list` and delete sessions you no longer need with `kernel browsers delete`", err.Error())
+}
+
+func TestCleanedUpSdkError_UnauthorizedStatusHint(t *testing.T) {
+	err := CleanedUpSdkError{Err: apiError(t, http.StatusUnauthorized, "/apps", `{"code":"","message":"bad key"}`)}
+	assert.Contains(t, err.Error(), "Hint: Run `kernel login`")
+}
+
+func TestErrorHint_MatchesStatusAndResource(t *testing.T) {
+	assert.Contains(t, ErrorHint(http.StatusNotFound, "/profiles/work"), "kernel profiles list")
+	assert.Contains(t, ErrorHint(http.StatusNotFound, "/proxies/p1"), "kernel proxies list")
+	assert.Empty(t, ErrorHint(http.StatusNotFound, "/apps"))
+	assert.Empty(t, ErrorHint(http.StatusTooManyRequests, "/profiles"), "capacity hint only applies to browsers")
+}
+
+func TestIsCapacityError(t *testing.T) {
+	// A create rejected at the concurrency limit, as the SDK surfaces it.
+	req, err := http.NewRequest(http.MethodPost, "https://api.onkernel.com/browsers", nil)
+	require.NoError(t, err)
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests", Request: req}
+	capacity := &kernel.Error{StatusCode: resp.StatusCode, Request: req, Response: resp}
+	require.NoError(t, capacity.UnmarshalJSON([]byte(`{"code":"too_many_requests","message":"concurrent browser limit reached"}`)))
+
+	assert.True(t, IsCapacityError(capacity))
+	assert.True(t, IsCapacityError(CleanedUpSdkError{Err: capacity}))
+	assert.False(t, IsCapacityError(apiError(t, http.StatusTooManyRequests, "/profiles", `{"code":"too_many_requests","message":"slow down"}`)))
+	assert.False(t, IsCapacityError(apiError(t, http.StatusForbidden, "/browsers", `{"code":"quota_exceeded","message":"limit reached"}`)))
+	assert.False(t, IsCapacityError(errors.New("quota_exceeded")))
+}
+
+func TestCleanedUpSdkError_UnknownCodePassesThrough(t *testing.T) {
+	err := CleanedUpSdkError{Err: apiError(t, http.StatusBadRequest, "/browsers", `{"code":"bad_request","message":"nope"}`)}
+	assert.Equal(t, "bad_request: nope", err.Error())
+
+	plain := CleanedUpSdkError{Err: errors.New("connection refused")}
+	assert.Equal(t, "connection refused", plain.Error())
+}
@@ -1,6 +1,14 @@
 package util
 
-import "strings"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RawOutput switches the display helpers to machine-friendly values: plain
+// byte counts and RFC 3339 timestamps. It is set by the global --raw flag.
+var RawOutput bool
 
 // OrDash returns the string if non-empty, otherwise returns "-".
 func OrDash(s string) string {
@@ -29,3 +37,26 @@ func JoinOrDash(items ...string) string {
 	}
 	return strings.Join(items, ", ")
 }
+
+// FormatBytes renders a byte count using binary units (e.g. "1.5 MiB").
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// HumanBytes renders a byte count for display: FormatBytes by default, or the
+// plain integer when RawOutput is set.
+func HumanBytes(n int64) string {
+	if RawOutput {
+		return strconv.FormatInt(n, 10)
+	}
+	return FormatBytes(n)
+}
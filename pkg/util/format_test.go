@@ -24,3 +24,19 @@ func TestJoinOrDash(t *testing.T) {
 	assert.Equal(t, "a", JoinOrDash("a"))
 	assert.Equal(t, "-", JoinOrDash())
 }
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "0 B", FormatBytes(0))
+	assert.Equal(t, "1023 B", FormatBytes(1023))
+	assert.Equal(t, "1.0 KiB", FormatBytes(1024))
+	assert.Equal(t, "1.5 MiB", FormatBytes(1536*1024))
+	assert.Equal(t, "2.0 GiB", FormatBytes(2<<30))
+}
+
+func TestHumanBytes(t *testing.T) {
+	assert.Equal(t, "1.5 KiB", HumanBytes(1536))
+
+	RawOutput = true
+	t.Cleanup(func() { RawOutput = false })
+	assert.Equal(t, "1536", HumanBytes(1536))
+}
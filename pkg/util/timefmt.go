@@ -1,16 +1,54 @@
 package util
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // DefaultTimeLayout is the standard layout used for displaying timestamps.
 // Includes the local timezone abbreviation to make it clear times are local.
 const DefaultTimeLayout = "2006-01-02 15:04:05 MST"
 
-// FormatLocal formats the provided time in the user's local timezone.
-// If the time is zero, it returns "-".
+// FormatLocal formats the provided time in the user's local timezone, or as
+// RFC 3339 in UTC when RawOutput is set. If the time is zero, it returns "-".
 func FormatLocal(t time.Time) string {
 	if t.IsZero() {
 		return "-"
 	}
+	if RawOutput {
+		return t.UTC().Format(time.RFC3339)
+	}
 	return t.In(time.Local).Format(DefaultTimeLayout)
 }
+
+// FormatRelative describes t relative to now (e.g. "5m ago", "in 2h"), or
+// formats it as RFC 3339 when RawOutput is set. If the time is zero, it
+// returns "-".
+func FormatRelative(t time.Time) string {
+	return formatRelativeTo(t, time.Now())
+}
+
+func formatRelativeTo(t, now time.Time) string {
+	if t.IsZero() || RawOutput {
+		return FormatLocal(t)
+	}
+	d := now.Sub(t)
+	suffix := " ago"
+	prefix := ""
+	if d < 0 {
+		d = -d
+		prefix, suffix = "in ", ""
+	}
+	var amount string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		amount = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		amount = fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		amount = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	return prefix + amount + suffix
+}
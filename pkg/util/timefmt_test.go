@@ -0,0 +1,35 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatLocal(t *testing.T) {
+	assert.Equal(t, "-", FormatLocal(time.Time{}))
+
+	ts := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	assert.Equal(t, ts.In(time.Local).Format(DefaultTimeLayout), FormatLocal(ts))
+}
+
+func TestFormatRelative(t *testing.T) {
+	now := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, "-", formatRelativeTo(time.Time{}, now))
+	assert.Equal(t, "just now", formatRelativeTo(now.Add(-10*time.Second), now))
+	assert.Equal(t, "5m ago", formatRelativeTo(now.Add(-5*time.Minute), now))
+	assert.Equal(t, "3h ago", formatRelativeTo(now.Add(-3*time.Hour), now))
+	assert.Equal(t, "2d ago", formatRelativeTo(now.Add(-50*time.Hour), now))
+	assert.Equal(t, "in 2h", formatRelativeTo(now.Add(2*time.Hour), now))
+}
+
+func TestRawOutputTimes(t *testing.T) {
+	RawOutput = true
+	t.Cleanup(func() { RawOutput = false })
+
+	ts := time.Date(2025, 3, 4, 5, 6, 7, 0, time.FixedZone("PST", -8*3600))
+	assert.Equal(t, "2025-03-04T13:06:07Z", FormatLocal(ts))
+	assert.Equal(t, "2025-03-04T13:06:07Z", FormatRelative(ts))
+	assert.Equal(t, "-", FormatLocal(time.Time{}))
+}
@@ -11,11 +11,26 @@ import (
 	"github.com/boyter/gocodewalker"
 )
 
+// ZipEntry describes a file written to an archive by ZipDirectoryWithEntries.
+type ZipEntry struct {
+	// Path is the slash-separated path inside the archive.
+	Path string
+	// Size is the uncompressed size in bytes.
+	Size int64
+}
+
 // ZipDirectory compresses the given source directory into the destination file path.
 func ZipDirectory(srcDir, destZip string) error {
+	_, err := ZipDirectoryWithEntries(srcDir, destZip)
+	return err
+}
+
+// ZipDirectoryWithEntries is ZipDirectory but also returns the files it archived
+// (directory entries are omitted).
+func ZipDirectoryWithEntries(srcDir, destZip string) ([]ZipEntry, error) {
 	zipFile, err := os.Create(destZip)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer zipFile.Close()
 
@@ -35,12 +50,13 @@ func ZipDirectory(srcDir, destZip string) error {
 
 	// Track directories we've already added to the zip archive so we don't duplicate entries
 	dirsAdded := make(map[string]struct{})
+	var entries []ZipEntry
 
 	for f := range fileQueue {
 		// Compute path in archive using forward slashes
 		relPath, err := filepath.Rel(srcDir, f.Location)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		relPath = filepath.ToSlash(relPath)
 
@@ -57,7 +73,7 @@ func ZipDirectory(srcDir, destZip string) error {
 				}
 				if _, exists := dirsAdded[current+"/"]; !exists {
 					if _, err := zipWriter.Create(current + "/"); err != nil {
-						return err
+						return nil, err
 					}
 					dirsAdded[current+"/"] = struct{}{}
 				}
@@ -67,7 +83,7 @@ func ZipDirectory(srcDir, destZip string) error {
 		// Determine if the current path is a symbolic link so we can handle it properly
 		fileInfo, err := os.Lstat(f.Location)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		isSymlink := fileInfo.Mode()&os.ModeSymlink != 0
 
@@ -76,7 +92,7 @@ func ZipDirectory(srcDir, destZip string) error {
 			// Read the link target to store inside the archive
 			linkTarget, err := os.Readlink(f.Location)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// Prepare a custom header marking this entry as a symlink.
@@ -89,37 +105,46 @@ func ZipDirectory(srcDir, destZip string) error {
 
 			zipFileWriter, err := zipWriter.CreateHeader(hdr)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			if _, err := zipFileWriter.Write([]byte(linkTarget)); err != nil {
-				return err
+				return nil, err
 			}
+			entries = append(entries, ZipEntry{Path: relPath, Size: int64(len(linkTarget))})
 		} else {
 			zipFileWriter, err := zipWriter.Create(relPath)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			file, err := os.Open(f.Location)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			// Avoid deferring to reduce open FDs on huge trees
-			_, err = io.Copy(zipFileWriter, file)
+			n, err := io.Copy(zipFileWriter, file)
 			if closeErr := file.Close(); closeErr != nil {
-				return closeErr
+				return nil, closeErr
 			}
 			if err != nil {
-				return err
+				return nil, err
 			}
+			entries = append(entries, ZipEntry{Path: relPath, Size: n})
 		}
 	}
 
-	return nil
+	return entries, nil
 }
 
 // Unzip extracts a zip file to the specified directory
 func Unzip(zipFilePath, destDir string) error {
+	return UnzipStrip(zipFilePath, destDir, 0)
+}
+
+// UnzipStrip is Unzip, but drops the first strip path components from every
+// entry like tar --strip-components. Entries with no components left are
+// skipped.
+func UnzipStrip(zipFilePath, destDir string, strip int) error {
 	// Open the zip file
 	reader, err := zip.OpenReader(zipFilePath)
 	if err != nil {
@@ -133,8 +158,17 @@ func Unzip(zipFilePath, destDir string) error {
 	}
 	// Extract each file
 	for _, file := range reader.File {
+		name := file.Name
+		if strip > 0 {
+			parts := strings.Split(strings.Trim(name, "/"), "/")
+			if len(parts) <= strip {
+				continue
+			}
+			name = strings.Join(parts[strip:], "/")
+		}
+
 		// Create the full destination path
-		destPath := filepath.Join(destDir, file.Name)
+		destPath := filepath.Join(destDir, name)
 
 		// Check for directory traversal vulnerabilities
 		if !strings.HasPrefix(destPath, filepath.Clean(destDir)+string(os.PathSeparator)) {
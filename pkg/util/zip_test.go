@@ -0,0 +1,41 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipDirectoryWithEntries(t *testing.T) {
+	src := t.TempDir()
+	files := map[string]string{
+		"index.ts":           "export {}",
+		"lib/util.ts":        strings.Repeat("x", 100),
+		"lib/nested/deep.ts": "deep",
+		".gitignore":         "ignored.log\n",
+		"ignored.log":        "should not be archived",
+	}
+	for name, content := range files {
+		path := filepath.Join(src, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	entries, err := ZipDirectoryWithEntries(src, filepath.Join(t.TempDir(), "out.zip"))
+	require.NoError(t, err)
+
+	got := map[string]int64{}
+	for _, e := range entries {
+		got[e.Path] = e.Size
+	}
+	assert.Equal(t, map[string]int64{
+		"index.ts":           9,
+		"lib/util.ts":        100,
+		"lib/nested/deep.ts": 4,
+		".gitignore":         12,
+	}, got)
+}